@@ -0,0 +1,91 @@
+package geocoder
+
+import (
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// adaptiveRate implements additive increase/multiplicative decrease of the limiter rate
+type adaptiveRate struct {
+	mu sync.Mutex
+	// Lowest rate the limiter is slowed down to
+	min float64
+	// Consecutive successful requests needed to increase the rate by 1 rps
+	successesPerStep int
+	current          float64
+	successes        int
+}
+
+// WithAdaptiveRate makes the Geocoder halve its effective rate, down to minRPS, after every
+// OVER_QUERY_LIMIT or HTTP 429 response and increase it by 1 rps after successesPerStep
+// consecutive successful requests, up to the configured requests per second.
+// The cooldown after OVER_QUERY_LIMIT still applies
+func WithAdaptiveRate(minRPS float64, successesPerStep int) Option {
+	return func(g *Geocoder) {
+		if minRPS <= 0 || minRPS > float64(g.rps) {
+			minRPS = float64(g.rps)
+		}
+		if successesPerStep <= 0 {
+			successesPerStep = 1
+		}
+		g.adaptive = &adaptiveRate{min: minRPS, successesPerStep: successesPerStep, current: float64(g.rps)}
+	}
+}
+
+// adaptRate adjusts the limiter after a request, throttled is true if Google pushed back.
+// The request was limited by the pool credential member if it isn't nil
+func (g *Geocoder) adaptRate(member *poolMember, throttled bool) {
+	a, limiter, ceiling := g.adaptive, g.limiter, float64(g.GetRPS())
+	if member != nil {
+		a, limiter, ceiling = member.adaptive, member.limiter, float64(member.ceiling(g.GetRPS()))
+	}
+	if a == nil {
+		return
+	}
+	if limit, changed := a.adapt(throttled, ceiling); changed {
+		limiter.SetLimitAt(g.clock.Now(), rate.Limit(limit))
+	}
+}
+
+// adapt applies a request outcome and returns the new rate, changed is false if it stays the same
+func (a *adaptiveRate) adapt(throttled bool, ceiling float64) (limit float64, changed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prev := a.current
+	if throttled {
+		a.successes = 0
+		a.current /= 2
+		if floor := math.Min(a.min, ceiling); a.current < floor {
+			a.current = floor
+		}
+	} else {
+		a.successes++
+		if a.successes < a.successesPerStep {
+			return a.current, false
+		}
+		a.successes = 0
+		a.current++
+		if a.current > ceiling {
+			a.current = ceiling
+		}
+	}
+	return a.current, a.current != prev
+}
+
+// setCeiling caps the rate at ceiling and returns the new rate
+func (a *adaptiveRate) setCeiling(ceiling float64) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current > ceiling {
+		a.current = ceiling
+	}
+	return a.current
+}
+
+// effectiveRate returns the current rate of the limiter
+func (g *Geocoder) effectiveRate() float64 {
+	return float64(g.limiter.Limit())
+}
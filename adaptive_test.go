@@ -0,0 +1,109 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_AdaptiveRate(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second,
+		nil, WithAdaptiveRate(2, 3))
+
+	steps := []struct {
+		name      string
+		throttled bool
+		times     int
+		expected  float64
+	}{
+		{"Halve on throttling", true, 1, 5},
+		{"Respect minimum", true, 3, 2},
+		{"Not enough successes", false, 2, 2},
+		{"Increase after successes", false, 1, 3},
+		{"Respect maximum", false, 30, 10},
+	}
+
+	for _, tt := range steps {
+		for i := 0; i < tt.times; i++ {
+			geocoder.adaptRate(nil, tt.throttled)
+		}
+		if res := geocoder.effectiveRate(); res != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expected)
+		}
+	}
+}
+
+func Test_SetRPS(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second,
+		nil, WithAdaptiveRate(2, 1))
+
+	if err := geocoder.SetRPS(0); err == nil {
+		t.Errorf("test for SetRPS Failed - zero rate was accepted")
+	}
+	if err := geocoder.SetRPS(4); err != nil {
+		t.Fatal(err)
+	}
+	if geocoder.GetRPS() != 4 || geocoder.effectiveRate() != 4 {
+		t.Errorf("test for SetRPS Failed - results not match\nGot:\n%v %v\nExpected:\n4 4", geocoder.GetRPS(), geocoder.effectiveRate())
+	}
+	for i := 0; i < 10; i++ {
+		geocoder.adaptRate(nil, false)
+	}
+	if geocoder.effectiveRate() != 4 {
+		t.Errorf("test for SetRPS Failed - adaptive rate exceeded new maximum: %v", geocoder.effectiveRate())
+	}
+}
+
+func Test_WithBurst(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1, time.Second, nil, WithBurst(3))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("test for burst Failed - burst was throttled for %v", elapsed)
+	}
+}
+
+func Test_AdaptiveRatePool(t *testing.T) {
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second,
+		nil, WithAdaptiveRate(2, 1), WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "a"}, Credential{APIKey: "b", RPS: 20}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := geocoder.pool.members[0], geocoder.pool.members[1]
+
+	steps := []struct {
+		name     string
+		apply    func()
+		expected [2]float64
+	}{
+		{"Lower rate", func() { geocoder.SetRPS(4) }, [2]float64{4, 20}},
+		{"Back off pooled credential", func() { geocoder.adaptRate(b, true) }, [2]float64{4, 10}},
+		{"Raise rate below adaptive rate", func() { geocoder.SetRPS(8) }, [2]float64{4, 10}},
+		{"Recover pooled credentials", func() {
+			for i := 0; i < 20; i++ {
+				geocoder.adaptRate(a, false)
+				geocoder.adaptRate(b, false)
+			}
+		}, [2]float64{8, 20}},
+	}
+
+	for _, tt := range steps {
+		tt.apply()
+		if res := [2]float64{float64(a.limiter.Limit()), float64(b.limiter.Limit())}; res != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expected)
+		}
+	}
+	// the Geocoder limiter recovers with its own successes only
+	if res := geocoder.effectiveRate(); res != 4 {
+		t.Errorf("test for Geocoder limiter Failed - results not match\nGot:\n%v\nExpected:\n%v", res, 4)
+	}
+}
@@ -0,0 +1,135 @@
+package geocoder
+
+import "strings"
+
+// AddressTemplate lays an address out in lines of placeholders. Supported placeholders are
+// {house_number}, {street}, {premise}, {subpremise}, {neighborhood}, {city}, {county}, {state},
+// {postal_code}, {country} and {country_code}. Missing components are left out together with
+// the separators around them, lines which end up empty are dropped
+type AddressTemplate []string
+
+// DefaultAddressTemplate is used for countries without a template
+var DefaultAddressTemplate = AddressTemplate{
+	"{house_number} {street}",
+	"{postal_code} {city}",
+	"{country}",
+}
+
+// streetFirst is the layout of most of continental Europe, house number after the street
+var streetFirst = AddressTemplate{
+	"{street} {house_number}",
+	"{postal_code} {city}",
+	"{country}",
+}
+
+// AddressTemplates are the built-in templates by ISO 3166-1 alpha-2 country code
+var AddressTemplates = map[string]AddressTemplate{
+	"US": {"{house_number} {street}", "{subpremise}", "{city}, {state} {postal_code}", "{country}"},
+	"CA": {"{house_number} {street}", "{city} {state} {postal_code}", "{country}"},
+	"AU": {"{house_number} {street}", "{city} {state} {postal_code}", "{country}"},
+	"GB": {"{premise}", "{house_number} {street}", "{city}", "{postal_code}", "{country}"},
+	"IE": {"{house_number} {street}", "{city}", "{county}", "{postal_code}", "{country}"},
+	"FR": {"{house_number} {street}", "{postal_code} {city}", "{country}"},
+	"IT": {"{street} {house_number}", "{postal_code} {city} {state}", "{country}"},
+	"DE": streetFirst,
+	"AT": streetFirst,
+	"CH": streetFirst,
+	"NL": streetFirst,
+	"BE": streetFirst,
+	"ES": streetFirst,
+	"PT": streetFirst,
+	"PL": streetFirst,
+	"CZ": streetFirst,
+	"DK": streetFirst,
+	"SE": streetFirst,
+	"NO": streetFirst,
+	"FI": streetFirst,
+}
+
+// AddressFormatter renders address components of results with per-country templates
+type AddressFormatter struct {
+	templates map[string]AddressTemplate
+}
+
+// NewAddressFormatter creates new instance of AddressFormatter. Templates override
+// AddressTemplates for their countries
+func NewAddressFormatter(templates map[string]AddressTemplate) *AddressFormatter {
+	merged := make(map[string]AddressTemplate, len(AddressTemplates)+len(templates))
+	for country, t := range AddressTemplates {
+		merged[country] = t
+	}
+	for country, t := range templates {
+		merged[strings.ToUpper(country)] = t
+	}
+	return &AddressFormatter{templates: merged}
+}
+
+// FormatAddress renders the address of r in lines with the built-in templates
+func FormatAddress(r *ResultSet) []string {
+	return (&AddressFormatter{templates: AddressTemplates}).Format(r)
+}
+
+// Format renders the address of r in lines with the template of its country
+func (f *AddressFormatter) Format(r *ResultSet) []string {
+	values := addressValues(r)
+	template, ok := f.templates[values["{country_code}"]]
+	if !ok {
+		template = DefaultAddressTemplate
+	}
+	pairs := make([]string, 0, 2*len(values))
+	for placeholder, value := range values {
+		pairs = append(pairs, placeholder, value)
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	var lines []string
+	for _, t := range template {
+		line := strings.Join(strings.Fields(replacer.Replace(t)), " ")
+		line = strings.Trim(strings.ReplaceAll(line, " ,", ","), ", ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// addressPlaceholders maps placeholders to component types in order of preference and
+// whether the short name is used
+var addressPlaceholders = []struct {
+	placeholder string
+	types       []string
+	short       bool
+}{
+	{"{house_number}", []string{"street_number"}, false},
+	{"{street}", []string{"route"}, false},
+	{"{premise}", []string{"premise"}, false},
+	{"{subpremise}", []string{"subpremise"}, false},
+	{"{neighborhood}", []string{"neighborhood"}, false},
+	{"{city}", []string{"locality", "postal_town", "sublocality", "administrative_area_level_3"}, false},
+	{"{county}", []string{"administrative_area_level_2"}, false},
+	{"{state}", []string{"administrative_area_level_1"}, true},
+	{"{postal_code}", []string{"postal_code"}, false},
+	{"{country}", []string{"country"}, false},
+	{"{country_code}", []string{"country"}, true},
+}
+
+// addressValues returns the values of all placeholders of r, empty for missing components
+func addressValues(r *ResultSet) map[string]string {
+	values := make(map[string]string, len(addressPlaceholders))
+	for _, p := range addressPlaceholders {
+		values[p.placeholder] = ""
+	types:
+		for _, typ := range p.types {
+			for _, c := range r.AddressComponents {
+				if hasType(c.Types, typ) {
+					values[p.placeholder] = c.LongName
+					if p.short {
+						values[p.placeholder] = c.ShortName
+					}
+					break types
+				}
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,59 @@
+package geocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FormatAddress(t *testing.T) {
+	berlin := &ResultSet{AddressComponents: []AddressComponent{
+		{LongName: "1", ShortName: "1", Types: []string{"street_number"}},
+		{LongName: "Alexanderplatz", ShortName: "Alexanderpl.", Types: []string{"route"}},
+		{LongName: "Berlin", ShortName: "Berlin", Types: []string{"locality", "political"}},
+		{LongName: "Berlin", ShortName: "BE", Types: []string{"administrative_area_level_1", "political"}},
+		{LongName: "10178", ShortName: "10178", Types: []string{"postal_code"}},
+		{LongName: "Germany", ShortName: "DE", Types: []string{"country", "political"}},
+	}}
+	mountainView := &ResultSet{AddressComponents: []AddressComponent{
+		{LongName: "1600", ShortName: "1600", Types: []string{"street_number"}},
+		{LongName: "Amphitheatre Parkway", ShortName: "Amphitheatre Pkwy", Types: []string{"route"}},
+		{LongName: "Mountain View", ShortName: "Mountain View", Types: []string{"locality", "political"}},
+		{LongName: "California", ShortName: "CA", Types: []string{"administrative_area_level_1", "political"}},
+		{LongName: "94043", ShortName: "94043", Types: []string{"postal_code"}},
+		{LongName: "United States", ShortName: "US", Types: []string{"country", "political"}},
+	}}
+	noCity := &ResultSet{AddressComponents: []AddressComponent{
+		{LongName: "California", ShortName: "CA", Types: []string{"administrative_area_level_1", "political"}},
+		{LongName: "United States", ShortName: "US", Types: []string{"country", "political"}},
+	}}
+	unknown := &ResultSet{AddressComponents: []AddressComponent{
+		{LongName: "5", ShortName: "5", Types: []string{"street_number"}},
+		{LongName: "Main Road", ShortName: "Main Rd", Types: []string{"route"}},
+		{LongName: "Atlantis", ShortName: "XA", Types: []string{"country", "political"}},
+	}}
+	tests := []struct {
+		name      string
+		templates map[string]AddressTemplate
+		result    *ResultSet
+		expected  []string
+	}{
+		{"street first", nil, berlin, []string{"Alexanderplatz 1", "10178 Berlin", "Germany"}},
+		{"US", nil, mountainView, []string{"1600 Amphitheatre Parkway", "Mountain View, CA 94043", "United States"}},
+		{"missing components", nil, noCity, []string{"CA", "United States"}},
+		{"default template", nil, unknown, []string{"5 Main Road", "Atlantis"}},
+		{"override", map[string]AddressTemplate{"de": {"{street} {house_number}, {postal_code} {city}"}}, berlin,
+			[]string{"Alexanderplatz 1, 10178 Berlin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatAddress(tt.result)
+			if tt.templates != nil {
+				got = NewAddressFormatter(tt.templates).Format(tt.result)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !libpostal
+
+package addressparse
+
+func defaultParser() Parser {
+	return Heuristic{}
+}
@@ -0,0 +1,98 @@
+package addressparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Heuristic is a pure-Go Parser for comma separated addresses of the common western layouts,
+// e.g. "Alexanderplatz 1, 10178 Berlin, Germany" or "1600 Amphitheatre Pkwy, Mountain View, CA 94043".
+// It is an approximation, libpostal is much more accurate
+type Heuristic struct{}
+
+var _ Parser = Heuristic{}
+
+var (
+	// Postal codes of the UK, Canada, the Netherlands, 5 digit (US ZIP+4 included) and 4 digit countries
+	postalCodeRe = regexp.MustCompile(`(?i)\b([A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}|[A-Z]\d[A-Z] ?\d[A-Z]\d|\d{4} ?[A-Z]{2}|\d{5}(-\d{4})?|\d{4})\b`)
+	// "1600 Amphitheatre Pkwy"
+	numberFirstRe = regexp.MustCompile(`^(\d+[A-Za-z]?(-\d+[A-Za-z]?)?)\s+(.+)$`)
+	// "Alexanderplatz 1", "Via Roma 12b"
+	numberLastRe = regexp.MustCompile(`^(.+?)\s+(\d+[A-Za-z]?(-\d+[A-Za-z]?)?)$`)
+	// "Apt 4", "Suite 200", "Floor 3"
+	unitRe = regexp.MustCompile(`(?i)^(apt|apartment|suite|ste|unit|floor|fl)\.?\s+\S+$`)
+	// "CA", "NSW"
+	stateRe = regexp.MustCompile(`^[A-Z]{2,3}$`)
+)
+
+// Parse implements Parser
+func (Heuristic) Parse(address string) Address {
+	var a Address
+	var parts []string
+	for _, p := range strings.Split(address, ",") {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return a
+	}
+
+	if code := countryCode(parts[len(parts)-1]); code != "" && len(parts) > 1 {
+		a.Country, a.CountryCode = parts[len(parts)-1], code
+		parts = parts[:len(parts)-1]
+	}
+
+	// the postal code is looked for from the end, house numbers are at the beginning
+	postal := -1
+	for i := len(parts) - 1; i > 0 && postal < 0; i-- {
+		if loc := postalCodeRe.FindStringIndex(parts[i]); loc != nil {
+			a.PostalCode = parts[i][loc[0]:loc[1]]
+			rest := strings.TrimSpace(parts[i][:loc[0]] + " " + parts[i][loc[1]:])
+			switch {
+			case stateRe.MatchString(rest):
+				a.State = rest
+			case rest != "":
+				a.City = rest
+			}
+			postal = i
+		}
+	}
+
+	street := 0
+	if len(parts) > 1 && !hasDigit(parts[0]) && hasDigit(parts[1]) && postal != 1 {
+		a.House, street = parts[0], 1
+	}
+	if m := numberFirstRe.FindStringSubmatch(parts[street]); m != nil {
+		a.HouseNumber, a.Road = m[1], m[3]
+	} else if m := numberLastRe.FindStringSubmatch(parts[street]); m != nil {
+		a.Road, a.HouseNumber = m[1], m[2]
+	} else if len(parts) > 1 {
+		a.Road = parts[street]
+	} else {
+		// a single part without a number is a place name
+		a.City = parts[street]
+	}
+
+	for i := street + 1; i < len(parts); i++ {
+		switch {
+		case i == postal:
+		case unitRe.MatchString(parts[i]):
+			a.Unit = parts[i]
+		case a.City == "":
+			a.City = parts[i]
+		case a.State == "":
+			a.State = parts[i]
+		}
+	}
+	return a
+}
+
+// Normalize implements Parser
+func (Heuristic) Normalize(address string) string {
+	return normalizeTokens(address)
+}
+
+func hasDigit(s string) bool {
+	return strings.ContainsAny(s, "0123456789")
+}
@@ -0,0 +1,59 @@
+package addressparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_HeuristicParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Address
+	}{
+		{"street first", "Alexanderplatz 1, 10178 Berlin, Germany",
+			Address{HouseNumber: "1", Road: "Alexanderplatz", PostalCode: "10178", City: "Berlin", Country: "Germany", CountryCode: "DE"}},
+		{"number first with state", "1600 Amphitheatre Pkwy, Mountain View, CA 94043, USA",
+			Address{HouseNumber: "1600", Road: "Amphitheatre Pkwy", PostalCode: "94043", City: "Mountain View", State: "CA", Country: "USA", CountryCode: "US"}},
+		{"UK postcode", "10 Downing Street, London SW1A 2AA, UK",
+			Address{HouseNumber: "10", Road: "Downing Street", PostalCode: "SW1A 2AA", City: "London", Country: "UK", CountryCode: "GB"}},
+		{"building and unit", "Empire State Building, 350 5th Ave, Suite 300, New York, NY 10118",
+			Address{House: "Empire State Building", HouseNumber: "350", Road: "5th Ave", Unit: "Suite 300", PostalCode: "10118", City: "New York", State: "NY"}},
+		{"4 digit postal code", "Bundesplatz 3, 3005 Bern, CH",
+			Address{HouseNumber: "3", Road: "Bundesplatz", PostalCode: "3005", City: "Bern", Country: "CH", CountryCode: "CH"}},
+		{"place name", "Berlin", Address{City: "Berlin"}},
+		{"empty", " , ", Address{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Heuristic{}).Parse(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%+v\nExpected:\n%+v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_Dedup(t *testing.T) {
+	addresses := []string{
+		"1600 Amphitheatre Pkwy, Mountain View",
+		"1600 amphitheatre parkway mountain view",
+		"Müllerstr. 5, Berlin",
+		"Muellerstr 5 Berlin",
+		"Muellerstrasse 5, Berlin",
+	}
+	expected := []string{"1600 Amphitheatre Pkwy, Mountain View", "Müllerstr. 5, Berlin", "Muellerstrasse 5, Berlin"}
+	if got := Dedup(Heuristic{}, addresses); !reflect.DeepEqual(got, expected) {
+		t.Errorf("test for dedup Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
+
+func Test_ParseAddress(t *testing.T) {
+	p := &Preprocessor{Parser: Heuristic{}}
+	if _, components := p.ParseAddress("Alexanderplatz 1, Berlin, Germany"); !reflect.DeepEqual(components, map[string]string{"country": "DE"}) {
+		t.Errorf("test for country Failed - results not match\nGot:\n%v\nExpected:\n%v", components, "country:DE")
+	}
+	if _, components := p.ParseAddress("Alexanderplatz 1, Berlin"); components != nil {
+		t.Errorf("test for no country Failed - results not match\nGot:\n%v\nExpected:\n%v", components, nil)
+	}
+}
@@ -0,0 +1,110 @@
+//go:build libpostal
+
+package addressparse
+
+/*
+#cgo pkg-config: libpostal
+#include <stdlib.h>
+#include <libpostal/libpostal.h>
+*/
+import "C"
+
+import (
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Libpostal is a Parser backed by libpostal. Its models are loaded on the first use,
+// which takes a few seconds and about 2 GB of memory
+type Libpostal struct{}
+
+var _ Parser = Libpostal{}
+
+var (
+	setupOnce sync.Once
+	setupOK   bool
+	// libpostal is not documented to be thread-safe
+	libpostalMu sync.Mutex
+)
+
+func defaultParser() Parser {
+	return Libpostal{}
+}
+
+// setup loads the libpostal models, it reports false if they are missing
+func setup() bool {
+	setupOnce.Do(func() {
+		setupOK = bool(C.libpostal_setup()) && bool(C.libpostal_setup_parser()) &&
+			bool(C.libpostal_setup_language_classifier())
+	})
+	return setupOK
+}
+
+// Parse implements Parser. It falls back to Heuristic if the libpostal models are missing
+func (Libpostal) Parse(address string) Address {
+	if !setup() {
+		return Heuristic{}.Parse(address)
+	}
+	cAddress := C.CString(address)
+	defer C.free(unsafe.Pointer(cAddress))
+
+	libpostalMu.Lock()
+	defer libpostalMu.Unlock()
+	res := C.libpostal_parse_address(cAddress, C.libpostal_get_address_parser_default_options())
+	if res == nil {
+		return Address{}
+	}
+	defer C.libpostal_address_parser_response_destroy(res)
+
+	n := int(res.num_components)
+	components := unsafe.Slice(res.components, n)
+	labels := unsafe.Slice(res.labels, n)
+	var a Address
+	for i := 0; i < n; i++ {
+		value := C.GoString(components[i])
+		switch C.GoString(labels[i]) {
+		case "house":
+			a.House = value
+		case "house_number":
+			a.HouseNumber = value
+		case "road":
+			a.Road = value
+		case "unit", "level":
+			a.Unit = strings.TrimSpace(a.Unit + " " + value)
+		case "postcode":
+			a.PostalCode = value
+		case "city":
+			a.City = value
+		case "state":
+			a.State = value
+		case "country":
+			a.Country = value
+			a.CountryCode = countryCode(value)
+		}
+	}
+	return a
+}
+
+// Normalize implements Parser with the first libpostal expansion of address.
+// It falls back to Heuristic if the libpostal models are missing
+func (Libpostal) Normalize(address string) string {
+	if !setup() {
+		return Heuristic{}.Normalize(address)
+	}
+	cAddress := C.CString(address)
+	defer C.free(unsafe.Pointer(cAddress))
+
+	libpostalMu.Lock()
+	defer libpostalMu.Unlock()
+	var n C.size_t
+	expansions := C.libpostal_expand_address(cAddress, C.libpostal_get_default_options(), &n)
+	if expansions == nil {
+		return normalizeTokens(address)
+	}
+	defer C.libpostal_expansion_array_destroy(expansions, n)
+	if n == 0 {
+		return normalizeTokens(address)
+	}
+	return C.GoString(*expansions)
+}
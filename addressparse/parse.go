@@ -0,0 +1,185 @@
+// Package addressparse parses free-form address strings into components and normalizes them
+// for deduplication. By default a pure-Go heuristic is used. Building with the libpostal tag
+// switches to libpostal, which has to be installed with its data files:
+//
+//	go build -tags libpostal
+//
+// Preprocessor implements geocoder.AddressParser:
+//
+//	g, err := geocoder.NewGeocoder(..., geocoder.WithAddressParser(addressparse.Default()))
+package addressparse
+
+import "strings"
+
+// Address is a parsed address, fields missing in the input are empty
+type Address struct {
+	// Building or venue name
+	House       string
+	HouseNumber string
+	Road        string
+	// Apartment, suite or floor
+	Unit       string
+	PostalCode string
+	City       string
+	State      string
+	Country    string
+	// ISO 3166-1 alpha-2 code of Country, if known
+	CountryCode string
+}
+
+// Parser parses and normalizes addresses
+type Parser interface {
+	// Parse splits address into components
+	Parse(address string) Address
+	// Normalize returns a canonical form of address, equal for spelling variants
+	// like "Main St." and "main street"
+	Normalize(address string) string
+}
+
+// Default returns the parser of the build, libpostal with the libpostal tag, Heuristic otherwise
+func Default() *Preprocessor {
+	return &Preprocessor{Parser: defaultParser()}
+}
+
+// Preprocessor adapts a Parser to geocoder.AddressParser
+type Preprocessor struct {
+	Parser Parser
+}
+
+// ParseAddress implements geocoder.AddressParser. The address is sent to Google unchanged,
+// the detected country restricts the results
+func (p *Preprocessor) ParseAddress(address string) (string, map[string]string) {
+	parsed := p.Parser.Parse(address)
+	if parsed.CountryCode == "" {
+		return address, nil
+	}
+	return address, map[string]string{"country": parsed.CountryCode}
+}
+
+// Dedup returns addresses without those whose normalized form has been seen before,
+// keeping the first spelling
+func Dedup(p Parser, addresses []string) []string {
+	seen := make(map[string]bool, len(addresses))
+	var unique []string
+	for _, a := range addresses {
+		key := p.Normalize(a)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, a)
+	}
+	return unique
+}
+
+// countryCodes are the ISO codes of country names and their common spellings, lower case
+var countryCodes = map[string]string{
+	"australia":                "AU",
+	"austria":                  "AT",
+	"österreich":               "AT",
+	"belgium":                  "BE",
+	"belgië":                   "BE",
+	"belgique":                 "BE",
+	"canada":                   "CA",
+	"czech republic":           "CZ",
+	"czechia":                  "CZ",
+	"denmark":                  "DK",
+	"danmark":                  "DK",
+	"france":                   "FR",
+	"germany":                  "DE",
+	"deutschland":              "DE",
+	"ireland":                  "IE",
+	"italy":                    "IT",
+	"italia":                   "IT",
+	"netherlands":              "NL",
+	"the netherlands":          "NL",
+	"nederland":                "NL",
+	"norway":                   "NO",
+	"norge":                    "NO",
+	"poland":                   "PL",
+	"polska":                   "PL",
+	"portugal":                 "PT",
+	"spain":                    "ES",
+	"españa":                   "ES",
+	"sweden":                   "SE",
+	"sverige":                  "SE",
+	"switzerland":              "CH",
+	"schweiz":                  "CH",
+	"suisse":                   "CH",
+	"united kingdom":           "GB",
+	"uk":                       "GB",
+	"great britain":            "GB",
+	"england":                  "GB",
+	"united states":            "US",
+	"united states of america": "US",
+	"usa":                      "US",
+	"us":                       "US",
+}
+
+// countryCode returns the ISO code of country name, empty if unknown
+func countryCode(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if code, ok := countryCodes[name]; ok {
+		return code
+	}
+	// the code itself, except ambiguous ones like CA for California
+	upper := strings.ToUpper(name)
+	for _, code := range countryCodes {
+		if code == upper && code != "CA" {
+			return code
+		}
+	}
+	return ""
+}
+
+// normalizeTokens lower cases s, folds diacritics, expands abbreviations and drops punctuation
+func normalizeTokens(s string) string {
+	s = diacritics.Replace(strings.ToLower(s))
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	for i, f := range fields {
+		if expanded, ok := abbreviations[f]; ok {
+			fields[i] = expanded
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// abbreviations are expanded by normalizeTokens
+var abbreviations = map[string]string{
+	"st":   "street",
+	"str":  "strasse",
+	"ave":  "avenue",
+	"av":   "avenue",
+	"rd":   "road",
+	"blvd": "boulevard",
+	"dr":   "drive",
+	"ln":   "lane",
+	"pl":   "place",
+	"ct":   "court",
+	"sq":   "square",
+	"hwy":  "highway",
+	"pkwy": "parkway",
+	"apt":  "apartment",
+	"ste":  "suite",
+	"fl":   "floor",
+	"n":    "north",
+	"s":    "south",
+	"e":    "east",
+	"w":    "west",
+}
+
+// diacritics folds Latin letters with diacritics to ASCII
+var diacritics = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss",
+	"à", "a", "á", "a", "â", "a", "ã", "a", "å", "a",
+	"ç", "c", "č", "c", "ć", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ě", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ñ", "n", "ń", "n", "ň", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ů", "u",
+	"ý", "y", "ÿ", "y",
+	"ł", "l", "ř", "r", "š", "s", "ś", "s", "ž", "z", "ź", "z", "ż", "z",
+)
@@ -0,0 +1,47 @@
+package geocoder
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// AddressParser splits a free-form address before forward geocoding into the address sent
+// to Google and component filters by component type, e.g. {"country": "DE"}.
+// See package addressparse for implementations
+type AddressParser interface {
+	ParseAddress(address string) (string, map[string]string)
+}
+
+// WithAddressParser makes Geocode pass addresses through p and restrict results to the parsed
+// components, e.g. to the detected country
+func WithAddressParser(p AddressParser) Option {
+	return func(g *Geocoder) {
+		g.addressParser = p
+	}
+}
+
+// addressParams returns the query and the cache key of forward geocoding address
+func (g *Geocoder) addressParams(address string) (url.Values, string) {
+	query := url.Values{"address": {address}}
+	if g.addressParser == nil {
+		return query, "address:" + address
+	}
+	parsed, components := g.addressParser.ParseAddress(address)
+	if strings.TrimSpace(parsed) != "" {
+		query.Set("address", parsed)
+	}
+	filters := make([]string, 0, len(components))
+	for typ, value := range components {
+		if value != "" {
+			filters = append(filters, typ+":"+value)
+		}
+	}
+	sort.Strings(filters)
+	key := "address:" + query.Get("address")
+	if len(filters) > 0 {
+		query.Set("components", strings.Join(filters, "|"))
+		key += "|" + query.Get("components")
+	}
+	return query, key
+}
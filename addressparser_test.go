@@ -0,0 +1,44 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countryParser restricts every address to its country
+type countryParser string
+
+func (p countryParser) ParseAddress(address string) (string, map[string]string) {
+	return strings.TrimSuffix(address, ", Germany"), map[string]string{"country": string(p)}
+}
+
+func Test_AddressParser(t *testing.T) {
+	var query url.Values
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		query = u.Query()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://localhost", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithAddressParser(countryParser("DE")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Geocode(context.TODO(), "Alexanderplatz 1, Berlin, Germany"); err != nil {
+		t.Fatal(err)
+	}
+
+	for param, expected := range map[string]string{"address": "Alexanderplatz 1, Berlin", "components": "country:DE"} {
+		if got := query.Get(param); got != expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", param, got, expected)
+		}
+	}
+}
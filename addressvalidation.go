@@ -0,0 +1,141 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// defaultAddressValidationURL is the endpoint of the Address Validation API
+const defaultAddressValidationURL = "https://addressvalidation.googleapis.com/v1:validateAddress"
+
+// WithAddressValidationURL sends ValidateAddress requests to url instead of the Google endpoint,
+// e.g. to a proxy or a test server
+func WithAddressValidationURL(url string) Option {
+	return func(g *Geocoder) {
+		if url != "" {
+			g.addressValidationURL = url
+		}
+	}
+}
+
+// ConfirmationLevel tells how well an address component was confirmed by the Address Validation API
+type ConfirmationLevel string
+
+const (
+	CL_CONFIRMED                  ConfirmationLevel = "CONFIRMED"
+	CL_UNCONFIRMED_BUT_PLAUSIBLE  ConfirmationLevel = "UNCONFIRMED_BUT_PLAUSIBLE"
+	CL_UNCONFIRMED_AND_SUSPICIOUS ConfirmationLevel = "UNCONFIRMED_AND_SUSPICIOUS"
+)
+
+// PostalAddress is an address of the Address Validation API
+type PostalAddress struct {
+	// ISO 3166-1 alpha-2 country, e.g. US
+	RegionCode         string `json:"regionCode,omitempty"`
+	LanguageCode       string `json:"languageCode,omitempty"`
+	PostalCode         string `json:"postalCode,omitempty"`
+	AdministrativeArea string `json:"administrativeArea,omitempty"`
+	Locality           string `json:"locality,omitempty"`
+	Sublocality        string `json:"sublocality,omitempty"`
+	// Unstructured lines, e.g. a whole address in a single line
+	AddressLines []string `json:"addressLines,omitempty"`
+	Recipients   []string `json:"recipients,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+}
+
+// AddressValidationResponse is the response of the Address Validation API
+type AddressValidationResponse struct {
+	Result     AddressValidationResult `json:"result"`
+	ResponseID string                  `json:"responseId"`
+}
+
+// AddressValidationResult is the outcome of an address validation
+type AddressValidationResult struct {
+	Verdict AddressVerdict     `json:"verdict"`
+	Address ValidatedAddress   `json:"address"`
+	Geocode *ValidationGeocode `json:"geocode,omitempty"`
+}
+
+// AddressVerdict summarizes the quality of the validated address
+type AddressVerdict struct {
+	// Granularity of the input, the validated address and the geocode, e.g. PREMISE or ROUTE
+	InputGranularity      string `json:"inputGranularity"`
+	ValidationGranularity string `json:"validationGranularity"`
+	GeocodeGranularity    string `json:"geocodeGranularity"`
+	// The address has no unresolved tokens and no missing or unexpected components
+	AddressComplete          bool `json:"addressComplete"`
+	HasUnconfirmedComponents bool `json:"hasUnconfirmedComponents"`
+	HasInferredComponents    bool `json:"hasInferredComponents"`
+	HasReplacedComponents    bool `json:"hasReplacedComponents"`
+}
+
+// ValidatedAddress is the standardized address
+type ValidatedAddress struct {
+	FormattedAddress  string               `json:"formattedAddress"`
+	PostalAddress     PostalAddress        `json:"postalAddress"`
+	AddressComponents []ValidatedComponent `json:"addressComponents"`
+	// Component types expected but missing in the input, e.g. street_number
+	MissingComponentTypes     []string `json:"missingComponentTypes,omitempty"`
+	UnconfirmedComponentTypes []string `json:"unconfirmedComponentTypes,omitempty"`
+	// Parts of the input which couldn't be matched to any component
+	UnresolvedTokens []string `json:"unresolvedTokens,omitempty"`
+}
+
+// ValidatedComponent is a component of the standardized address
+type ValidatedComponent struct {
+	ComponentName     ComponentName     `json:"componentName"`
+	ComponentType     string            `json:"componentType"`
+	ConfirmationLevel ConfirmationLevel `json:"confirmationLevel"`
+	// Not in the input, but inferred from it
+	Inferred       bool `json:"inferred,omitempty"`
+	SpellCorrected bool `json:"spellCorrected,omitempty"`
+	// Replaced by a different value, e.g. a wrong postal code
+	Replaced bool `json:"replaced,omitempty"`
+	// Present in the input but not expected in the region
+	Unexpected bool `json:"unexpected,omitempty"`
+}
+
+// ComponentName is the value of a validated component
+type ComponentName struct {
+	Text         string `json:"text"`
+	LanguageCode string `json:"languageCode,omitempty"`
+}
+
+// ValidationGeocode is the location of the validated address
+type ValidationGeocode struct {
+	Location   LatLng   `json:"location"`
+	PlaceID    string   `json:"placeId"`
+	PlaceTypes []string `json:"placeTypes,omitempty"`
+}
+
+var apiAddressValidation = mapsAPI{usage: UsageAPIAddressValidation, rest: true}
+
+// ValidateAddress validates and standardizes address with the Address Validation API.
+// It requires API key authentication, ErrAPIKeyRequired is returned without one, errors are
+// reported as HTTPError. Requests are limited by the EP_ADDRESS_VALIDATION limiter and are not cached
+func (g *Geocoder) ValidateAddress(ctx context.Context, address PostalAddress) (*AddressValidationResponse, error) {
+	if len(address.AddressLines) == 0 {
+		return nil, errors.New("empty address lines")
+	}
+	body, err := json.Marshal(struct {
+		Address PostalAddress `json:"address"`
+	}{address})
+	if err != nil {
+		return nil, err
+	}
+	api := apiAddressValidation
+	api.url = g.addressValidationURL
+
+	res := &AddressValidationResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_ADDRESS_VALIDATION,
+		endpoint: EP_ADDRESS_VALIDATION,
+		api:      &api,
+		params:   url.Values{},
+		body:     body,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
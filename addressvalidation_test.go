@@ -0,0 +1,108 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ValidateAddress(t *testing.T) {
+	var method, requested, contentType string
+	var body map[string]PostalAddress
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		method, requested, contentType = req.Method, req.URL.String(), req.Header.Get("Content-Type")
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		res := `{"result":{"verdict":{"inputGranularity":"PREMISE","validationGranularity":"PREMISE",
+			"geocodeGranularity":"PREMISE","addressComplete":true,"hasInferredComponents":true},
+			"address":{"formattedAddress":"1600 Amphitheatre Parkway, Mountain View, CA 94043-1351, USA",
+			"addressComponents":[{"componentName":{"text":"1600"},"componentType":"street_number","confirmationLevel":"CONFIRMED"},
+			{"componentName":{"text":"94043"},"componentType":"postal_code","confirmationLevel":"CONFIRMED","inferred":true}]},
+			"geocode":{"location":{"latitude":37.4225,"longitude":-122.0847},"placeId":"ChIJ"}},"responseId":"id"}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(res))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithStatusErrors())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := PostalAddress{RegionCode: "US", AddressLines: []string{"1600 Amphitheatre Pkwy, Mountain View"}}
+	res, err := g.ValidateAddress(context.TODO(), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPost || contentType != "application/json" ||
+		requested != "https://addressvalidation.googleapis.com/v1:validateAddress?key=key" {
+		t.Errorf("test for request Failed - unexpected %v %v %v", method, requested, contentType)
+	}
+	if !reflect.DeepEqual(body["address"], address) {
+		t.Errorf("test for body Failed - results not match\nGot:\n%v\nExpected:\n%v", body["address"], address)
+	}
+	verdict := AddressVerdict{InputGranularity: "PREMISE", ValidationGranularity: "PREMISE", GeocodeGranularity: "PREMISE",
+		AddressComplete: true, HasInferredComponents: true}
+	if res.Result.Verdict != verdict || len(res.Result.Address.AddressComponents) != 2 ||
+		!res.Result.Address.AddressComponents[1].Inferred || res.Result.Geocode.PlaceID != "ChIJ" {
+		t.Errorf("test for response Failed - unexpected response %+v", res.Result)
+	}
+}
+
+func Test_ValidateAddressErrors(t *testing.T) {
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"error":{"code":400,"message":"Address lines are too long.","status":"INVALID_ARGUMENT"}}`
+		return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://localhost", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithAddressValidationURL("https://localhost/v1:validateAddress"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var he *HTTPError
+	if _, err := g.ValidateAddress(context.TODO(), PostalAddress{AddressLines: []string{"x"}}); !errors.As(err, &he) || he.StatusCode != http.StatusBadRequest {
+		t.Errorf("test for HTTP error Failed - unexpected error %v", err)
+	}
+
+	getOnly, err := NewGeocoder(nil, "https://localhost", "en", &fakeHttpRequester{responseBodyJSON: `{}`}, 1000,
+		time.Millisecond, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getOnly.ValidateAddress(context.TODO(), PostalAddress{AddressLines: []string{"x"}}); err == nil {
+		t.Errorf("test for GET only client Failed - expected error")
+	}
+
+	var sent int
+	counting := doerFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return client.Do(req)
+	})
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	business, err := NewGeocoder(bkey, "https://localhost", "en", counting, 1000, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := business.ValidateAddress(context.TODO(), PostalAddress{AddressLines: []string{"x"}}); !errors.Is(err, ErrAPIKeyRequired) || sent != 0 {
+		t.Errorf("test for BusinessKey Failed - results not match\nGot:\n%v %d requests\nExpected:\n%v", err, sent, ErrAPIKeyRequired)
+	}
+	// the API key credential of the pool is used, the BusinessKey one isn't
+	pooled, err := NewGeocoder(nil, "https://localhost", "en", counting, 1000, time.Millisecond, nil,
+		WithCredentialPool(PS_ROUND_ROBIN, Credential{BusinessKey: bkey}, Credential{APIKey: "key"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := pooled.ValidateAddress(context.TODO(), PostalAddress{AddressLines: []string{"x"}}); errors.As(err, &he) == errors.Is(err, ErrAPIKeyRequired) {
+			t.Errorf("test for pool Failed - unexpected error %v", err)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("test for pool Failed - results not match\nGot:\n%v\nExpected:\n%v", sent, 1)
+	}
+}
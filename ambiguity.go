@@ -0,0 +1,105 @@
+package geocoder
+
+import "sort"
+
+// locationTypeConfidence is the base confidence of a result by its geometry location type
+var locationTypeConfidence = map[string]float64{
+	LT_ROOFTOP:            1,
+	LT_RANGE_INTERPOLATED: 0.8,
+	LT_GEOMETRIC_CENTER:   0.6,
+	LT_APPROXIMATE:        0.4,
+}
+
+// partialMatchPenalty is applied to the confidence of partially matched results
+const partialMatchPenalty = 0.5
+
+// Candidate is one of the matches of an ambiguous forward geocoding response
+type Candidate struct {
+	Result *ResultSet
+	// Confidence in range [0, 1] based on the location type and partial_match flag
+	Confidence float64
+	// Components of Result whose values differ from other candidates, to be highlighted
+	// in a disambiguation picker
+	Differing []AddressComponent
+}
+
+// AmbiguityReport lists the top candidates of a forward geocoding response
+type AmbiguityReport struct {
+	// Ambiguous is true when more than one result was returned
+	Ambiguous  bool
+	Candidates []Candidate
+}
+
+// Ambiguity ranks the results by confidence and returns top n of them as candidates.
+// Results with equal confidence keep Google's order
+func (r *GoogleResponse) Ambiguity(n int) *AmbiguityReport {
+	candidates := make([]Candidate, 0, len(r.Results))
+	for _, res := range r.Results {
+		candidates = append(candidates, Candidate{Result: res, Confidence: Confidence(res)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	if n > 0 && len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	differing := differingComponentTypes(candidates)
+	for i := range candidates {
+		for _, c := range candidates[i].Result.AddressComponents {
+			if len(c.Types) > 0 && differing[c.Types[0]] {
+				candidates[i].Differing = append(candidates[i].Differing, c)
+			}
+		}
+	}
+
+	return &AmbiguityReport{Ambiguous: len(r.Results) > 1, Candidates: candidates}
+}
+
+// Confidence estimates how reliable the result is, in range [0, 1]
+func Confidence(r *ResultSet) float64 {
+	confidence, ok := locationTypeConfidence[r.Geometry.LocationType]
+	if !ok {
+		confidence = locationTypeConfidence[LT_APPROXIMATE]
+	}
+	if r.PartialMatch {
+		confidence *= partialMatchPenalty
+	}
+	return confidence
+}
+
+// differingComponentTypes returns primary component types whose long names are not
+// the same across all candidates, a missing component counts as a different value
+func differingComponentTypes(candidates []Candidate) map[string]bool {
+	values := make(map[string]map[string]bool)
+	for i, cand := range candidates {
+		seen := make(map[string]bool)
+		for _, c := range cand.Result.AddressComponents {
+			if len(c.Types) == 0 {
+				continue
+			}
+			typ := c.Types[0]
+			seen[typ] = true
+			if values[typ] == nil {
+				values[typ] = make(map[string]bool)
+				if i > 0 {
+					values[typ][""] = true
+				}
+			}
+			values[typ][c.LongName] = true
+		}
+		for typ := range values {
+			if !seen[typ] {
+				values[typ][""] = true
+			}
+		}
+	}
+
+	differing := make(map[string]bool)
+	for typ, vals := range values {
+		if len(vals) > 1 {
+			differing[typ] = true
+		}
+	}
+	return differing
+}
@@ -0,0 +1,57 @@
+package geocoder
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_Ambiguity(t *testing.T) {
+	body := `{"status":"OK","results":[
+		{"formatted_address":"Springfield, IL, USA","partial_match":true,
+		 "geometry":{"location_type":"APPROXIMATE"},
+		 "address_components":[{"long_name":"Springfield","types":["locality","political"]},{"long_name":"Illinois","types":["administrative_area_level_1","political"]},{"long_name":"United States","types":["country","political"]}]},
+		{"formatted_address":"Springfield, MA, USA",
+		 "geometry":{"location_type":"APPROXIMATE"},
+		 "address_components":[{"long_name":"Springfield","types":["locality","political"]},{"long_name":"Massachusetts","types":["administrative_area_level_1","political"]},{"long_name":"United States","types":["country","political"]}]},
+		{"formatted_address":"Springfield, MO 65806, USA",
+		 "geometry":{"location_type":"APPROXIMATE"},
+		 "address_components":[{"long_name":"Springfield","types":["locality","political"]},{"long_name":"Missouri","types":["administrative_area_level_1","political"]},{"long_name":"United States","types":["country","political"]},{"long_name":"65806","types":["postal_code"]}]}
+	]}`
+	var res GoogleResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	report := res.Ambiguity(2)
+	if !report.Ambiguous || len(report.Candidates) != 2 {
+		t.Fatalf("test for ambiguity Failed - unexpected report %+v", report)
+	}
+
+	tests := []struct {
+		name               string
+		candidate          Candidate
+		expectedAddress    string
+		expectedConfidence float64
+		expectedDiffering  []string
+	}{
+		{"First candidate", report.Candidates[0], "Springfield, MA, USA", 0.4, []string{"Massachusetts"}},
+		{"Second candidate", report.Candidates[1], "Springfield, MO 65806, USA", 0.4, []string{"Missouri", "65806"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var differing []string
+			for _, c := range tt.candidate.Differing {
+				differing = append(differing, c.LongName)
+			}
+			if tt.candidate.Result.FormattedAddress != tt.expectedAddress || tt.candidate.Confidence != tt.expectedConfidence {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v %v", tt.name,
+					tt.candidate.Result.FormattedAddress, tt.candidate.Confidence, tt.expectedAddress, tt.expectedConfidence)
+			}
+			if !reflect.DeepEqual(differing, tt.expectedDiffering) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, differing, tt.expectedDiffering)
+			}
+		})
+	}
+}
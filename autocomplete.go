@@ -0,0 +1,138 @@
+package geocoder
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var apiAutocomplete = &mapsAPI{path: "place/autocomplete", usage: UsageAPIPlaces}
+
+// SessionToken groups the autocomplete requests of one address entry and the place details
+// request concluding it into a single billed session. Use a new token for every session
+type SessionToken string
+
+// NewSessionToken returns a random version 4 UUID session token
+func NewSessionToken() SessionToken {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("geocoder: reading random bytes: " + err.Error())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return SessionToken(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]))
+}
+
+// AutocompleteOptions narrows down autocomplete predictions, all fields are optional
+type AutocompleteOptions struct {
+	// Place types or type collection, e.g. address or (cities)
+	Types string
+	// ISO 3166-1 alpha-2 countries the predictions are restricted to, up to 5
+	Countries []string
+	// Predictions near Location within Radius meters are preferred
+	Location *Coordinate
+	Radius   int
+	// Only return predictions within Location and Radius
+	StrictBounds bool
+	// Point DistanceMeters of predictions is measured from
+	Origin *Coordinate
+	// Position of the cursor in the input, e.g. to predict only the typed part
+	Offset int
+	// Output language overriding the Geocoder one
+	Language string
+}
+
+// AutocompleteResponse is the response of the Place Autocomplete API
+type AutocompleteResponse struct {
+	Predictions  []Prediction         `json:"predictions"`
+	Status       GoogleResponseStatus `json:"status"`
+	ErrorMessage string               `json:"error_message,omitempty"`
+}
+
+// Prediction is a place predicted for an autocomplete input
+type Prediction struct {
+	Description string `json:"description"`
+	PlaceID     string `json:"place_id"`
+	// Distance from AutocompleteOptions.Origin in meters, zero without origin
+	DistanceMeters       int                  `json:"distance_meters,omitempty"`
+	Types                []string             `json:"types"`
+	MatchedSubstrings    []MatchedSubstring   `json:"matched_substrings"`
+	StructuredFormatting StructuredFormatting `json:"structured_formatting"`
+	Terms                []PredictionTerm     `json:"terms"`
+}
+
+// MatchedSubstring is a part of a text matching the input, to be highlighted
+type MatchedSubstring struct {
+	Offset int `json:"offset"`
+	Length int `json:"length"`
+}
+
+// StructuredFormatting splits a prediction into the main text, e.g. the street address,
+// and the secondary text, e.g. the city
+type StructuredFormatting struct {
+	MainText                  string             `json:"main_text"`
+	MainTextMatchedSubstrings []MatchedSubstring `json:"main_text_matched_substrings"`
+	SecondaryText             string             `json:"secondary_text"`
+}
+
+// PredictionTerm is a part of the description
+type PredictionTerm struct {
+	Offset int    `json:"offset"`
+	Value  string `json:"value"`
+}
+
+// Autocomplete returns place predictions for input as typed by the user. Pass the same
+// session token to all requests of one address entry and to the concluding PlaceDetails,
+// an empty token bills every request separately. Requests are limited by the EP_PLACES limiter
+func (g *Geocoder) Autocomplete(ctx context.Context, input string, sessionToken SessionToken, opts AutocompleteOptions) (*AutocompleteResponse, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, errors.New("empty input")
+	}
+	params := url.Values{"input": {input}}
+	if sessionToken != "" {
+		params.Set("sessiontoken", string(sessionToken))
+	}
+	if opts.Types != "" {
+		params.Set("types", opts.Types)
+	}
+	if len(opts.Countries) > 0 {
+		countries := make([]string, len(opts.Countries))
+		for i, c := range opts.Countries {
+			countries[i] = "country:" + strings.ToLower(c)
+		}
+		params.Set("components", strings.Join(countries, "|"))
+	}
+	if opts.Location != nil {
+		params.Set("location", formatLatLng(opts.Location.Lat, opts.Location.Lng, g.precision))
+	}
+	if opts.Radius > 0 {
+		params.Set("radius", strconv.Itoa(opts.Radius))
+	}
+	if opts.StrictBounds {
+		params.Set("strictbounds", "true")
+	}
+	if opts.Origin != nil {
+		params.Set("origin", formatLatLng(opts.Origin.Lat, opts.Origin.Lng, g.precision))
+	}
+	if opts.Offset > 0 {
+		params.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if opts.Language != "" {
+		params.Set("language", opts.Language)
+	}
+
+	res := &AutocompleteResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_AUTOCOMPLETE,
+		endpoint: EP_PLACES,
+		api:      apiAutocomplete,
+		params:   params,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
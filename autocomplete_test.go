@@ -0,0 +1,73 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Autocomplete(t *testing.T) {
+	var requested *url.URL
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		requested = u
+		body := `{"status":"OK","predictions":[{"description":"Alexanderplatz, Berlin, Germany","place_id":"ChIJ",
+			"structured_formatting":{"main_text":"Alexanderplatz","secondary_text":"Berlin, Germany",
+			"main_text_matched_substrings":[{"offset":0,"length":4}]},"types":["route"],
+			"terms":[{"offset":0,"value":"Alexanderplatz"}],"matched_substrings":[{"offset":0,"length":4}]}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.Autocomplete(context.TODO(), "Alex", "token", AutocompleteOptions{
+		Types:     "address",
+		Countries: []string{"DE", "AT"},
+		Location:  &Coordinate{Lat: 52.52, Lng: 13.405},
+		Radius:    5000,
+		Language:  "de",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Predictions) != 1 || res.Predictions[0].StructuredFormatting.MainText != "Alexanderplatz" {
+		t.Errorf("test for predictions Failed - unexpected response %+v", res)
+	}
+
+	expected := map[string]string{
+		"input":        "Alex",
+		"sessiontoken": "token",
+		"types":        "address",
+		"components":   "country:de|country:at",
+		"location":     "52.52000000,13.40500000",
+		"radius":       "5000",
+		"language":     "de",
+	}
+	if requested.Path != "/maps/api/place/autocomplete/json" {
+		t.Errorf("test for path Failed - results not match\nGot:\n%v\nExpected:\n%v", requested.Path, "/maps/api/place/autocomplete/json")
+	}
+	for param, value := range expected {
+		if got := requested.Query().Get(param); got != value {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", param, got, value)
+		}
+	}
+}
+
+func Test_NewSessionToken(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first, second := NewSessionToken(), NewSessionToken()
+	if !uuid.MatchString(string(first)) || first == second {
+		t.Errorf("test for session token Failed - unexpected tokens %v, %v", first, second)
+	}
+}
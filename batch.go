@@ -0,0 +1,101 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ItemError is the failure of a single batch item
+type ItemError struct {
+	// Index of the item in the batch input, BatchResult.Index of BatchProcessor items
+	Index int
+	Point Coordinate
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d (%.8f,%.8f): %v", e.Index, e.Point.Lat, e.Point.Lng, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError aggregates failures of batch items. errors.Is and errors.As look into every item error
+type BatchError struct {
+	// Failed items ordered by index
+	Items []*ItemError
+}
+
+func (e *BatchError) Error() string {
+	return errors.Join(e.Unwrap()...).Error()
+}
+
+// Unwrap returns item errors
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item
+	}
+	return errs
+}
+
+// BatchPolicy tells whether a failed item stops a batch
+type BatchPolicy string
+
+const (
+	// Every item is processed whatever the failures, the default
+	BP_BEST_EFFORT BatchPolicy = "best_effort"
+	// The first failed item stops the batch, the items in flight are cancelled
+	BP_FAIL_FAST BatchPolicy = "fail_fast"
+)
+
+// WithBatchPolicy sets whether a failed item stops the batch, BP_BEST_EFFORT by default.
+// With BP_FAIL_FAST Process returns the *ItemError of the first failed item after emitting it
+func WithBatchPolicy(policy BatchPolicy) BatchOption {
+	return func(p *BatchProcessor) {
+		p.policy = policy
+	}
+}
+
+// ReverseGeocodeBatch reverse geocodes points with up to concurrency parallel requests.
+// Unless stopped by BP_FAIL_FAST, failed items don't stop the batch. Responses of successful items
+// are returned in input order, nil in place of failed and unprocessed ones, together with a
+// *BatchError describing the failures
+func ReverseGeocodeBatch(ctx context.Context, rg ReverseGeocoder, points []Coordinate, concurrency int, opts ...BatchOption) ([]*GoogleResponse, error) {
+	processor, err := NewBatchProcessor(rg, concurrency, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(chan BatchItem)
+	go func() {
+		defer close(items)
+		for _, p := range points {
+			select {
+			case items <- BatchItem{Lat: p.Lat, Lng: p.Lng}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]*GoogleResponse, len(points))
+	var batchErr BatchError
+	err = processor.Process(ctx, items, func(r BatchResult) error {
+		results[r.Index] = r.Response
+		if r.Err != nil {
+			batchErr.Items = append(batchErr.Items, &ItemError{Index: r.Index, Point: points[r.Index], Err: r.Err})
+		}
+		return nil
+	})
+	var itemErr *ItemError
+	if err != nil && !errors.As(err, &itemErr) {
+		return results, err
+	}
+	if len(batchErr.Items) > 0 {
+		return results, &batchErr
+	}
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errOcean = errors.New("ocean")
+
+type oceanReverseGeocoder struct{}
+
+func (oceanReverseGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	if lat < 0 {
+		return nil, errOcean
+	}
+	return &GoogleResponse{Status: GRS_OK}, nil
+}
+
+func Test_ReverseGeocodeBatch(t *testing.T) {
+	points := []Coordinate{{Lat: 1}, {Lat: -1}, {Lat: 2}, {Lat: -2}}
+	results, err := ReverseGeocodeBatch(context.TODO(), oceanReverseGeocoder{}, points, 3)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("test for batch Failed - unexpected error %v", err)
+	}
+	if len(batchErr.Items) != 2 || batchErr.Items[0].Index != 1 || batchErr.Items[1].Index != 3 {
+		t.Errorf("test for batch Failed - unexpected items %v", batchErr.Items)
+	}
+	if !errors.Is(err, errOcean) {
+		t.Errorf("test for batch Failed - item errors are not unwrapped")
+	}
+	if results[0] == nil || results[1] != nil || results[2] == nil || results[3] != nil {
+		t.Errorf("test for batch Failed - unexpected results %v", results)
+	}
+	expected := "item 1 (-1.00000000,0.00000000): ocean\nitem 3 (-2.00000000,0.00000000): ocean"
+	if err.Error() != expected {
+		t.Errorf("test for batch Failed - results not match\nGot:\n%v\nExpected:\n%v", err, expected)
+	}
+}
+
+func Test_ReverseGeocodeBatchFailFast(t *testing.T) {
+	points := []Coordinate{{Lat: 1}, {Lat: -1}, {Lat: 2}, {Lat: -2}}
+	results, err := ReverseGeocodeBatch(context.TODO(), oceanReverseGeocoder{}, points, 1, WithBatchPolicy(BP_FAIL_FAST))
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Items) != 1 || batchErr.Items[0].Index != 1 {
+		t.Fatalf("test for fail fast Failed - unexpected error %v", err)
+	}
+	if results[0] == nil || results[2] != nil || results[3] != nil {
+		t.Errorf("test for fail fast Failed - unexpected results %v", results)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build go1.23
+
+package geocoder
+
+import (
+	"context"
+	"iter"
+)
+
+// ReverseGeocodeAll reverse geocodes points with up to concurrency parallel requests and yields
+// the results in input order as soon as they are done, without buffering the whole batch.
+// The error of a failed item is yielded with its result and doesn't stop the batch. If processing
+// stops early, e.g. when ctx is done, the error is yielded last with a zero BatchResult.
+// Breaking out of the loop cancels the requests in flight
+func ReverseGeocodeAll(ctx context.Context, rg ReverseGeocoder, points iter.Seq[Coordinate], concurrency int) iter.Seq2[BatchResult, error] {
+	return func(yield func(BatchResult, error) bool) {
+		processor, err := NewBatchProcessor(rg, concurrency)
+		if err != nil {
+			yield(BatchResult{}, err)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		items := make(chan BatchItem)
+		go func() {
+			defer close(items)
+			for p := range points {
+				select {
+				case items <- BatchItem{Lat: p.Lat, Lng: p.Lng}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		results := make(chan BatchResult)
+		done := make(chan error, 1)
+		go func() {
+			done <- processor.Process(ctx, items, func(r BatchResult) error {
+				select {
+				case results <- r:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			close(results)
+		}()
+
+		for r := range results {
+			if !yield(r, r.Err) {
+				cancel()
+				for range results {
+				}
+				return
+			}
+		}
+		if err := <-done; err != nil {
+			yield(BatchResult{}, err)
+		}
+	}
+}
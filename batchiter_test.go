@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test_ReverseGeocodeAll(t *testing.T) {
+	points := []Coordinate{{Lat: 1}, {Lat: -1}, {Lat: 2}, {Lat: 3}}
+	tests := []struct {
+		name     string
+		stop     int
+		expected string
+	}{
+		{"all", -1, "[0:OK 1:ocean 2:OK 3:OK]"},
+		{"break", 2, "[0:OK 1:ocean]"},
+	}
+	for _, tt := range tests {
+		var got []string
+		for r, err := range ReverseGeocodeAll(context.TODO(), oceanReverseGeocoder{}, slices.Values(points), 2) {
+			if len(got) == tt.stop {
+				break
+			}
+			if errors.Is(err, errOcean) {
+				got = append(got, fmt.Sprintf("%d:ocean", r.Index))
+				continue
+			}
+			got = append(got, fmt.Sprintf("%d:%s", r.Index, r.Response.Status))
+		}
+		if fmt.Sprint(got) != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_ReverseGeocodeAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var last error
+	for _, err := range ReverseGeocodeAll(ctx, oceanReverseGeocoder{}, slices.Values([]Coordinate{{Lat: 1}}), 1) {
+		last = err
+	}
+	if !errors.Is(last, context.Canceled) {
+		t.Errorf("test for cancelled batch Failed - results not match\nGot:\n%v\nExpected:\n%v", last, context.Canceled)
+	}
+}
@@ -0,0 +1,187 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// batchWindowFactor bounds the number of items in flight or waiting to be emitted in order,
+// relative to the concurrency
+const batchWindowFactor = 4
+
+// BatchItem is a single input of BatchProcessor
+type BatchItem struct {
+	// Caller defined identifier, passed through to the result
+	ID string
+	// Address to geocode. If empty the coordinate is reverse geocoded
+	Address string
+	Lat     float64
+	Lng     float64
+}
+
+// BatchResult is the outcome of a single BatchItem
+type BatchResult struct {
+	Item BatchItem
+	// Index of the item among the processed items. Items skipped by WithCheckpointer don't
+	// advance it, so it is the position in the input stream only without a checkpointer
+	Index    int
+	Response *GoogleResponse
+	Err      error
+}
+
+// BatchProcessor geocodes a stream of items with bounded concurrency and emits results in input order
+type BatchProcessor struct {
+	reverse     ReverseGeocoder
+	concurrency int
+	// See WithCompletionHook
+	onComplete CompletionHook
+	// See WithBatchPolicy
+	policy BatchPolicy
+	// See WithCheckpointer
+	checkpointer Checkpointer
+}
+
+// NewBatchProcessor creates new instance of BatchProcessor. Address items require rg to implement
+// ForwardGeocoder, as Geocoder does
+func NewBatchProcessor(rg ReverseGeocoder, concurrency int, opts ...BatchOption) (*BatchProcessor, error) {
+	if rg == nil {
+		return nil, errors.New("empty ReverseGeocoder")
+	}
+	if concurrency <= 0 {
+		return nil, errors.New("concurrency must be a positive number")
+	}
+	p := &BatchProcessor{reverse: rg, concurrency: concurrency}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// Process geocodes items until the channel is closed and passes every result to emit in input order.
+// Item failures are reported in BatchResult.Err and don't stop processing, unless BP_FAIL_FAST is set.
+// Processing stops when ctx is done or emit returns an error, which is returned then. The completion
+// hook, if any, is called once processing ends
+func (p *BatchProcessor) Process(ctx context.Context, items <-chan BatchItem, emit func(BatchResult) error) error {
+	start := time.Now()
+	var summary BatchSummary
+	var skipped atomic.Int64
+	err := p.process(ctx, items, &skipped, func(r BatchResult) error {
+		summary.Processed++
+		if r.Err != nil {
+			summary.Failed++
+		}
+		if err := emit(r); err != nil {
+			return err
+		}
+		if p.checkpointer != nil && r.Err == nil && r.Item.ID != "" {
+			return p.checkpointer.Complete(r.Item.ID)
+		}
+		return nil
+	})
+	summary.Skipped = int(skipped.Load())
+	summary.Duration = time.Since(start)
+	return p.complete(ctx, summary, err)
+}
+
+func (p *BatchProcessor) process(ctx context.Context, items <-chan BatchItem, skipped *atomic.Int64, emit func(BatchResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		index int
+		item  BatchItem
+	}
+	jobs := make(chan job)
+	results := make(chan BatchResult)
+	window := make(chan struct{}, p.concurrency*batchWindowFactor)
+
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var item BatchItem
+			var ok bool
+			select {
+			case item, ok = <-items:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			if p.checkpointer != nil && item.ID != "" && p.checkpointer.Completed(item.ID) {
+				skipped.Add(1)
+				index--
+				continue
+			}
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			jobs <- job{index: index, item: item}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res, err := p.geocode(ctx, j.item)
+				results <- BatchResult{Item: j.item, Index: j.index, Response: res, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int]BatchResult)
+	next := 0
+	var emitErr error
+	for r := range results {
+		if emitErr != nil {
+			continue
+		}
+		pending[r.Index] = r
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+			if err := emit(res); err != nil {
+				emitErr = err
+				cancel()
+				break
+			}
+			if res.Err != nil && p.policy == BP_FAIL_FAST {
+				emitErr = &ItemError{Index: res.Index, Point: Coordinate{Lat: res.Item.Lat, Lng: res.Item.Lng}, Err: res.Err}
+				cancel()
+				break
+			}
+		}
+	}
+	if emitErr != nil {
+		return emitErr
+	}
+	return ctx.Err()
+}
+
+func (p *BatchProcessor) geocode(ctx context.Context, item BatchItem) (*GoogleResponse, error) {
+	if item.Address == "" {
+		return p.reverse.ReverseGeocode(ctx, item.Lat, item.Lng)
+	}
+	forward, ok := p.reverse.(ForwardGeocoder)
+	if !ok {
+		return nil, errors.New("address items are not supported by the ReverseGeocoder")
+	}
+	return forward.Geocode(ctx, item.Address)
+}
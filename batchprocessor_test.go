@@ -0,0 +1,65 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type slowReverseGeocoder struct{}
+
+// ReverseGeocode finishes earlier items later to shuffle the completion order
+func (slowReverseGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	time.Sleep(time.Duration(10-int(lat)) * time.Millisecond)
+	return &GoogleResponse{Status: GoogleResponseStatus(fmt.Sprint(lat))}, nil
+}
+
+func Test_BatchProcessor(t *testing.T) {
+	processor, err := NewBatchProcessor(slowReverseGeocoder{}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := make(chan BatchItem)
+	go func() {
+		defer close(items)
+		for i := 0; i < 10; i++ {
+			items <- BatchItem{ID: fmt.Sprint(i), Lat: float64(i)}
+		}
+	}()
+
+	var got []string
+	err = processor.Process(context.TODO(), items, func(r BatchResult) error {
+		got = append(got, fmt.Sprintf("%d:%s:%s", r.Index, r.Item.ID, r.Response.Status))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[0:0:0 1:1:1 2:2:2 3:3:3 4:4:4 5:5:5 6:6:6 7:7:7 8:8:8 9:9:9]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("test for order Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
+
+func Test_BatchProcessorAddress(t *testing.T) {
+	processor, err := NewBatchProcessor(oceanReverseGeocoder{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := make(chan BatchItem, 1)
+	items <- BatchItem{Address: "1600 Amphitheatre Parkway"}
+	close(items)
+
+	var got error
+	err = processor.Process(context.TODO(), items, func(r BatchResult) error {
+		got = r.Err
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Errorf("test for address without ForwardGeocoder Failed - expected an error")
+	}
+}
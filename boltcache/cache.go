@@ -0,0 +1,296 @@
+// Package boltcache implements geocoder.Cache on top of bbolt, an embedded key/value store,
+// so that a single node service keeps its cache across restarts
+package boltcache
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// entriesBucket maps cache keys to entries
+	entriesBucket = []byte("entries")
+	// orderBucket maps insertion sequence numbers to cache keys, oldest first
+	orderBucket = []byte("order")
+)
+
+// entryHeaderSize is the size of the expiration time and the sequence number preceding the response
+const entryHeaderSize = 16
+
+// compactTxMaxSize is the size of the transactions copying the database on Compact
+const compactTxMaxSize = 64 << 20
+
+// Options of Cache
+type Options struct {
+	// Time entries are kept for, entries never expire if zero
+	TTL time.Duration
+	// Maximum number of entries, the oldest ones are evicted beyond it. Unlimited if zero
+	MaxEntries int
+	// Called with errors of Set, which has no way to return them, optional
+	OnError func(error)
+	// Returns the current time entries expire against, e.g. a fake clock in tests. time.Now if nil
+	Now func() time.Time
+}
+
+// Cache is a disk backed geocoder.Cache. It is safe for concurrent use by the goroutines of a
+// single process, bbolt locks the file against other processes
+type Cache struct {
+	path string
+	opts Options
+
+	// Guards db against Compact, which replaces it
+	mu sync.RWMutex
+	db *bolt.DB
+
+	// Serializes writes keeping entries in line with the database
+	writeMu sync.Mutex
+	// Number of entries, expired ones included until Purge
+	entries   int
+	evictions int64
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+var (
+	_ geocoder.Cache              = (*Cache)(nil)
+	_ geocoder.CacheStatsReporter = (*Cache)(nil)
+)
+
+// Open opens or creates the cache database at path
+func Open(path string, opts Options) (*Cache, error) {
+	if opts.MaxEntries < 0 {
+		return nil, errors.New("MaxEntries must not be negative")
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	c := &Cache{path: path, opts: opts}
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cache) open() error {
+	db, err := bolt.Open(c.path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		entries, err := tx.CreateBucketIfNotExists(entriesBucket)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(orderBucket); err != nil {
+			return err
+		}
+		c.entries = entries.Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+	c.db = db
+	return nil
+}
+
+// Get returns the cached response for key
+func (c *Cache) Get(key string) (*geocoder.GoogleResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var res *geocoder.GoogleResponse
+	c.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(entriesBucket).Get([]byte(key))
+		if len(value) < entryHeaderSize || expired(value, c.opts.Now()) {
+			return nil
+		}
+		var decoded geocoder.GoogleResponse
+		if json.Unmarshal(value[entryHeaderSize:], &decoded) == nil {
+			res = &decoded
+		}
+		return nil
+	})
+	if res == nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return res, true
+}
+
+// Set stores res for key, evicting the oldest entries beyond MaxEntries
+func (c *Cache) Set(key string, res *geocoder.GoogleResponse) {
+	body, err := json.Marshal(res)
+	if err != nil {
+		c.fail(err)
+		return
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	added, evicted := 0, 0
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		entries, order := tx.Bucket(entriesBucket), tx.Bucket(orderBucket)
+		if old := entries.Get([]byte(key)); len(old) >= entryHeaderSize {
+			if err := order.Delete(old[8:entryHeaderSize]); err != nil {
+				return err
+			}
+		} else {
+			added++
+		}
+		seq, err := order.NextSequence()
+		if err != nil {
+			return err
+		}
+		var expires int64
+		if c.opts.TTL > 0 {
+			expires = c.opts.Now().Add(c.opts.TTL).UnixNano()
+		}
+		value := make([]byte, entryHeaderSize, entryHeaderSize+len(body))
+		binary.BigEndian.PutUint64(value, uint64(expires))
+		binary.BigEndian.PutUint64(value[8:], seq)
+		if err := order.Put(value[8:entryHeaderSize], []byte(key)); err != nil {
+			return err
+		}
+		if err := entries.Put([]byte(key), append(value, body...)); err != nil {
+			return err
+		}
+		if c.opts.MaxEntries > 0 {
+			var err error
+			evicted, err = evict(tx, c.entries+added-c.opts.MaxEntries, nil)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		c.fail(err)
+		return
+	}
+	c.entries += added - evicted
+	c.evictions += int64(evicted)
+}
+
+// Purge removes expired entries and returns their number
+func (c *Cache) Purge() (int, error) {
+	if c.opts.TTL <= 0 {
+		return 0, nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	now := c.opts.Now()
+	purged := 0
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		// entries expire in the order of insertion, all of them living for TTL
+		var err error
+		purged, err = evict(tx, c.entries, func(value []byte) bool { return expired(value, now) })
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	c.entries -= purged
+	c.evictions += int64(purged)
+	return purged, nil
+}
+
+// Compact rewrites the database file without the free pages left by removed entries.
+// bbolt never shrinks the file by itself. Requests wait while it runs
+func (c *Cache) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	tmpPath := c.path + ".compact"
+	dst, err := bolt.Open(tmpPath, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	if err := bolt.Compact(dst, c.db, compactTxMaxSize); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := c.db.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return errors.Join(err, c.open())
+	}
+	return c.open()
+}
+
+// Len returns the number of entries, expired ones included until Purge
+func (c *Cache) Len() int {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.entries
+}
+
+// CacheStats implements geocoder.CacheStatsReporter
+func (c *Cache) CacheStats() geocoder.CacheStats {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	hits, misses := c.hits.Load(), c.misses.Load()
+	stats := geocoder.CacheStats{Hits: hits, Misses: misses, Evictions: c.evictions, Entries: c.entries}
+	if hits+misses > 0 {
+		stats.HitRatio = float64(hits) / float64(hits+misses)
+	}
+	return stats
+}
+
+// Close closes the database
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.db.Close()
+}
+
+func (c *Cache) fail(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// evict removes up to n oldest entries as long as evictable, if not nil, returns true for them.
+// It returns the number of removed entries
+func evict(tx *bolt.Tx, n int, evictable func(value []byte) bool) (int, error) {
+	entries, order := tx.Bucket(entriesBucket), tx.Bucket(orderBucket)
+	cursor := order.Cursor()
+	removed := 0
+	for seq, key := cursor.First(); seq != nil && removed < n; seq, key = cursor.First() {
+		if evictable != nil && !evictable(entries.Get(key)) {
+			break
+		}
+		if err := entries.Delete(key); err != nil {
+			return removed, err
+		}
+		if err := cursor.Delete(); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// expired reports whether the entry value has expired at now
+func expired(value []byte, now time.Time) bool {
+	expires := int64(binary.BigEndian.Uint64(value))
+	return expires != 0 && now.UnixNano() > expires
+}
@@ -0,0 +1,103 @@
+package boltcache
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+func response(address string) *geocoder.GoogleResponse {
+	return &geocoder.GoogleResponse{
+		Status:  geocoder.GRS_OK,
+		Results: []*geocoder.ResultSet{{FormattedAddress: address, Types: []string{"street_address"}}},
+	}
+}
+
+func Test_CachePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("en|latlng:1,1", response("first"))
+	c.Set("en|latlng:1,1", response("second"))
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	res, ok := c.Get("en|latlng:1,1")
+	if !ok || !reflect.DeepEqual(res, response("second")) {
+		t.Errorf("test for reopened cache Failed - results not match\nGot:\n%v\nExpected:\n%v", res, response("second"))
+	}
+	if _, ok := c.Get("en|latlng:2,2"); ok {
+		t.Errorf("test for missing key Failed - unexpected hit")
+	}
+	if c.Len() != 1 {
+		t.Errorf("test for overwritten entry Failed - results not match\nGot:\n%v\nExpected:\n%v", c.Len(), 1)
+	}
+}
+
+func Test_CacheEviction(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "cache.db"), Options{MaxEntries: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	for i := 0; i < 5; i++ {
+		c.Set(strconv.Itoa(i), response(strconv.Itoa(i)))
+	}
+	// an overwritten entry becomes the newest one
+	c.Set("2", response("2"))
+	c.Set("5", response("5"))
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"0", false}, {"1", false}, {"3", false}, {"2", true}, {"4", true}, {"5", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if _, ok := c.Get(tt.key); ok != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.key, ok, tt.expected)
+			}
+		})
+	}
+	if c.Len() != 3 {
+		t.Errorf("test for size Failed - results not match\nGot:\n%v\nExpected:\n%v", c.Len(), 3)
+	}
+}
+
+func Test_CacheExpiration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c, err := Open(filepath.Join(t.TempDir(), "cache.db"), Options{TTL: time.Hour, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.Set("old", response("old"))
+	now = now.Add(90 * time.Minute)
+	c.Set("new", response("new"))
+
+	if _, ok := c.Get("old"); ok {
+		t.Errorf("test for expired entry Failed - unexpected hit")
+	}
+	if purged, err := c.Purge(); err != nil || purged != 1 {
+		t.Errorf("test for purge Failed - %d entries purged, error %v", purged, err)
+	}
+	if err := c.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("new"); !ok || c.Len() != 1 {
+		t.Errorf("test for compacted cache Failed - hit %v, %d entries", ok, c.Len())
+	}
+}
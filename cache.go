@@ -0,0 +1,127 @@
+package geocoder
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache stores geocoding responses by request key. Implementations must be safe for concurrent use.
+// Cached responses are shared between callers and must not be modified
+type Cache interface {
+	Get(key string) (*GoogleResponse, bool)
+	Set(key string, res *GoogleResponse)
+}
+
+// WithCache makes the Geocoder serve repeated requests from cache. Only OK responses are cached
+func WithCache(cache Cache) Option {
+	return func(g *Geocoder) {
+		g.cache = cache
+	}
+}
+
+// MemoryCache is an in-memory LRU Cache with entry expiration. Entries expire on the clock of
+// the Geocoder it is passed to, see WithClock
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	clock      Clock
+	ll         *list.List
+	entries    map[string]*list.Element
+	hits       int64
+	misses     int64
+	evictions  int64
+}
+
+type memoryCacheEntry struct {
+	key     string
+	res     *GoogleResponse
+	expires time.Time
+}
+
+// NewMemoryCache creates new instance of MemoryCache holding up to maxEntries responses for ttl.
+// Zero ttl means entries never expire
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		clock:      systemClock{},
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached response for key
+func (c *MemoryCache) Get(key string) (*GoogleResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if !entry.expires.IsZero() && c.clock.Now().After(entry.expires) {
+		c.remove(el)
+		c.misses++
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	c.hits++
+	return entry.res, true
+}
+
+// Set stores res under key, evicting the least recently used entry if the cache is full
+func (c *MemoryCache) Set(key string, res *GoogleResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.clock.Now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.res, entry.expires = res, expires
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.ll.PushFront(&memoryCacheEntry{key: key, res: res, expires: expires})
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		c.remove(c.ll.Back())
+	}
+}
+
+// useClock makes entries expire on clock
+func (c *MemoryCache) useClock(clock Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clock
+}
+
+// Len returns the number of cached entries, including expired ones not evicted yet
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// CacheStats implements CacheStatsReporter
+func (c *MemoryCache) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.ll.Len(),
+		HitRatio:  hitRatio(c.hits, c.misses),
+	}
+}
+
+// remove evicts the entry of el
+func (c *MemoryCache) remove(el *list.Element) {
+	c.evictions++
+	c.ll.Remove(el)
+	delete(c.entries, el.Value.(*memoryCacheEntry).key)
+}
@@ -0,0 +1,94 @@
+package geocoder
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_MemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2, time.Hour)
+	cache.Set("a", &GoogleResponse{Status: GRS_OK})
+	cache.Set("b", &GoogleResponse{Status: GRS_OK})
+	cache.Get("a")
+	cache.Set("c", &GoogleResponse{Status: GRS_OK})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("test for LRU eviction Failed - least recently used entry was kept")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("test for LRU eviction Failed - recently used entry was evicted")
+	}
+
+	expiring := NewMemoryCache(0, time.Nanosecond)
+	expiring.Set("a", &GoogleResponse{Status: GRS_OK})
+	time.Sleep(time.Millisecond)
+	if _, ok := expiring.Get("a"); ok {
+		t.Errorf("test for expiration Failed - expired entry was returned")
+	}
+}
+
+func Test_WithCache(t *testing.T) {
+	observer := &recordingObserver{}
+	client := &flakyHttpRequester{responseBodyJSON: `{"status":"OK"}`}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second,
+		observer, WithCache(NewMemoryCache(10, time.Hour)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(observer.infos) != 2 || observer.infos[0].Cached || !observer.infos[1].Cached {
+		t.Errorf("test for cache Failed - unexpected observations %+v", observer.infos)
+	}
+}
+
+// manualClock is a Clock whose time only moves when set, its timers are real
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	return systemClock{}.NewTimer(d)
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func Test_MemoryCacheClock(t *testing.T) {
+	clock := &manualClock{now: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := NewMemoryCache(10, time.Hour)
+	if _, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second,
+		nil, WithAPIKey("key"), WithCache(cache), WithClock(clock)); err != nil {
+		t.Fatal(err)
+	}
+	cache.Set("a", &GoogleResponse{Status: GRS_OK})
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		expected bool
+	}{
+		{"Fresh entry", 59 * time.Minute, true},
+		{"Expired entry", 2 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		clock.Advance(tt.advance)
+		if _, ok := cache.Get("a"); ok != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, ok, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package geocoder
+
+import "sync/atomic"
+
+// CacheStats are the statistics of a cache
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	// Entries removed because the cache was full or they expired
+	Evictions int64
+	// Entries currently stored, expired ones may be included until evicted
+	Entries int
+	// Hits / (Hits + Misses), 0 before the first lookup
+	HitRatio float64
+}
+
+// CacheStatsReporter is implemented by caches reporting their statistics, e.g. MemoryCache
+type CacheStatsReporter interface {
+	CacheStats() CacheStats
+}
+
+// CacheObserver is an optional extension of RequestObserver. When the observer passed to
+// NewGeocoder implements it and a cache is configured, the Geocoder registers its CacheStats
+// function, to be polled by the observer e.g. when metrics are collected
+type CacheObserver interface {
+	RequestObserver
+	ObserveCacheStats(label string, stats func() CacheStats)
+}
+
+// cacheCounter counts lookups of the caches of a Geocoder
+type cacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func (c *cacheCounter) record(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// CacheStats returns the statistics of the cache lookups made by the Geocoder, negative cache
+// included. Evictions and Entries are reported if the cache implements CacheStatsReporter
+func (g *Geocoder) CacheStats() CacheStats {
+	var stats CacheStats
+	if reporter, ok := g.cache.(CacheStatsReporter); ok {
+		stats = reporter.CacheStats()
+	}
+	stats.Hits, stats.Misses = g.cacheLookups.hits.Load(), g.cacheLookups.misses.Load()
+	stats.HitRatio = hitRatio(stats.Hits, stats.Misses)
+	return stats
+}
+
+func hitRatio(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
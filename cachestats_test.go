@@ -0,0 +1,29 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_CacheStats(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithCache(NewMemoryCache(2, time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := g.CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("test for empty stats Failed - results not match\nGot:\n%+v\nExpected:\n%+v", stats, CacheStats{})
+	}
+	for _, c := range []Coordinate{{Lat: 1, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}, {Lat: 3, Lng: 3}, {Lat: 3, Lng: 3}} {
+		if _, err := g.ReverseGeocode(context.TODO(), c.Lat, c.Lng); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := CacheStats{Hits: 2, Misses: 3, Evictions: 1, Entries: 2, HitRatio: 0.4}
+	if stats := g.CacheStats(); stats != expected {
+		t.Errorf("test for stats Failed - results not match\nGot:\n%+v\nExpected:\n%+v", stats, expected)
+	}
+}
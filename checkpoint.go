@@ -0,0 +1,126 @@
+package geocoder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Checkpointer records the batch items completed so far, so that a batch run resumes after a
+// crash without geocoding them again. Items are identified by BatchItem.ID
+type Checkpointer interface {
+	// Completed reports whether the item was completed by an earlier run
+	Completed(id string) bool
+	// Complete records the item as completed
+	Complete(id string) error
+}
+
+// WithCheckpointer makes BatchProcessor skip the items completed according to cp and record
+// the items it completes. Failed items and items without an ID are never recorded. Skipped items are not
+// emitted and don't count in BatchResult.Index, they are reported in BatchSummary.Skipped
+func WithCheckpointer(cp Checkpointer) BatchOption {
+	return func(p *BatchProcessor) {
+		p.checkpointer = cp
+	}
+}
+
+// FileCheckpointer is a Checkpointer keeping the IDs of completed items in a file, one JSON
+// string per line. It is safe for concurrent use
+type FileCheckpointer struct {
+	mu        sync.Mutex
+	f         *os.File
+	completed map[string]bool
+}
+
+var _ Checkpointer = (*FileCheckpointer)(nil)
+
+// OpenFileCheckpointer opens the checkpoint file at path, creating it if needed, and loads
+// the items completed so far. A line cut off by a crash is dropped
+func OpenFileCheckpointer(path string) (*FileCheckpointer, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	if complete < len(data) {
+		if err := f.Truncate(int64(complete)); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	if _, err := f.Seek(int64(complete), io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	c := &FileCheckpointer{f: f, completed: make(map[string]bool)}
+	scanner := bufio.NewScanner(bytes.NewReader(data[:complete]))
+	for line := 1; scanner.Scan(); line++ {
+		var id string
+		if err := json.Unmarshal(scanner.Bytes(), &id); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("reading checkpoint %s line %d: %w", path, line, err)
+		}
+		c.completed[id] = true
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *FileCheckpointer) Completed(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.completed[id]
+}
+
+func (c *FileCheckpointer) Complete(id string) error {
+	line, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return errors.New("checkpointer is closed")
+	}
+	if c.completed[id] {
+		return nil
+	}
+	if _, err := c.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	c.completed[id] = true
+	return nil
+}
+
+// Len returns the number of completed items
+func (c *FileCheckpointer) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.completed)
+}
+
+// Close closes the checkpoint file
+func (c *FileCheckpointer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return nil
+	}
+	err := c.f.Close()
+	c.f = nil
+	return err
+}
@@ -0,0 +1,78 @@
+package geocoder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_FileCheckpointer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	items := []BatchItem{{ID: "a", Lat: 1}, {ID: "b", Lat: -1}, {ID: "c", Lat: 2}, {Lat: 3}}
+	tests := []struct {
+		name      string
+		processed []string
+		skipped   int
+	}{
+		{"first run", []string{"a", "b", "c", ""}, 0},
+		{"resumed run", []string{"b", ""}, 2},
+	}
+	for _, tt := range tests {
+		cp, err := OpenFileCheckpointer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var summary BatchSummary
+		processor, err := NewBatchProcessor(oceanReverseGeocoder{}, 2, WithCheckpointer(cp),
+			WithCompletionHook(func(ctx context.Context, s BatchSummary) error {
+				summary = s
+				return nil
+			}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		in := make(chan BatchItem, len(items))
+		for _, item := range items {
+			in <- item
+		}
+		close(in)
+		var processed []string
+		err = processor.Process(context.TODO(), in, func(r BatchResult) error {
+			processed = append(processed, r.Item.ID)
+			return nil
+		})
+		cp.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(processed, tt.processed) || summary.Skipped != tt.skipped {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v %v", tt.name, processed, summary.Skipped, tt.processed, tt.skipped)
+		}
+	}
+}
+
+func Test_FileCheckpointerTruncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	if err := os.WriteFile(path, []byte("\"a\"\n\"b\"\n\"c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := OpenFileCheckpointer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Complete("d"); err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+	cp, err = OpenFileCheckpointer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	got := []bool{cp.Completed("a"), cp.Completed("b"), cp.Completed("c"), cp.Completed("d")}
+	if expected := []bool{true, true, false, true}; !reflect.DeepEqual(got, expected) || cp.Len() != 3 {
+		t.Errorf("test for truncated checkpoint Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
@@ -0,0 +1,233 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling Google while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker
+type CircuitState string
+
+const (
+	// Requests are sent
+	CS_CLOSED CircuitState = "closed"
+	// Requests fail with ErrCircuitOpen
+	CS_OPEN CircuitState = "open"
+	// A limited number of probe requests is sent to decide whether to close the circuit
+	CS_HALF_OPEN CircuitState = "half_open"
+)
+
+// CircuitBreakerConfig describes when the circuit breaker opens and how it recovers.
+// Transport errors and HTTP 5xx statuses are failures, any Google response is a success
+type CircuitBreakerConfig struct {
+	// Opens after this many consecutive failures, disabled if zero
+	ConsecutiveFailures int
+	// Opens when the share of failures among the last Window requests reaches ErrorRate, disabled if zero
+	ErrorRate float64
+	// Number of requests ErrorRate is computed over, 20 if zero
+	Window int
+	// Time the circuit stays open before probing, 30 seconds if zero
+	OpenDuration time.Duration
+	// Successful probes which close the circuit, also the limit of concurrent probes, 1 if zero
+	HalfOpenProbes int
+	// Called on every state change, optional
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker makes requests fail fast with ErrCircuitOpen while Google is failing,
+// instead of waiting for the limiter and timing out
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(g *Geocoder) {
+		if config.Window <= 0 {
+			config.Window = 20
+		}
+		if config.OpenDuration <= 0 {
+			config.OpenDuration = 30 * time.Second
+		}
+		if config.HalfOpenProbes <= 0 {
+			config.HalfOpenProbes = 1
+		}
+		g.breaker = &circuitBreaker{config: config, state: CS_CLOSED, outcomes: make([]bool, config.Window)}
+	}
+}
+
+// circuitOutcome classifies an attempt for the circuit breaker
+type circuitOutcome int
+
+const (
+	outcomeSuccess circuitOutcome = iota
+	outcomeFailure
+	// Attempt tells nothing about Google, e.g. it was cancelled by the caller
+	outcomeIgnored
+)
+
+// circuitBreaker is the state of CircuitBreakerConfig, methods are no-op on nil
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu    sync.Mutex
+	state CircuitState
+	// Incremented on every state change, outcomes of requests allowed in another state are dropped
+	generation uint64
+	// Consecutive failures in the closed state
+	failures int
+	// Ring buffer of the last requests in the closed state, true is a failure
+	outcomes []bool
+	next     int
+	filled   int
+	openedAt time.Time
+	// Probes in flight and succeeded in the half-open state
+	probes    int
+	successes int
+}
+
+// allow reports whether a request may be sent now, ErrCircuitOpen otherwise.
+// Every allowed request has to be followed by record with the returned generation
+func (b *circuitBreaker) allow(now time.Time) (uint64, error) {
+	if b == nil {
+		return 0, nil
+	}
+	b.mu.Lock()
+	from := b.state
+	var err error
+	if b.state == CS_OPEN && now.Sub(b.openedAt) >= b.config.OpenDuration {
+		b.setState(CS_HALF_OPEN)
+	}
+	switch {
+	case b.state == CS_OPEN:
+		err = ErrCircuitOpen
+	case b.state == CS_HALF_OPEN && b.probes+b.successes >= b.config.HalfOpenProbes:
+		err = ErrCircuitOpen
+	case b.state == CS_HALF_OPEN:
+		b.probes++
+	}
+	generation := b.generation
+	b.unlock(from)
+	return generation, err
+}
+
+// record accounts the outcome of a request allowed in generation
+func (b *circuitBreaker) record(now time.Time, generation uint64, outcome circuitOutcome) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	from := b.state
+	if generation == b.generation {
+		switch b.state {
+		case CS_HALF_OPEN:
+			b.probes--
+			switch outcome {
+			case outcomeFailure:
+				b.open(now)
+			case outcomeSuccess:
+				if b.successes++; b.successes >= b.config.HalfOpenProbes {
+					b.setState(CS_CLOSED)
+				}
+			}
+		case CS_CLOSED:
+			if outcome != outcomeIgnored {
+				b.recordClosed(now, outcome == outcomeFailure)
+			}
+		}
+	}
+	b.unlock(from)
+}
+
+// recordClosed accounts an outcome in the closed state, b.mu must be held
+func (b *circuitBreaker) recordClosed(now time.Time, failed bool) {
+	b.outcomes[b.next] = failed
+	b.next = (b.next + 1) % len(b.outcomes)
+	if b.filled < len(b.outcomes) {
+		b.filled++
+	}
+	if failed {
+		b.failures++
+	} else {
+		b.failures = 0
+	}
+	if b.tripped() {
+		b.open(now)
+	}
+}
+
+// unlock releases b.mu and reports the state change since from to OnStateChange
+func (b *circuitBreaker) unlock(from CircuitState) {
+	to := b.state
+	b.mu.Unlock()
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
+
+// tripped reports whether the failures in the closed state exceed the thresholds, b.mu must be held
+func (b *circuitBreaker) tripped() bool {
+	if b.config.ConsecutiveFailures > 0 && b.failures >= b.config.ConsecutiveFailures {
+		return true
+	}
+	if b.config.ErrorRate <= 0 || b.filled < len(b.outcomes) {
+		return false
+	}
+	failed := 0
+	for _, f := range b.outcomes {
+		if f {
+			failed++
+		}
+	}
+	return float64(failed)/float64(len(b.outcomes)) >= b.config.ErrorRate
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.openedAt = now
+	b.setState(CS_OPEN)
+}
+
+// setState switches to state resetting the counters, b.mu must be held
+func (b *circuitBreaker) setState(state CircuitState) {
+	b.state = state
+	b.generation++
+	b.failures, b.probes, b.successes = 0, 0, 0
+	b.next, b.filled = 0, 0
+	for i := range b.outcomes {
+		b.outcomes[i] = false
+	}
+}
+
+// current returns the current state, CS_CLOSED on nil
+func (b *circuitBreaker) current() CircuitState {
+	if b == nil {
+		return CS_CLOSED
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// classifyOutcome tells whether err of an attempt is a failure of Google
+func classifyOutcome(ctx context.Context, err error) circuitOutcome {
+	if err == nil {
+		return outcomeSuccess
+	}
+	var te *transportError
+	if errors.As(err, &te) {
+		if ctx.Err() != nil {
+			return outcomeIgnored
+		}
+		return outcomeFailure
+	}
+	var he *HTTPError
+	if errors.As(err, &he) {
+		if he.StatusCode >= http.StatusInternalServerError {
+			return outcomeFailure
+		}
+		return outcomeSuccess
+	}
+	// the request was not sent or the response could not be decoded
+	return outcomeIgnored
+}
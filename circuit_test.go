@@ -0,0 +1,87 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyClient answers with the HTTP statuses in turn, then with statuses[len(statuses)-1]
+func flakyClient(requests *int, statuses ...int) HttpRequester {
+	return requesterFunc(func(string) (*http.Response, error) {
+		status := statuses[len(statuses)-1]
+		if *requests < len(statuses) {
+			status = statuses[*requests]
+		}
+		*requests++
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+	})
+}
+
+func Test_CircuitBreaker(t *testing.T) {
+	const fail, ok = http.StatusInternalServerError, http.StatusOK
+	tests := []struct {
+		name     string
+		config   CircuitBreakerConfig
+		statuses []int
+		// Number of requests which reach Google before the circuit opens
+		sent int
+	}{
+		{"consecutive failures", CircuitBreakerConfig{ConsecutiveFailures: 2}, []int{fail, ok, fail, fail, ok}, 4},
+		{"error rate", CircuitBreakerConfig{ErrorRate: 0.5, Window: 4}, []int{fail, ok, fail, ok, ok}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var changes []CircuitState
+			tt.config.OpenDuration = 20 * time.Millisecond
+			tt.config.OnStateChange = func(from, to CircuitState) { changes = append(changes, to) }
+			g, err := NewGeocoder(nil, "https://localhost", "en", flakyClient(&requests, tt.statuses...), 1000,
+				time.Millisecond, nil, WithAPIKey("key"), WithCircuitBreaker(tt.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < tt.sent; i++ {
+				g.ReverseGeocode(context.TODO(), 1, 1)
+			}
+			if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, ErrCircuitOpen) || requests != tt.sent {
+				t.Fatalf("test for %v Failed - error %v after %d requests", tt.name, err, requests)
+			}
+			if state := g.Stats().Circuit; state != CS_OPEN {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, state, CS_OPEN)
+			}
+
+			time.Sleep(30 * time.Millisecond)
+			if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+				t.Fatalf("test for %v probe Failed - %v", tt.name, err)
+			}
+			if expected := []CircuitState{CS_OPEN, CS_HALF_OPEN, CS_CLOSED}; !reflect.DeepEqual(changes, expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, changes, expected)
+			}
+		})
+	}
+}
+
+func Test_CircuitBreakerFailedProbe(t *testing.T) {
+	var requests int
+	g, err := NewGeocoder(nil, "https://localhost", "en", flakyClient(&requests, http.StatusBadGateway), 1000,
+		time.Millisecond, nil, WithAPIKey("key"),
+		WithCircuitBreaker(CircuitBreakerConfig{ConsecutiveFailures: 1, OpenDuration: 20 * time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.ReverseGeocode(context.TODO(), 1, 1)
+	time.Sleep(30 * time.Millisecond)
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("test for probe Failed - probe was not sent")
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, ErrCircuitOpen) || requests != 2 {
+		t.Errorf("test for failed probe Failed - error %v after %d requests", err, requests)
+	}
+}
@@ -0,0 +1,165 @@
+package geocoder
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_DefaultHTTPRequesterGzip(t *testing.T) {
+	body := `{"status":"OK","results":[{"formatted_address":"` + strings.Repeat("Unter den Linden, ", 100) + `Berlin"}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			io.WriteString(w, body)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		io.WriteString(zw, body)
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		opts HTTPClientOptions
+	}{
+		{"gzip", HTTPClientOptions{}},
+		{"plain", HTTPClientOptions{DisableGzip: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewDefaultHTTPRequester(tt.opts)
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Uncompressed != !tt.opts.DisableGzip {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, resp.Uncompressed, !tt.opts.DisableGzip)
+			}
+			resp.Body.Close()
+
+			g, err := NewGeocoder(nil, srv.URL+"/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil, WithAPIKey("key"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(res.Results[0].FormattedAddress, "Berlin") {
+				t.Errorf("test for %v Failed - unexpected address %q", tt.name, res.Results[0].FormattedAddress)
+			}
+		})
+	}
+}
+
+func Test_DefaultHTTPRequesterProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host+r.URL.Path)
+		io.WriteString(w, `{"status":"OK","results":[{"formatted_address":"Berlin"}]}`)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewDefaultHTTPRequester(HTTPClientOptions{Proxy: proxyURL})
+	g, err := NewGeocoder(nil, "http://maps.example.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "maps.example.com/maps/api/geocode/json"; len(proxied) != 1 || proxied[0] != expected {
+		t.Errorf("test for proxy Failed - results not match\nGot:\n%v\nExpected:\n%v", proxied, expected)
+	}
+}
+
+func Test_DefaultHTTPRequesterTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			http.Error(w, "client certificate required", http.StatusForbidden)
+			return
+		}
+		io.WriteString(w, `{"status":"OK","results":[{"formatted_address":"Berlin"}]}`)
+	}))
+	// the server certificate doubles as the client certificate
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	clientCert := srv.TLS.Certificates[0]
+
+	tests := []struct {
+		name   string
+		config *tls.Config
+		ok     bool
+	}{
+		{"system roots", nil, false},
+		{"custom CA without client certificate", &tls.Config{RootCAs: roots}, false},
+		{"mTLS", &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}, MinVersion: tls.VersionTLS12}, true},
+	}
+	for _, tt := range tests {
+		client := NewDefaultHTTPRequester(HTTPClientOptions{TLSConfig: tt.config})
+		g, err := NewGeocoder(nil, srv.URL+"/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil, WithAPIKey("key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.ReverseGeocode(context.TODO(), 1, 1); (err == nil) != tt.ok {
+			t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+		}
+	}
+}
+
+func Test_DefaultHTTPRequesterSettings(t *testing.T) {
+	type settings struct {
+		handshake, header, idle time.Duration
+		idlePerHost, perHost    int
+		http2                   bool
+	}
+	tests := []struct {
+		name     string
+		opts     HTTPClientOptions
+		expected settings
+	}{
+		{"defaults", HTTPClientOptions{}, settings{5 * time.Second, 10 * time.Second, 90 * time.Second, 64, 0, true}},
+		{"custom", HTTPClientOptions{TLSHandshakeTimeout: time.Second, ResponseHeaderTimeout: 2 * time.Second, IdleConnTimeout: 3 * time.Second,
+			MaxIdleConnsPerHost: 8, MaxConnsPerHost: 16, DisableHTTP2: true}, settings{time.Second, 2 * time.Second, 3 * time.Second, 8, 16, false}},
+	}
+	for _, tt := range tests {
+		transport := NewDefaultHTTPRequester(tt.opts).client.Transport.(*http.Transport)
+		got := settings{transport.TLSHandshakeTimeout, transport.ResponseHeaderTimeout, transport.IdleConnTimeout,
+			transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.ForceAttemptHTTP2}
+		if got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%+v\nExpected:\n%+v", tt.name, got, tt.expected)
+		}
+		if transport.DialContext == nil || transport.MaxIdleConns < transport.MaxIdleConnsPerHost {
+			t.Errorf("test for %v Failed - unexpected dialer or idle connection limit %v", tt.name, transport.MaxIdleConns)
+		}
+	}
+
+	// a server not sending headers in time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+	client := NewDefaultHTTPRequester(HTTPClientOptions{ResponseHeaderTimeout: 20 * time.Millisecond})
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Errorf("test for response header timeout Failed - expected error")
+	}
+}
@@ -0,0 +1,128 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Clock is the source of time of a Geocoder: cooldowns, rate limiting, retry backoff, hedging,
+// the circuit breaker, quotas, QuotaTracker.Budget and the expiration of MemoryCache entries,
+// including the negative and degradation caches, follow it. See geocodertest.FakeClock for a manually advanced one
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a timer firing once after d
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer of a Clock
+type Timer interface {
+	// C returns the channel the time is sent to when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, false if it has fired or been stopped already
+	Stop() bool
+}
+
+// WithClock makes the Geocoder use c instead of the system clock, e.g. to test cooldowns
+// and rate limiting without sleeping
+func WithClock(c Clock) Option {
+	return func(g *Geocoder) {
+		if c != nil {
+			g.clock = c
+		}
+	}
+}
+
+// shareClock makes the MemoryCache entries of the Geocoder expire on its clock and its
+// QuotaTracker count days on it
+func (g *Geocoder) shareClock() {
+	caches := []Cache{g.cache}
+	if g.negative != nil {
+		for _, c := range g.negative.caches {
+			caches = append(caches, c)
+		}
+	}
+	if g.degradation != nil {
+		caches = append(caches, g.degradation.Cache)
+	}
+	for _, c := range caches {
+		if c, ok := c.(*MemoryCache); ok {
+			c.useClock(g.clock)
+		}
+	}
+	if g.quota != nil {
+		g.quota.useClock(g.clock)
+	}
+}
+
+// systemClock is the Clock of the time package
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+// sleep waits for d on the clock or until ctx is done
+func (g *Geocoder) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := g.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// waitLimiters is limiter.Wait(ctx) of every limiter on the clock of the Geocoder, it returns
+// the time the limiters made the caller wait. Tokens are reserved from all limiters at once, so
+// they are handed back to every limiter if a wait fails. A wait outlasting the ctx deadline fails
+// right away with ErrWouldExceedDeadline. Nil limiters are skipped
+func (g *Geocoder) waitLimiters(ctx context.Context, limiters ...*rate.Limiter) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	now := g.clock.Now()
+	reserved := make([]*rate.Reservation, 0, len(limiters))
+	cancel := func(at time.Time) {
+		for _, r := range reserved {
+			r.CancelAt(at)
+		}
+	}
+	var delay time.Duration
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		r := limiter.ReserveN(now, 1)
+		if !r.OK() {
+			cancel(now)
+			return 0, fmt.Errorf("rate: Wait(n=1) exceeds limiter's burst %d", limiter.Burst())
+		}
+		reserved = append(reserved, r)
+		delay = max(delay, r.DelayFrom(now))
+	}
+	// ctx deadlines are wall clock times, whatever the clock of the Geocoder
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		cancel(now)
+		return 0, fmt.Errorf("%w: wait %v, deadline in %v", ErrWouldExceedDeadline, delay, time.Until(deadline))
+	}
+	if err := g.sleep(ctx, delay); err != nil {
+		cancel(g.clock.Now())
+		return 0, err
+	}
+	return delay, nil
+}
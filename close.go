@@ -0,0 +1,107 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by calls made after Close
+var ErrClosed = errors.New("geocoder is closed")
+
+// lifecycle counts in-flight calls of a Geocoder and rejects new ones once closed
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight int
+	drained  chan struct{}
+	// Done when Close gives up waiting, in-flight calls are cancelled
+	abort  context.Context
+	cancel context.CancelFunc
+}
+
+// enter registers a call, false after Close
+func (l *lifecycle) enter() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return false
+	}
+	if l.abort == nil {
+		l.abort, l.cancel = context.WithCancel(context.Background())
+	}
+	l.inflight++
+	return true
+}
+
+// leave unregisters a call
+func (l *lifecycle) leave() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if l.closed && l.inflight == 0 {
+		close(l.drained)
+	}
+}
+
+// close rejects new calls and returns a channel closed once the in-flight calls are done
+func (l *lifecycle) close() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	if l.drained == nil {
+		l.drained = make(chan struct{})
+		if l.inflight == 0 {
+			close(l.drained)
+		}
+	}
+	return l.drained
+}
+
+// context returns ctx cancelled also when Close gives up waiting
+func (l *lifecycle) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	l.mu.Lock()
+	abort := l.abort
+	l.mu.Unlock()
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(abort, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// Close stops accepting calls, which fail with ErrClosed, and waits for the in-flight ones
+// to finish, including asynchronous calls and pages of searches. If ctx is done first the
+// in-flight calls are cancelled and ctx.Err() is returned. Idle connections of the
+// HttpRequester are closed if it has CloseIdleConnections, like *http.Client.
+// Caches and stores given in options are left open. Closing again is a no-op
+func (g *Geocoder) Close(ctx context.Context) error {
+	drained := g.lifecycle.close()
+	defer g.closeIdleConnections()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		g.lifecycle.mu.Lock()
+		if g.lifecycle.cancel != nil {
+			g.lifecycle.cancel()
+		}
+		g.lifecycle.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// closeIdleConnections closes idle keep-alive connections of the client if it supports it
+func (g *Geocoder) closeIdleConnections() {
+	client := g.client
+	if legacy, ok := client.(legacyDoer); ok {
+		if c, ok := legacy.HttpRequester.(interface{ CloseIdleConnections() }); ok {
+			c.CloseIdleConnections()
+		}
+		return
+	}
+	if c, ok := client.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
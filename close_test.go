@@ -0,0 +1,77 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Close(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+	})
+	newGeocoder := func() *Geocoder {
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+			WithAPIKey("key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	// in-flight calls finish, new ones are rejected
+	g := newGeocoder()
+	reverse := g.ReverseGeocodeAsync(context.TODO(), 52.52, 13.40)
+	async := g.GeocodeAsync(context.TODO(), "Berlin")
+	<-started
+	<-started
+	closed := make(chan error, 1)
+	go func() { closed <- g.Close(context.TODO()) }()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := g.Geocode(context.TODO(), "Hamburg"); !errors.Is(err, ErrClosed) {
+		t.Errorf("test for call after Close Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrClosed)
+	}
+	select {
+	case err := <-closed:
+		t.Fatalf("test for drain Failed - Close returned %v before in-flight calls finished", err)
+	default:
+	}
+	close(release)
+	if err := <-closed; err != nil {
+		t.Errorf("test for drain Failed - unexpected error %v", err)
+	}
+	for _, f := range []*Future{reverse, async} {
+		if _, err := f.Get(); err != nil {
+			t.Errorf("test for in-flight call Failed - unexpected error %v", err)
+		}
+	}
+	if err := g.Close(context.TODO()); err != nil {
+		t.Errorf("test for second Close Failed - unexpected error %v", err)
+	}
+
+	// in-flight calls are cancelled once the Close deadline passes
+	release = make(chan struct{})
+	g = newGeocoder()
+	f := g.ReverseGeocodeAsync(context.TODO(), 52.52, 13.40)
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("test for Close deadline Failed - results not match\nGot:\n%v\nExpected:\n%v", err, context.DeadlineExceeded)
+	}
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Errorf("test for aborted call Failed - results not match\nGot:\n%v\nExpected:\n%v", err, context.Canceled)
+	}
+}
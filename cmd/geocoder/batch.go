@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alvillain/geocoder"
+)
+
+// batchColumns are appended to the input columns in the output
+var batchColumns = []string{"status", "formatted_address", "place_id", "error"}
+
+func batchCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), `Usage: geocoder batch --in coords.csv --out results.csv [flags]
+
+Reverse geocodes the lat,lng pair in the first two columns of every input row. Output rows
+repeat the input row followed by `+strings.Join(batchColumns, ",")+`. A header row is detected
+and copied. Rows are written in input order as soon as they are done, so an interrupted run
+resumes after the last row found in the output file. With --webhook a JSON summary with the
+processed, failed and skipped rows, the duration and the output path is posted when the run ends.
+
+Flags:`)
+		fs.PrintDefaults()
+	}
+	var client clientFlags
+	client.register(fs)
+	in := fs.String("in", "", "input CSV file")
+	out := fs.String("out", "", "output CSV file, appended to when resuming")
+	concurrency := fs.Int("concurrency", 4, "number of parallel requests")
+	webhook := fs.String("webhook", "", "URL the JSON summary of the run is posted to when it finishes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" || *out == "" {
+		fs.Usage()
+		return errors.New("-in and -out are required")
+	}
+
+	g, err := client.geocoder()
+	if err != nil {
+		return err
+	}
+
+	input, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	output, done, err := openCheckpoint(*out)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	if done > 0 {
+		fmt.Fprintf(os.Stderr, "resuming after %d rows of %s\n", done, *out)
+	}
+
+	var summary geocoder.BatchSummary
+	written, err := runBatch(ctx, g, input, output, done, *concurrency,
+		geocoder.WithCompletionHook(func(ctx context.Context, s geocoder.BatchSummary) error {
+			summary = s
+			return nil
+		}))
+	fmt.Fprintf(os.Stderr, "%d rows written to %s\n", written, *out)
+	if err == nil {
+		err = output.Close()
+	}
+	if *webhook != "" {
+		summary.Skipped = done
+		summary.Output, _ = filepath.Abs(*out)
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		if hookErr := geocoder.NewWebhook(*webhook, nil)(context.WithoutCancel(ctx), summary); hookErr != nil {
+			fmt.Fprintf(os.Stderr, "webhook failed: %v\n", hookErr)
+		}
+	}
+	return err
+}
+
+// openCheckpoint opens the output file for appending and returns the number of complete rows it has.
+// A row cut off by an interruption is truncated
+func openCheckpoint(path string) (*os.File, int, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	if complete < len(data) {
+		if err := f.Truncate(int64(complete)); err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+	}
+	if _, err := f.Seek(int64(complete), io.SeekStart); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(data[:complete])).ReadAll()
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("reading checkpoint %s: %w", path, err)
+	}
+	return f, len(records), nil
+}
+
+// runBatch reverse geocodes the input rows, skipping the first skip rows already present in the output.
+// Every input row, including the header, maps to exactly one output row, so the number of rows
+// in the output is the checkpoint. It returns the number of rows written
+func runBatch(ctx context.Context, rg geocoder.ReverseGeocoder, in io.Reader, out io.Writer, skip, concurrency int, opts ...geocoder.BatchOption) (int, error) {
+	processor, err := geocoder.NewBatchProcessor(rg, concurrency, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	reader := csv.NewReader(bufio.NewReader(in))
+	reader.FieldsPerRecord = -1
+	writer := csv.NewWriter(out)
+
+	var mu sync.Mutex
+	records := make(map[int][]string)
+	written := 0
+
+	// the first row is either the header or held back to be geocoded
+	var first []string
+	if skip == 0 {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if _, _, err := parseRow(record); err != nil {
+			if err := writeRow(writer, append(record, batchColumns...)); err != nil {
+				return 0, err
+			}
+			written++
+			skip = 1
+		} else {
+			first = record
+		}
+	}
+	for i := 0; i < skip-written; i++ {
+		if _, err := reader.Read(); err != nil {
+			if err == io.EOF {
+				return 0, nil
+			}
+			return 0, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var readErr error
+	items := make(chan geocoder.BatchItem)
+	go func() {
+		defer close(items)
+		for index := 0; ; index++ {
+			record, err := first, error(nil)
+			if first != nil {
+				first = nil
+			} else {
+				record, err = reader.Read()
+			}
+			if err == io.EOF {
+				return
+			}
+			line := skip + index + 1
+			if err == nil {
+				var lat, lng float64
+				lat, lng, err = parseRow(record)
+				if err == nil {
+					mu.Lock()
+					records[index] = record
+					mu.Unlock()
+					select {
+					case items <- geocoder.BatchItem{ID: strconv.Itoa(line), Lat: lat, Lng: lng}:
+						continue
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			// rows before the broken one are still written, fix the input and run again to resume
+			readErr = fmt.Errorf("row %d: %w", line, err)
+			return
+		}
+	}()
+
+	err = processor.Process(ctx, items, func(r geocoder.BatchResult) error {
+		if ctx.Err() != nil && errors.Is(r.Err, ctx.Err()) {
+			// interrupted, leave the row for the next run
+			return ctx.Err()
+		}
+		mu.Lock()
+		record := records[r.Index]
+		delete(records, r.Index)
+		mu.Unlock()
+		if err := writeRow(writer, append(record, resultColumns(r)...)); err != nil {
+			return err
+		}
+		written++
+		return nil
+	})
+	cancel()
+	if err != nil {
+		return written, err
+	}
+	return written, readErr
+}
+
+func parseRow(record []string) (float64, float64, error) {
+	if len(record) < 2 {
+		return 0, 0, errors.New("expected lat,lng in the first two columns")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lat, lng, nil
+}
+
+func resultColumns(r geocoder.BatchResult) []string {
+	if r.Err != nil {
+		status := ""
+		if r.Response != nil {
+			status = string(r.Response.Status)
+		}
+		return []string{status, "", "", r.Err.Error()}
+	}
+	if len(r.Response.Results) == 0 {
+		return []string{string(r.Response.Status), "", "", ""}
+	}
+	best := r.Response.Results[0]
+	return []string{string(r.Response.Status), best.FormattedAddress, best.PlaceID, ""}
+}
+
+// writeRow writes and flushes a row, so that the output is a valid checkpoint at any time
+func writeRow(w *csv.Writer, record []string) error {
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+)
+
+type fakeReverseGeocoder struct {
+	// fails with ctx cancellation after that many calls
+	interruptAfter int
+	cancel         context.CancelFunc
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeReverseGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, error) {
+	f.mu.Lock()
+	f.calls++
+	interrupt := f.interruptAfter > 0 && f.calls > f.interruptAfter
+	f.mu.Unlock()
+	if interrupt {
+		f.cancel()
+		return nil, ctx.Err()
+	}
+	if lat < 0 {
+		return nil, errors.New("ocean")
+	}
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{
+		FormattedAddress: "Main St, " + strings.Repeat("x", int(lat)),
+		PlaceID:          "place",
+	}}}, nil
+}
+
+func Test_RunBatchResume(t *testing.T) {
+	input := "lat,lng,name\n1,10,a\n-1,10,b\n2,10,c\n3,10,d\n"
+	path := filepath.Join(t.TempDir(), "out.csv")
+
+	// the first run is interrupted on the third request
+	ctx, cancel := context.WithCancel(context.Background())
+	out, done, err := openCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := runBatch(ctx, &fakeReverseGeocoder{interruptAfter: 2, cancel: cancel}, strings.NewReader(input), out, done, 1)
+	out.Close()
+	if !errors.Is(err, context.Canceled) || written != 3 {
+		t.Fatalf("test for interrupted run Failed - written %d, error %v", written, err)
+	}
+	// simulate a row cut off by a kill
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString("2,10,c,OK,Ma")
+	f.Close()
+
+	out, done, err = openCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done != 3 {
+		t.Errorf("test for checkpoint Failed - results not match\nGot:\n%v\nExpected:\n%v", done, 3)
+	}
+	written, err = runBatch(context.Background(), &fakeReverseGeocoder{}, strings.NewReader(input), out, done, 2)
+	out.Close()
+	if err != nil || written != 2 {
+		t.Fatalf("test for resumed run Failed - written %d, error %v", written, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `lat,lng,name,status,formatted_address,place_id,error
+1,10,a,OK,"Main St, x",place,
+-1,10,b,,,,ocean
+2,10,c,OK,"Main St, xx",place,
+3,10,d,OK,"Main St, xxx",place,
+`
+	if string(data) != expected {
+		t.Errorf("test for output Failed - results not match\nGot:\n%v\nExpected:\n%v", string(data), expected)
+	}
+}
+
+func Test_RunBatchNoHeader(t *testing.T) {
+	var out strings.Builder
+	written, err := runBatch(context.Background(), &fakeReverseGeocoder{}, strings.NewReader("1,10\nfoo,bar\n2,10\n"), &out, 0, 1)
+	if err == nil || err.Error() != `row 2: strconv.ParseFloat: parsing "foo": invalid syntax` {
+		t.Errorf("test for broken row Failed - unexpected error %v", err)
+	}
+	expected := "1,10,OK,\"Main St, x\",place,\n"
+	if written != 1 || out.String() != expected {
+		t.Errorf("test for no header Failed - results not match\nGot:\n%v\nExpected:\n%v", out.String(), expected)
+	}
+}
@@ -0,0 +1,93 @@
+// Command geocoder is a command line client of the geocoder package
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+const usage = `Usage: geocoder <command> [flags]
+
+Commands:
+  batch   reverse geocode coordinates from a CSV file
+  serve   run an HTTP geocoding gateway
+
+Credentials are read from flags or from the GEOCODER_CLIENT_ID, GEOCODER_SIGNING_KEY,
+GEOCODER_CHANNEL and GEOCODER_API_KEY environment variables.
+Run "geocoder <command> -h" for the command flags.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "batch":
+		err = batchCommand(ctx, os.Args[2:])
+	case "serve":
+		err = serveCommand(ctx, os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "geocoder:", err)
+		os.Exit(1)
+	}
+}
+
+// clientFlags are the flags shared by all commands to set up a Geocoder
+type clientFlags struct {
+	clientID   string
+	signingKey string
+	channel    string
+	apiKey     string
+	baseURL    string
+	language   string
+	rps        int
+	cooldown   time.Duration
+	retries    int
+}
+
+func (c *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.clientID, "client-id", os.Getenv("GEOCODER_CLIENT_ID"), "Google Maps Platform client ID")
+	fs.StringVar(&c.signingKey, "signing-key", os.Getenv("GEOCODER_SIGNING_KEY"), "URL signing key of the client ID")
+	fs.StringVar(&c.channel, "channel", os.Getenv("GEOCODER_CHANNEL"), "channel reported in usage reports")
+	fs.StringVar(&c.apiKey, "api-key", os.Getenv("GEOCODER_API_KEY"), "API key, used instead of the client ID")
+	fs.StringVar(&c.baseURL, "base-url", "https://maps.googleapis.com/maps/api/geocode/json", "geocoding endpoint")
+	fs.StringVar(&c.language, "language", "en", "language of the results")
+	fs.IntVar(&c.rps, "rps", 10, "requests per second")
+	fs.DurationVar(&c.cooldown, "cooldown", time.Second, "pause after OVER_QUERY_LIMIT")
+	fs.IntVar(&c.retries, "retries", 3, "attempts per request on transient failures")
+}
+
+func (c *clientFlags) geocoder(extra ...geocoder.Option) (*geocoder.Geocoder, error) {
+	var bkey *geocoder.BusinessKey
+	opts := append([]geocoder.Option{geocoder.WithRetries(c.retries, 500*time.Millisecond)}, extra...)
+	switch {
+	case c.clientID != "":
+		bkey = &geocoder.BusinessKey{ClientID: c.clientID, SigningKey: c.signingKey, Channel: c.channel}
+	case c.apiKey != "":
+		opts = append(opts, geocoder.WithAPIKey(c.apiKey))
+	default:
+		return nil, errors.New("no credentials, set -client-id and -signing-key or -api-key")
+	}
+	return geocoder.NewGeocoder(bkey, c.baseURL, c.language, geocoder.NewDefaultHTTPRequester(geocoder.HTTPClientOptions{}),
+		c.rps, c.cooldown, nil, opts...)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	"github.com/alvillain/geocoder/boltcache"
+	"github.com/alvillain/geocoder/grpcserver"
+	"github.com/alvillain/geocoder/grpcserver/geocoderpb"
+	"github.com/alvillain/geocoder/server"
+	"google.golang.org/grpc"
+)
+
+func serveCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	var client clientFlags
+	client.register(fs)
+	addr := fs.String("addr", ":8080", "listen address")
+	grpcAddr := fs.String("grpc-addr", "", "gRPC listen address, gRPC is disabled if empty")
+	batchConcurrency := fs.Int("batch-concurrency", 4, "parallel requests per gRPC Batch stream")
+	cacheSize := fs.Int("cache-size", 100000, "number of cached responses, 0 disables the cache")
+	cacheTTL := fs.Duration("cache-ttl", 24*time.Hour, "time to live of cached responses")
+	cacheFile := fs.String("cache-file", "", "keep cached responses in this file across restarts instead of memory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	// callers get 503 with Retry-After instead of hanging during cooldowns
+	opts := []geocoder.Option{geocoder.WithLogger(logger), geocoder.WithNonBlockingCooldown()}
+	switch {
+	case *cacheSize > 0 && *cacheFile != "":
+		cache, err := boltcache.Open(*cacheFile, boltcache.Options{
+			TTL:        *cacheTTL,
+			MaxEntries: *cacheSize,
+			OnError:    func(err error) { logger.Warn("cache write failed", slog.String("error", err.Error())) },
+		})
+		if err != nil {
+			return err
+		}
+		defer cache.Close()
+		// drop entries expired while the service was down and give their space back
+		if _, err := cache.Purge(); err != nil {
+			return err
+		}
+		if err := cache.Compact(); err != nil {
+			return err
+		}
+		opts = append(opts, geocoder.WithCache(cache))
+	case *cacheSize > 0:
+		opts = append(opts, geocoder.WithCache(geocoder.NewMemoryCache(*cacheSize, *cacheTTL)))
+	}
+	g, err := client.geocoder(opts...)
+	if err != nil {
+		return err
+	}
+	handler, err := server.New(g, logger)
+	if err != nil {
+		return err
+	}
+
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		service, err := grpcserver.New(g, *batchConcurrency)
+		if err != nil {
+			return err
+		}
+		grpcSrv := grpc.NewServer()
+		geocoderpb.RegisterGeocoderServer(grpcSrv, service)
+		go func() {
+			<-ctx.Done()
+			grpcSrv.GracefulStop()
+		}()
+		go func() {
+			logger.Info("serving gRPC geocoding gateway", slog.String("addr", *grpcAddr))
+			if err := grpcSrv.Serve(lis); err != nil {
+				logger.Error("gRPC server failed", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx) //nolint:errcheck
+	}()
+	logger.Info("serving geocoding gateway", slog.String("addr", *addr))
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BatchSummary describes a finished batch job
+type BatchSummary struct {
+	// Items geocoded, failed ones included
+	Processed int `json:"processed"`
+	Failed    int `json:"failed"`
+	// Items not geocoded, e.g. done by an earlier run of a resumed job
+	Skipped  int           `json:"skipped"`
+	Duration time.Duration `json:"-"`
+	// Location of the output artifact, e.g. a file path or an object URL, empty if unknown
+	Output string `json:"output,omitempty"`
+	// Error which stopped the job, empty if it ran to completion
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON adds the duration in seconds as duration_seconds
+func (s BatchSummary) MarshalJSON() ([]byte, error) {
+	type summary BatchSummary
+	return json.Marshal(struct {
+		summary
+		DurationSeconds float64 `json:"duration_seconds"`
+	}{summary(s), s.Duration.Seconds()})
+}
+
+// CompletionHook is called when a batch job finishes, e.g. to chain the next job
+type CompletionHook func(ctx context.Context, summary BatchSummary) error
+
+// NewWebhook returns a CompletionHook posting the summary as JSON to url. Responses other than
+// 2xx fail with HTTPError. client may be nil for http.DefaultClient
+func NewWebhook(url string, client HttpDoer) CompletionHook {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context, summary BatchSummary) error {
+		body, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySnippet))
+			return &HTTPError{StatusCode: resp.StatusCode, Body: string(snippet)}
+		}
+		return nil
+	}
+}
+
+// BatchOption configures BatchProcessor
+type BatchOption func(*BatchProcessor)
+
+// WithCompletionHook calls hook once Process returns, with a context which is not cancelled
+// together with the Process one. An error of the hook is returned by Process unless
+// processing failed itself
+func WithCompletionHook(hook CompletionHook) BatchOption {
+	return func(p *BatchProcessor) {
+		p.onComplete = hook
+	}
+}
+
+// complete calls the completion hook, if any, with the outcome of Process
+func (p *BatchProcessor) complete(ctx context.Context, summary BatchSummary, err error) error {
+	if p.onComplete == nil {
+		return err
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	hookErr := p.onComplete(context.WithoutCancel(ctx), summary)
+	if err == nil && hookErr != nil {
+		return errors.Join(errors.New("batch completion hook failed"), hookErr)
+	}
+	return err
+}
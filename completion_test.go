@@ -0,0 +1,77 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_CompletionHook(t *testing.T) {
+	tests := []struct {
+		name     string
+		hookErr  error
+		expected BatchSummary
+	}{
+		{"completed", nil, BatchSummary{Processed: 3, Failed: 1}},
+		{"hook failure", errors.New("orchestrator down"), BatchSummary{Processed: 3, Failed: 1}},
+	}
+	for _, tt := range tests {
+		var got BatchSummary
+		processor, err := NewBatchProcessor(oceanReverseGeocoder{}, 2, WithCompletionHook(func(ctx context.Context, s BatchSummary) error {
+			got = s
+			return tt.hookErr
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := make(chan BatchItem, 3)
+		items <- BatchItem{Lat: 1}
+		items <- BatchItem{Lat: -1}
+		items <- BatchItem{Lat: 2}
+		close(items)
+		err = processor.Process(context.TODO(), items, func(BatchResult) error { return nil })
+		if !errors.Is(err, tt.hookErr) || (tt.hookErr == nil) != (err == nil) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.hookErr)
+		}
+		got.Duration = 0
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%+v\nExpected:\n%+v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_Webhook(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    bool
+	}{
+		{"accepted", http.StatusAccepted, false},
+		{"server error", http.StatusInternalServerError, true},
+	}
+	for _, tt := range tests {
+		var body map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &body)
+			w.WriteHeader(tt.status)
+		}))
+		summary := BatchSummary{Processed: 10, Failed: 2, Skipped: 5, Duration: 1500 * time.Millisecond, Output: "/data/out.csv"}
+		err := NewWebhook(server.URL, nil)(context.TODO(), summary)
+		server.Close()
+		var httpErr *HTTPError
+		if tt.err != errors.As(err, &httpErr) {
+			t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+		}
+		expected := map[string]any{"processed": 10.0, "failed": 2.0, "skipped": 5.0, "duration_seconds": 1.5, "output": "/data/out.csv"}
+		if !reflect.DeepEqual(body, expected) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, body, expected)
+		}
+	}
+}
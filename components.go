@@ -0,0 +1,78 @@
+package geocoder
+
+// Typed address components returned by GetComponent
+type (
+	StreetNumber             AddressComponent
+	Route                    AddressComponent
+	Premise                  AddressComponent
+	Subpremise               AddressComponent
+	Neighborhood             AddressComponent
+	Sublocality              AddressComponent
+	Locality                 AddressComponent
+	PostalTown               AddressComponent
+	AdministrativeAreaLevel1 AddressComponent
+	AdministrativeAreaLevel2 AddressComponent
+	PostalCode               AddressComponent
+	Country                  AddressComponent
+)
+
+// ComponentType is the set of typed address components
+type ComponentType interface {
+	StreetNumber | Route | Premise | Subpremise | Neighborhood | Sublocality | Locality | PostalTown |
+		AdministrativeAreaLevel1 | AdministrativeAreaLevel2 | PostalCode | Country
+}
+
+// GetComponent returns the first address component of type T, e.g.
+//
+//	postalCode, ok := geocoder.GetComponent[geocoder.PostalCode](result)
+func GetComponent[T ComponentType](r *ResultSet) (T, bool) {
+	typ := componentTypeName[T]()
+	for _, c := range r.AddressComponents {
+		if hasType(c.Types, typ) {
+			return T(c), true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// componentTypeName returns the Google address component type of T
+func componentTypeName[T ComponentType]() string {
+	var zero T
+	switch any(zero).(type) {
+	case StreetNumber:
+		return "street_number"
+	case Route:
+		return "route"
+	case Premise:
+		return "premise"
+	case Subpremise:
+		return "subpremise"
+	case Neighborhood:
+		return "neighborhood"
+	case Sublocality:
+		return "sublocality"
+	case Locality:
+		return "locality"
+	case PostalTown:
+		return "postal_town"
+	case AdministrativeAreaLevel1:
+		return "administrative_area_level_1"
+	case AdministrativeAreaLevel2:
+		return "administrative_area_level_2"
+	case PostalCode:
+		return "postal_code"
+	case Country:
+		return "country"
+	}
+	panic("unreachable")
+}
+
+func hasType(types []string, typ string) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
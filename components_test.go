@@ -0,0 +1,23 @@
+package geocoder
+
+import "testing"
+
+func Test_GetComponent(t *testing.T) {
+	result := &ResultSet{AddressComponents: []AddressComponent{
+		{LongName: "1", ShortName: "1", Types: []string{"street_number"}},
+		{LongName: "Alexanderplatz", ShortName: "Alexanderpl.", Types: []string{"route"}},
+		{LongName: "Berlin", ShortName: "Berlin", Types: []string{"locality", "political"}},
+		{LongName: "10178", ShortName: "10178", Types: []string{"postal_code"}},
+		{LongName: "Germany", ShortName: "DE", Types: []string{"country", "political"}},
+	}}
+
+	if res, ok := GetComponent[PostalCode](result); !ok || res.LongName != "10178" {
+		t.Errorf("test for postal code Failed - results not match\nGot:\n%v\nExpected:\n%v", res.LongName, "10178")
+	}
+	if res, ok := GetComponent[Country](result); !ok || res.ShortName != "DE" {
+		t.Errorf("test for country Failed - results not match\nGot:\n%v\nExpected:\n%v", res.ShortName, "DE")
+	}
+	if res, ok := GetComponent[Premise](result); ok {
+		t.Errorf("test for missing premise Failed - got %v", res)
+	}
+}
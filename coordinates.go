@@ -0,0 +1,89 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCoordinates is matched by errors of coordinates which can't be geocoded
+var ErrInvalidCoordinates = errors.New("invalid coordinates")
+
+// ErrSwappedCoordinates is returned when swap detection is enabled and the latitude
+// is out of range while the longitude would be a valid latitude. It matches ErrInvalidCoordinates
+var ErrSwappedCoordinates = fmt.Errorf("%w: latitude and longitude look swapped", ErrInvalidCoordinates)
+
+// validateCoordinates returns an error matching ErrInvalidCoordinates for NaN, infinite
+// and out of range coordinates
+func validateCoordinates(lat, lng float64) error {
+	if math.IsNaN(lat) || math.IsNaN(lng) || math.IsInf(lat, 0) || math.IsInf(lng, 0) {
+		return fmt.Errorf("%w: %v,%v is not a number", ErrInvalidCoordinates, lat, lng)
+	}
+	if math.Abs(lat) > 90 {
+		return fmt.Errorf("%w: latitude %v is out of range [-90, 90]", ErrInvalidCoordinates, lat)
+	}
+	if math.Abs(lng) > 180 {
+		return fmt.Errorf("%w: longitude %v is out of range [-180, 180]", ErrInvalidCoordinates, lng)
+	}
+	return nil
+}
+
+// normalizeLongitude wraps lng into [-180, 180)
+func normalizeLongitude(lng float64) float64 {
+	if lng >= -180 && lng < 180 || math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return lng
+	}
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// ReverseGeocodeLngLat is ReverseGeocode taking coordinates in longitude, latitude order,
+// as used by GeoJSON, WKT and most spatial databases
+func (g *Geocoder) ReverseGeocodeLngLat(ctx context.Context, lng, lat float64) (*GoogleResponse, error) {
+	return g.ReverseGeocode(ctx, lat, lng)
+}
+
+// ParseLatLng parses "lat,lng" string, e.g. "52.52,13.40"
+func ParseLatLng(s string) (lat, lng float64, err error) {
+	lat, lng, err = parsePair(s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid lat,lng %q: %w", s, err)
+	}
+	return lat, lng, nil
+}
+
+// ParseLngLat parses "lng,lat" string, e.g. "13.40,52.52"
+func ParseLngLat(s string) (lat, lng float64, err error) {
+	lng, lat, err = parsePair(s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid lng,lat %q: %w", s, err)
+	}
+	return lat, lng, nil
+}
+
+func parsePair(s string) (float64, float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, errors.New("expected two comma separated numbers")
+	}
+	first, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	second, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, second, nil
+}
+
+// looksSwapped reports whether lat is not a valid latitude while lng is
+func looksSwapped(lat, lng float64) bool {
+	return math.Abs(lat) > 90 && math.Abs(lng) <= 90
+}
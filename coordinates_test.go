@@ -0,0 +1,106 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_ParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name        string
+		parse       func(string) (float64, float64, error)
+		input       string
+		expectedLat float64
+		expectedLng float64
+		expectError bool
+	}{
+		{"lat,lng", ParseLatLng, "52.52, 13.40", 52.52, 13.40, false},
+		{"lng,lat", ParseLngLat, "13.40,52.52", 52.52, 13.40, false},
+		{"missing part", ParseLatLng, "52.52", 0, 0, true},
+		{"not a number", ParseLngLat, "13.40,north", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lng, err := tt.parse(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+			}
+			if lat != tt.expectedLat || lng != tt.expectedLng {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v,%v\nExpected:\n%v,%v", tt.name, lat, lng, tt.expectedLat, tt.expectedLng)
+			}
+		})
+	}
+}
+
+func Test_SwapDetection(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1000, time.Second, nil, WithSwapDetection())
+
+	if _, err := geocoder.ReverseGeocode(context.TODO(), 151.21, -33.87); err != ErrSwappedCoordinates {
+		t.Errorf("test for swapped Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrSwappedCoordinates)
+	}
+	if _, err := geocoder.ReverseGeocodeLngLat(context.TODO(), 151.21, -33.87); err != nil {
+		t.Errorf("test for lng,lat Failed - unexpected error %v", err)
+	}
+}
+
+func Test_CoordinateValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []Option
+		lat, lng    float64
+		expectError bool
+	}{
+		{"valid", nil, 52.52, 13.40, false},
+		{"poles and antimeridian", nil, -90, 180, false},
+		{"latitude out of range", nil, 90.5, 13.40, true},
+		{"longitude out of range", nil, 52.52, 190, true},
+		{"NaN", nil, math.NaN(), 13.40, true},
+		{"infinity", nil, 52.52, math.Inf(-1), true},
+		{"normalized longitude", []Option{WithLongitudeNormalization()}, 52.52, 373.40, false},
+		{"normalized NaN", []Option{WithLongitudeNormalization()}, 52.52, math.NaN(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			g, err := NewGeocoder(nil, "https://localhost", "en", flakyClient(&requests, 200), 1000, time.Millisecond, nil,
+				append(tt.options, WithAPIKey("key"))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = g.ReverseGeocode(context.TODO(), tt.lat, tt.lng)
+			if errors.Is(err, ErrInvalidCoordinates) != tt.expectError {
+				t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+			}
+			if tt.expectError && requests != 0 {
+				t.Errorf("test for %v Failed - %d requests sent", tt.name, requests)
+			}
+		})
+	}
+}
+
+func Test_NormalizeLongitude(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{13.40, 13.40},
+		{180, -180},
+		{-180, -180},
+		{190, -170},
+		{-190, 170},
+		{540, -180},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeLongitude(tt.input); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.input, got, tt.expected)
+		}
+	}
+}
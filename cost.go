@@ -0,0 +1,127 @@
+package geocoder
+
+import (
+	"sort"
+	"sync"
+)
+
+// Operation is the type of a request for usage reporting and pricing
+type Operation string
+
+const (
+	OP_REVERSE_GEOCODING Operation = "reverse_geocoding"
+	OP_GEOCODING         Operation = "geocoding"
+	// Geocoding of a place ID
+	OP_PLACE_GEOCODING Operation = "place_geocoding"
+	OP_TIMEZONE        Operation = "timezone"
+	OP_ELEVATION       Operation = "elevation"
+	// Autocomplete request, billed per session if it carries a session token
+	OP_AUTOCOMPLETE       Operation = "autocomplete"
+	OP_PLACE_DETAILS      Operation = "place_details"
+	OP_NEARBY_SEARCH      Operation = "nearby_search"
+	OP_TEXT_SEARCH        Operation = "text_search"
+	OP_ADDRESS_VALIDATION Operation = "address_validation"
+	OP_DIRECTIONS         Operation = "directions"
+	OP_SNAP_TO_ROADS      Operation = "snap_to_roads"
+	OP_NEAREST_ROADS      Operation = "nearest_roads"
+	OP_GEOLOCATION        Operation = "geolocation"
+)
+
+// Pricing is the price of requests per 1000, in any currency
+type Pricing struct {
+	PerThousand float64
+	// Prices of operations billed differently than PerThousand
+	Operations map[Operation]float64
+}
+
+// perThousand returns the price of 1000 requests of op
+func (p Pricing) perThousand(op Operation) float64 {
+	if price, ok := p.Operations[op]; ok {
+		return price
+	}
+	return p.PerThousand
+}
+
+// WithPricing sets the prices UsageReport estimates the cost of requests with
+func WithPricing(pricing Pricing) Option {
+	return func(g *Geocoder) {
+		g.operations.pricing = pricing
+	}
+}
+
+// OperationUsage is the usage of an operation
+type OperationUsage struct {
+	Operation Operation
+	// Requests sent to Google
+	Requests int64
+	// Requests served from cache
+	CacheHits int64
+	// Cost of Requests according to Pricing
+	EstimatedCost float64
+}
+
+// UsageReport is the usage of a Geocoder since it was created
+type UsageReport struct {
+	// Usage per operation sorted by operation
+	Operations    []OperationUsage
+	Requests      int64
+	CacheHits     int64
+	EstimatedCost float64
+}
+
+// operationCounter counts requests and cache hits per operation. It is safe for concurrent use
+type operationCounter struct {
+	pricing Pricing
+
+	mu     sync.Mutex
+	counts map[Operation]*OperationUsage
+}
+
+func (c *operationCounter) request(op Operation) {
+	c.mu.Lock()
+	c.usage(op).Requests++
+	c.mu.Unlock()
+}
+
+func (c *operationCounter) cacheHit(op Operation) {
+	c.mu.Lock()
+	c.usage(op).CacheHits++
+	c.mu.Unlock()
+}
+
+// usage returns the counters of op, c.mu must be held
+func (c *operationCounter) usage(op Operation) *OperationUsage {
+	if c.counts == nil {
+		c.counts = make(map[Operation]*OperationUsage)
+	}
+	u, ok := c.counts[op]
+	if !ok {
+		u = &OperationUsage{Operation: op}
+		c.counts[op] = u
+	}
+	return u
+}
+
+func (c *operationCounter) report() UsageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var report UsageReport
+	for op, u := range c.counts {
+		usage := *u
+		usage.EstimatedCost = float64(usage.Requests) * c.pricing.perThousand(op) / 1000
+		report.Operations = append(report.Operations, usage)
+		report.Requests += usage.Requests
+		report.CacheHits += usage.CacheHits
+		report.EstimatedCost += usage.EstimatedCost
+	}
+	sort.Slice(report.Operations, func(i, j int) bool {
+		return report.Operations[i].Operation < report.Operations[j].Operation
+	})
+	return report
+}
+
+// UsageReport returns the number of requests and cache hits per operation since the Geocoder was
+// created, and the cost of the requests estimated with the prices set by WithPricing
+func (g *Geocoder) UsageReport() UsageReport {
+	return g.operations.report()
+}
@@ -0,0 +1,39 @@
+package geocoder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_UsageReport(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithCache(NewMemoryCache(10, time.Hour)),
+		WithPricing(Pricing{PerThousand: 5, Operations: map[Operation]float64{OP_GEOCODING: 4}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []Coordinate{{Lat: 1, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}} {
+		if _, err := g.ReverseGeocode(context.TODO(), c.Lat, c.Lng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.Geocode(context.TODO(), "Berlin"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := UsageReport{
+		Operations: []OperationUsage{
+			{Operation: OP_GEOCODING, Requests: 1, EstimatedCost: 0.004},
+			{Operation: OP_REVERSE_GEOCODING, Requests: 2, CacheHits: 1, EstimatedCost: 0.01},
+		},
+		Requests:      3,
+		CacheHits:     1,
+		EstimatedCost: 0.014,
+	}
+	if report := g.UsageReport(); !reflect.DeepEqual(report, expected) {
+		t.Errorf("test for usage report Failed - results not match\nGot:\n%+v\nExpected:\n%+v", report, expected)
+	}
+}
@@ -0,0 +1,94 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// countryBoundsMargin is added around country bounding boxes, in degrees,
+// the boxes are approximate and coastlines may extend a little beyond them
+const countryBoundsMargin = 0.1
+
+//go:embed data/country_bounds.csv
+var countryBoundsCSV []byte
+
+// countryBounds maps ISO 3166-1 alpha-2 codes to country bounding boxes
+var countryBounds = mustParseCountryBounds(countryBoundsCSV)
+
+// CountryBounds returns the approximate bounding box of the country with ISO 3166-1 alpha-2 code
+func CountryBounds(code string) (Bounds, bool) {
+	b, ok := countryBounds[strings.ToUpper(code)]
+	return b, ok
+}
+
+// CountryMayContain reports whether the coordinate lies within the bounding box of the country.
+// It returns true for unknown country codes
+func CountryMayContain(code string, lat, lng float64) bool {
+	b, ok := CountryBounds(code)
+	if !ok {
+		return true
+	}
+	b.SouthWest.Lat -= countryBoundsMargin
+	b.NorthEast.Lat += countryBoundsMargin
+	b.SouthWest.Lng -= countryBoundsMargin
+	b.NorthEast.Lng += countryBoundsMargin
+	return b.Contains(lat, lng)
+}
+
+// WithCountryCheck makes ReverseGeocode verify that the country of every result plausibly
+// contains the queried coordinate. Implausible results are flagged with CountryMismatch
+// and logged as warnings, they are not removed from the response
+func WithCountryCheck() Option {
+	return func(g *Geocoder) {
+		g.countryCheck = true
+	}
+}
+
+// checkCountries returns a copy of res whose results are flagged if their country can't contain
+// the queried coordinate. res may be a shared cache entry, so it is left untouched
+func (g *Geocoder) checkCountries(ctx context.Context, res *GoogleResponse, lat, lng float64) *GoogleResponse {
+	checked := *res
+	checked.Results = make([]*ResultSet, len(res.Results))
+	for i, r := range res.Results {
+		flagged := *r
+		checked.Results[i] = &flagged
+		country, ok := GetComponent[Country](r)
+		flagged.CountryMismatch = ok && !CountryMayContain(country.ShortName, lat, lng)
+		if !flagged.CountryMismatch {
+			continue
+		}
+		g.logger.WarnContext(ctx, "geocoding result country does not contain the queried coordinate",
+			slog.String("country", country.ShortName), slog.Float64("lat", lat), slog.Float64("lng", lng),
+			slog.String("place_id", r.PlaceID))
+	}
+	return &checked
+}
+
+func mustParseCountryBounds(data []byte) map[string]Bounds {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		panic("invalid embedded country bounds: " + err.Error())
+	}
+
+	bounds := make(map[string]Bounds, len(records))
+	for _, rec := range records {
+		var v [4]float64
+		for i := range v {
+			if v[i], err = strconv.ParseFloat(rec[i+1], 64); err != nil {
+				panic("invalid embedded country bounds: " + err.Error())
+			}
+		}
+		bounds[rec[0]] = Bounds{
+			SouthWest: Coordinate{Lat: v[0], Lng: v[1]},
+			NorthEast: Coordinate{Lat: v[2], Lng: v[3]},
+		}
+	}
+	return bounds
+}
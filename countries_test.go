@@ -0,0 +1,92 @@
+package geocoder
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_CountryMayContain(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		lat      float64
+		lng      float64
+		expected bool
+	}{
+		{"Berlin in DE", "DE", 52.52, 13.40, true},
+		{"Berlin in FR", "FR", 52.52, 13.40, false},
+		{"Chukotka in RU", "RU", 65.0, -172.0, true},
+		{"Honolulu in US", "us", 21.31, -157.86, true},
+		{"Canary Islands in ES", "ES", 28.29, -16.63, true},
+		{"Unknown code", "ZZ", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if res := CountryMayContain(tt.code, tt.lat, tt.lng); res != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_WithCountryCheck(t *testing.T) {
+	body := `{"status":"OK","results":[
+		{"address_components":[{"long_name":"Germany","short_name":"DE","types":["country","political"]}]},
+		{"address_components":[{"long_name":"Poland","short_name":"PL","types":["country","political"]}]}
+	]}`
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: body}, 1000, time.Second, nil, WithCountryCheck())
+
+	res, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Results[0].CountryMismatch || !res.Results[1].CountryMismatch {
+		t.Errorf("test for country check Failed - unexpected flags %v %v", res.Results[0].CountryMismatch, res.Results[1].CountryMismatch)
+	}
+}
+
+func Test_WithCountryCheckCached(t *testing.T) {
+	body := `{"status":"OK","results":[
+		{"address_components":[{"long_name":"France","short_name":"FR","types":["country","political"]}]}
+	]}`
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: body}, 1000, time.Second, nil, WithAPIKey("key"), WithCountryCheck(),
+		WithGeohashCacheKeys(3), WithCache(NewMemoryCache(10, time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+		expected bool
+	}{
+		{"Off the French coast", 48.5, -5.3, true},
+		{"Same cell within France", 48.5, -5.0, false},
+	}
+
+	// concurrent checks of the shared cache entry, run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, tt := range tests {
+			wg.Add(1)
+			go func(name string, lat, lng float64, expected bool) {
+				defer wg.Done()
+				res, err := geocoder.ReverseGeocode(context.TODO(), lat, lng)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if res.Results[0].CountryMismatch != expected {
+					t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", name, res.Results[0].CountryMismatch, expected)
+				}
+			}(tt.name, tt.lat, tt.lng, tt.expected)
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,122 @@
+package geocoder
+
+import (
+	"context"
+	"strings"
+)
+
+// probeLat and probeLng is a coordinate known to be geocoded successfully, Googleplex.
+// Credential checks and Ping probe it
+const (
+	probeLat = 37.4224764
+	probeLng = -122.0842499
+)
+
+// CredentialDiagnosis classifies the outcome of a credential check
+type CredentialDiagnosis int
+
+const (
+	// Request was authorized
+	CredentialsOK CredentialDiagnosis = iota
+	// Signature does not match the signing key of the client ID
+	CredentialsBadSignature
+	// Client ID or API key is unknown or not authorized for the Geocoding API
+	CredentialsUnauthorizedClient
+	// Channel parameter was rejected
+	CredentialsChannelIssue
+	// Request was denied for another reason, see ErrorMessage
+	CredentialsDenied
+	// Google responded with a status which tells nothing about the credentials
+	CredentialsUnverified
+)
+
+var credentialDiagnosisNames = map[CredentialDiagnosis]string{
+	CredentialsOK:                 "ok",
+	CredentialsBadSignature:       "bad signature",
+	CredentialsUnauthorizedClient: "unauthorized client",
+	CredentialsChannelIssue:       "channel issue",
+	CredentialsDenied:             "denied",
+	CredentialsUnverified:         "unverified",
+}
+
+func (d CredentialDiagnosis) String() string {
+	return credentialDiagnosisNames[d]
+}
+
+// CredentialCheck is the result of ValidateCredentials
+type CredentialCheck struct {
+	// Client ID, or API key with all but the last 4 characters masked
+	Credential   string
+	Diagnosis    CredentialDiagnosis
+	Status       GoogleResponseStatus
+	ErrorMessage string
+}
+
+// ValidateCredentials sends a signed reverse geocoding request of a known coordinate, bypassing
+// the cache, and diagnoses the credentials by the response. With WithCredentialPool every credential
+// is checked and the first one which isn't OK is returned. Use it at service startup
+func (g *Geocoder) ValidateCredentials(ctx context.Context) (*CredentialCheck, error) {
+	if g.pool == nil {
+		return g.validateCredentials(ctx, nil)
+	}
+	var first *CredentialCheck
+	for _, m := range g.pool.members {
+		check, err := g.validateCredentials(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if check.Diagnosis != CredentialsOK {
+			return check, nil
+		}
+		if first == nil {
+			first = check
+		}
+	}
+	return first, nil
+}
+
+// validateCredentials checks the credentials of the pool member, or of the Geocoder if nil
+func (g *Geocoder) validateCredentials(ctx context.Context, member *poolMember) (*CredentialCheck, error) {
+	builder := &g.builder
+	if member != nil {
+		builder = &member.builder
+	}
+	res, err := g.do(ctx, &request{
+		op:       OP_REVERSE_GEOCODING,
+		params:   latLngParams(probeLat, probeLng, g.precision),
+		noCache:  true,
+		member:   member,
+		diagnose: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialCheck{
+		Credential:   credentialName(builder),
+		Diagnosis:    diagnoseCredentials(res),
+		Status:       res.Status,
+		ErrorMessage: res.ErrorMessage,
+	}, nil
+}
+
+// diagnoseCredentials interprets the response status and REQUEST_DENIED error message
+func diagnoseCredentials(res *GoogleResponse) CredentialDiagnosis {
+	switch res.Status {
+	case GRS_OK, GRS_ZERO_RESULTS, GRS_OVER_QUERY_LIMIT:
+		return CredentialsOK
+	case GRS_REQUEST_DENIED:
+	default:
+		return CredentialsUnverified
+	}
+
+	msg := strings.ToLower(res.ErrorMessage)
+	switch {
+	case strings.Contains(msg, "signature"):
+		return CredentialsBadSignature
+	case strings.Contains(msg, "channel"):
+		return CredentialsChannelIssue
+	case strings.Contains(msg, "client"), strings.Contains(msg, "api key"), strings.Contains(msg, "not authorized"):
+		return CredentialsUnauthorizedClient
+	}
+	return CredentialsDenied
+}
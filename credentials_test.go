@@ -0,0 +1,63 @@
+package geocoder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_ValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected CredentialDiagnosis
+	}{
+		{"OK", `{"status":"OK"}`, CredentialsOK},
+		{"Bad signature", `{"status":"REQUEST_DENIED","error_message":"Unable to authenticate the request. Provided 'signature' is not valid for the provided client ID."}`, CredentialsBadSignature},
+		{"Unauthorized client", `{"status":"REQUEST_DENIED","error_message":"This API project is not authorized to use this API."}`, CredentialsUnauthorizedClient},
+		{"Channel", `{"status":"REQUEST_DENIED","error_message":"Invalid channel parameter."}`, CredentialsChannelIssue},
+		{"Other denial", `{"status":"REQUEST_DENIED","error_message":"Requests to this API must be over SSL."}`, CredentialsDenied},
+		{"Unknown error", `{"status":"UNKNOWN_ERROR"}`, CredentialsUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+			geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+				&fakeHttpRequester{responseBodyJSON: tt.body}, 1000, time.Second, nil)
+
+			res, err := geocoder.ValidateCredentials(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Diagnosis != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res.Diagnosis, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_ValidateCredentialsPool(t *testing.T) {
+	var used []string
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", poolClient(&used, "key-b"), 1000, time.Second,
+		nil, WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "key-a"}, Credential{APIKey: "key-b"}, Credential{APIKey: "key-c"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := geocoder.ValidateCredentials(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Credential != "*ey-b" || res.Diagnosis != CredentialsUnauthorizedClient {
+		t.Errorf("test for denied pool credential Failed - results not match\nGot:\n%+v\nExpected:\n*ey-b %v", res, CredentialsUnauthorizedClient)
+	}
+	// the check stops at the denied credential without taking it out of rotation
+	if expected := []string{"key-a", "key-b"}; !reflect.DeepEqual(used, expected) || geocoder.pool.members[1].disabled.Load() {
+		t.Errorf("test for checked credentials Failed - results not match\nGot:\n%v\nExpected:\n%v", used, expected)
+	}
+	if stats := geocoder.Stats(); stats.Requests != 2 {
+		t.Errorf("test for request stats Failed - results not match\nGot:\n%v\nExpected:\n%v", stats.Requests, 2)
+	}
+}
@@ -0,0 +1,30 @@
+package geocoder
+
+import (
+	"context"
+	"time"
+)
+
+// DebugInfo is a dump of a single request/response exchange with Google.
+// Signature, client ID and API key are masked in URL
+type DebugInfo struct {
+	URL string
+	// HTTP status code, zero if no response was received
+	StatusCode int
+	// Raw response body
+	Body     []byte
+	Duration time.Duration
+	// Transport or decoding error, if any
+	Err error
+}
+
+// DebugHook receives a DebugInfo for every request sent by the Geocoder
+type DebugHook func(ctx context.Context, info DebugInfo)
+
+// WithDebugHook enables the debug mode: every request URL and raw response body are passed to hook,
+// e.g. to diagnose REQUEST_DENIED responses. Response bodies are buffered in memory in this mode
+func WithDebugHook(hook DebugHook) Option {
+	return func(g *Geocoder) {
+		g.debugHook = hook
+	}
+}
@@ -0,0 +1,36 @@
+package geocoder
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WithDebugHook(t *testing.T) {
+	body := `{"status":"REQUEST_DENIED","error_message":"Invalid signature"}`
+	var dumps []DebugInfo
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: body}, 1000, time.Second, nil,
+		WithDebugHook(func(ctx context.Context, info DebugInfo) {
+			dumps = append(dumps, info)
+		}))
+
+	res, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != GRS_REQUEST_DENIED {
+		t.Errorf("test for debug hook Failed - results not match\nGot:\n%v\nExpected:\n%v", res.Status, GRS_REQUEST_DENIED)
+	}
+	if len(dumps) != 1 {
+		t.Fatalf("test for debug hook Failed - got %d dumps, expected 1", len(dumps))
+	}
+	if string(dumps[0].Body) != body {
+		t.Errorf("test for debug hook Failed - results not match\nGot:\n%s\nExpected:\n%v", dumps[0].Body, body)
+	}
+	if strings.Contains(dumps[0].URL, "my_test_client") || !strings.Contains(dumps[0].URL, "signature=REDACTED") {
+		t.Errorf("test for debug hook Failed - secrets are not masked in %v", dumps[0].URL)
+	}
+}
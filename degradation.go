@@ -0,0 +1,114 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DegradationLevel tells how a response was produced under quota pressure
+type DegradationLevel string
+
+const (
+	// DL_FULL is a full precision Google response, the zero value
+	DL_FULL DegradationLevel = ""
+	// DL_LOCALITY is a locality level result cached for the surrounding grid cell
+	DL_LOCALITY DegradationLevel = "LOCALITY"
+	// DL_FALLBACK is a response of the fallback provider
+	DL_FALLBACK DegradationLevel = "FALLBACK"
+)
+
+// coarseTypes are result types kept for degraded responses, from the finest
+var coarseTypes = []string{"locality", "postal_town", "administrative_area_level_2", "administrative_area_level_1", "country"}
+
+// QuotaBudget reports the share of the request quota left, from 0 to 1
+type QuotaBudget interface {
+	Remaining() float64
+}
+
+// QuotaBudgetFunc adapts a function to QuotaBudget
+type QuotaBudgetFunc func() float64
+
+// Remaining implements QuotaBudget
+func (f QuotaBudgetFunc) Remaining() float64 { return f() }
+
+// DegradationPolicy describes how reverse geocoding degrades when the quota runs low
+type DegradationPolicy struct {
+	Budget QuotaBudget
+	// Requests are degraded while Budget.Remaining() is below Threshold
+	Threshold float64
+	// Size of the grid cells locality level results are cached for, in degrees, 0.05 if zero
+	CellSize float64
+	// Stores locality level results by grid cell, a MemoryCache of 100000 cells for a week if nil
+	Cache Cache
+	// Serves degraded requests without a cached locality, optional
+	Fallback ReverseGeocoder
+}
+
+// degradation is the state of DegradationPolicy
+type degradation struct {
+	DegradationPolicy
+}
+
+// WithDegradation makes ReverseGeocode serve coarse results instead of calling Google while the
+// remaining quota is below the policy threshold. Locality level results of full responses are
+// cached per grid cell; a degraded request is served from that cache, then from the fallback
+// provider, and only then from Google. GoogleResponse.Degradation reports the level
+func WithDegradation(policy DegradationPolicy) Option {
+	return func(g *Geocoder) {
+		if policy.Budget == nil {
+			return
+		}
+		if policy.CellSize <= 0 {
+			policy.CellSize = 0.05
+		}
+		if policy.Cache == nil {
+			policy.Cache = NewMemoryCache(100000, 7*24*time.Hour)
+		}
+		g.degradation = &degradation{DegradationPolicy: policy}
+	}
+}
+
+// active reports whether requests have to be degraded
+func (d *degradation) active() bool {
+	return d.Budget.Remaining() < d.Threshold
+}
+
+// serve returns a degraded response, ok is false if there is none and Google has to be called
+func (d *degradation) serve(ctx context.Context, lat, lng float64) (*GoogleResponse, bool) {
+	if res, ok := d.Cache.Get(d.cellKey(lat, lng)); ok {
+		degraded := *res
+		degraded.Degradation = DL_LOCALITY
+		return &degraded, true
+	}
+	if d.Fallback == nil {
+		return nil, false
+	}
+	res, err := d.Fallback.ReverseGeocode(ctx, lat, lng)
+	if err != nil || res == nil || res.Status != GRS_OK {
+		return nil, false
+	}
+	degraded := *res
+	degraded.Degradation = DL_FALLBACK
+	return &degraded, true
+}
+
+// learn caches the coarsest useful result of a full response for the grid cell of lat, lng
+func (d *degradation) learn(lat, lng float64, res *GoogleResponse) {
+	if res.Status != GRS_OK {
+		return
+	}
+	for _, typ := range coarseTypes {
+		for _, rs := range res.Results {
+			if hasType(rs.Types, typ) {
+				d.Cache.Set(d.cellKey(lat, lng), &GoogleResponse{Status: GRS_OK, Results: []*ResultSet{rs}})
+				return
+			}
+		}
+	}
+}
+
+func (d *degradation) cellKey(lat, lng float64) string {
+	return fmt.Sprintf("cell:%g:%d,%d", d.CellSize, int64(math.Floor(lat/d.CellSize)), int64(math.Floor(lng/d.CellSize)))
+}
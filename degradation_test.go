@@ -0,0 +1,81 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Degradation(t *testing.T) {
+	calls := 0
+	client := requesterFunc(func(string) (*http.Response, error) {
+		calls++
+		body := `{"status":"OK","results":[
+{"formatted_address":"Unter den Linden 1, Berlin","types":["street_address"]},
+{"formatted_address":"Berlin, Germany","types":["locality","political"]},
+{"formatted_address":"Germany","types":["country","political"]}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	remaining := 1.0
+	fallbackCalls := 0
+	fallback := reverseGeocoderFunc(func(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+		fallbackCalls++
+		if lat < 0 {
+			return &GoogleResponse{Status: GRS_ZERO_RESULTS}, nil
+		}
+		return &GoogleResponse{Status: GRS_OK}, nil
+	})
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: "bXlfdGVzdF9rZXk="}, "https://localhost", "en",
+		client, 100, time.Millisecond, nil,
+		WithDegradation(DegradationPolicy{
+			Budget:    QuotaBudgetFunc(func() float64 { return remaining }),
+			Threshold: 0.1,
+			Fallback:  fallback,
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		remaining         float64
+		lat, lng          float64
+		expectedLevel     DegradationLevel
+		expectedAddress   string
+		expectedCalls     int
+		expectedFallbacks int
+	}{
+		{"full budget", 1, 52.5163, 13.3777, DL_FULL, "Unter den Linden 1, Berlin", 1, 0},
+		{"same cell", 0.05, 52.5200, 13.3800, DL_LOCALITY, "Berlin, Germany", 1, 0},
+		{"other cell", 0.05, 48.1372, 11.5756, DL_FALLBACK, "", 1, 1},
+		{"fallback without results", 0.05, -33.86, 151.20, DL_FULL, "Unter den Linden 1, Berlin", 2, 2},
+	}
+	for _, tt := range tests {
+		remaining = tt.remaining
+		res, err := g.ReverseGeocode(context.TODO(), tt.lat, tt.lng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := ""
+		if len(res.Results) > 0 {
+			address = res.Results[0].FormattedAddress
+		}
+		got := []interface{}{res.Degradation, address, calls, fallbackCalls}
+		expected := []interface{}{tt.expectedLevel, tt.expectedAddress, tt.expectedCalls, tt.expectedFallbacks}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, expected)
+				break
+			}
+		}
+	}
+}
+
+type reverseGeocoderFunc func(ctx context.Context, lat, lng float64) (*GoogleResponse, error)
+
+func (f reverseGeocoderFunc) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	return f(ctx, lat, lng)
+}
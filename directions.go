@@ -0,0 +1,164 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var apiDirections = &mapsAPI{path: "directions", usage: UsageAPIDirections}
+
+// TravelMode is the means of transport of a route
+type TravelMode string
+
+const (
+	TM_DRIVING   TravelMode = "driving"
+	TM_WALKING   TravelMode = "walking"
+	TM_BICYCLING TravelMode = "bicycling"
+	TM_TRANSIT   TravelMode = "transit"
+)
+
+// DirectionsOptions tunes the routes, all fields are optional
+type DirectionsOptions struct {
+	// TM_DRIVING if empty
+	Mode TravelMode
+	// Stopovers between origin and destination, in the same formats as them.
+	// Prefix with via: to pass through a point without stopping
+	Waypoints []string
+	// Let Google reorder the waypoints, the order is reported in DirectionsRoute.WaypointOrder
+	OptimizeWaypoints bool
+	// Return alternative routes
+	Alternatives bool
+	// Features to avoid: tolls, highways, ferries or indoor
+	Avoid []string
+	// metric or imperial distance texts, the distance values are always in meters
+	Units string
+	// Departure or arrival time, for traffic and transit schedules. Only one of them may be set
+	DepartureTime time.Time
+	ArrivalTime   time.Time
+	// ccTLD region the origin and destination are resolved in, e.g. de
+	Region string
+	// Output language overriding the Geocoder one
+	Language string
+}
+
+// DirectionsResponse is the response of the Directions API
+type DirectionsResponse struct {
+	Routes            []DirectionsRoute  `json:"routes"`
+	GeocodedWaypoints []GeocodedWaypoint `json:"geocoded_waypoints"`
+	// Travel modes with routes, set if there are none in the requested one
+	AvailableTravelModes []TravelMode         `json:"available_travel_modes,omitempty"`
+	Status               GoogleResponseStatus `json:"status"`
+	ErrorMessage         string               `json:"error_message,omitempty"`
+}
+
+// GeocodedWaypoint is the geocoding outcome of the origin, a waypoint or the destination
+type GeocodedWaypoint struct {
+	GeocoderStatus GoogleResponseStatus `json:"geocoder_status"`
+	PlaceID        string               `json:"place_id"`
+	Types          []string             `json:"types"`
+	PartialMatch   bool                 `json:"partial_match,omitempty"`
+}
+
+// DirectionsRoute is a way from the origin to the destination
+type DirectionsRoute struct {
+	Summary string `json:"summary"`
+	// A leg per stopover, only one without waypoints
+	Legs             []RouteLeg `json:"legs"`
+	OverviewPolyline Polyline   `json:"overview_polyline"`
+	Bounds           Bounds     `json:"bounds"`
+	Copyrights       string     `json:"copyrights"`
+	Warnings         []string   `json:"warnings"`
+	// Order of the waypoints if they were optimized
+	WaypointOrder []int `json:"waypoint_order"`
+}
+
+// RouteLeg is a part of a route between two stopovers
+type RouteLeg struct {
+	Distance      TextValue   `json:"distance"`
+	Duration      TextValue   `json:"duration"`
+	StartAddress  string      `json:"start_address"`
+	EndAddress    string      `json:"end_address"`
+	StartLocation Coordinate  `json:"start_location"`
+	EndLocation   Coordinate  `json:"end_location"`
+	Steps         []RouteStep `json:"steps"`
+	// Duration in current traffic, set for driving routes with a departure time
+	DurationInTraffic *TextValue `json:"duration_in_traffic,omitempty"`
+}
+
+// RouteStep is a single instruction of a leg
+type RouteStep struct {
+	HTMLInstructions string     `json:"html_instructions"`
+	Distance         TextValue  `json:"distance"`
+	Duration         TextValue  `json:"duration"`
+	StartLocation    Coordinate `json:"start_location"`
+	EndLocation      Coordinate `json:"end_location"`
+	Polyline         Polyline   `json:"polyline"`
+	TravelMode       string     `json:"travel_mode"`
+	// E.g. turn-left, empty if there is nothing to do
+	Maneuver string `json:"maneuver,omitempty"`
+}
+
+// TextValue is a distance in meters or a duration in seconds with its localized text, e.g. 1.2 km
+type TextValue struct {
+	Text  string `json:"text"`
+	Value int    `json:"value"`
+}
+
+// Directions returns routes from origin to destination. Both are an address, a "lat,lng"
+// coordinate or a place ID prefixed with place_id:. Requests are limited by the EP_DIRECTIONS
+// limiter and are not cached
+func (g *Geocoder) Directions(ctx context.Context, origin, destination string, opts DirectionsOptions) (*DirectionsResponse, error) {
+	if strings.TrimSpace(origin) == "" || strings.TrimSpace(destination) == "" {
+		return nil, errors.New("empty origin or destination")
+	}
+	if !opts.DepartureTime.IsZero() && !opts.ArrivalTime.IsZero() {
+		return nil, errors.New("only one of departure and arrival time may be set")
+	}
+	params := url.Values{"origin": {origin}, "destination": {destination}}
+	if opts.Mode != "" {
+		params.Set("mode", string(opts.Mode))
+	}
+	if len(opts.Waypoints) > 0 {
+		waypoints := strings.Join(opts.Waypoints, "|")
+		if opts.OptimizeWaypoints {
+			waypoints = "optimize:true|" + waypoints
+		}
+		params.Set("waypoints", waypoints)
+	}
+	if opts.Alternatives {
+		params.Set("alternatives", "true")
+	}
+	if len(opts.Avoid) > 0 {
+		params.Set("avoid", strings.Join(opts.Avoid, "|"))
+	}
+	if opts.Units != "" {
+		params.Set("units", opts.Units)
+	}
+	if !opts.DepartureTime.IsZero() {
+		params.Set("departure_time", strconv.FormatInt(opts.DepartureTime.Unix(), 10))
+	}
+	if !opts.ArrivalTime.IsZero() {
+		params.Set("arrival_time", strconv.FormatInt(opts.ArrivalTime.Unix(), 10))
+	}
+	if opts.Region != "" {
+		params.Set("region", opts.Region)
+	}
+	if opts.Language != "" {
+		params.Set("language", opts.Language)
+	}
+
+	res := &DirectionsResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_DIRECTIONS,
+		endpoint: EP_DIRECTIONS,
+		api:      apiDirections,
+		params:   params,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
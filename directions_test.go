@@ -0,0 +1,71 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Directions(t *testing.T) {
+	var requested *url.URL
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		requested = u
+		body := `{"status":"OK","geocoded_waypoints":[{"geocoder_status":"OK","place_id":"A"},{"geocoder_status":"OK","place_id":"B"}],
+			"routes":[{"summary":"A100","overview_polyline":{"points":"_p~iF~ps|U_ulLnnqC"},"waypoint_order":[0],
+			"legs":[{"distance":{"text":"5.2 km","value":5200},"duration":{"text":"12 mins","value":720},
+			"steps":[{"html_instructions":"Head <b>north</b>","travel_mode":"BICYCLING","polyline":{"points":"_p~iF~ps|U"}}]}]}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.Directions(context.TODO(), "Alexanderplatz, Berlin", "place_id:B", DirectionsOptions{
+		Mode:              TM_BICYCLING,
+		Waypoints:         []string{"52.51,13.39"},
+		OptimizeWaypoints: true,
+		Avoid:             []string{"ferries", "indoor"},
+		DepartureTime:     time.Unix(1720000000, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Routes) != 1 || res.Routes[0].Legs[0].Distance.Value != 5200 || res.Routes[0].Legs[0].Steps[0].TravelMode != "BICYCLING" {
+		t.Errorf("test for routes Failed - unexpected response %+v", res)
+	}
+	path, err := res.Routes[0].OverviewPolyline.Decode()
+	if err != nil || len(path) != 2 {
+		t.Errorf("test for overview polyline Failed - unexpected path %v, %v", path, err)
+	}
+
+	expected := map[string]string{
+		"origin":         "Alexanderplatz, Berlin",
+		"destination":    "place_id:B",
+		"mode":           "bicycling",
+		"waypoints":      "optimize:true|52.51,13.39",
+		"avoid":          "ferries|indoor",
+		"departure_time": "1720000000",
+	}
+	if requested.Path != "/maps/api/directions/json" {
+		t.Errorf("test for path Failed - results not match\nGot:\n%v\nExpected:\n%v", requested.Path, "/maps/api/directions/json")
+	}
+	for param, value := range expected {
+		if got := requested.Query().Get(param); got != value {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", param, got, value)
+		}
+	}
+
+	if _, err := g.Directions(context.TODO(), "a", "b", DirectionsOptions{DepartureTime: time.Now(), ArrivalTime: time.Now()}); err == nil {
+		t.Errorf("test for departure and arrival Failed - expected error")
+	}
+}
@@ -0,0 +1,101 @@
+package geocoder
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// Mean Earth radius in meters used by Haversine
+	earthRadius = 6371008.8
+	// WGS 84 ellipsoid used by Vincenty
+	wgs84SemiMajor   = 6378137.0
+	wgs84Flattening  = 1 / 298.257223563
+	vincentyMaxIters = 200
+)
+
+// Haversine returns the great-circle distance between a and b in meters on a spherical Earth.
+// Its error is up to 0.5%, which is enough to compare distances
+func Haversine(a, b Coordinate) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLng := lat2-lat1, radians(b.Lng-a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Vincenty returns the distance between a and b in meters on the WGS 84 ellipsoid, accurate
+// to millimeters. For nearly antipodal points, where the method doesn't converge, it falls
+// back to Haversine
+func Vincenty(a, b Coordinate) float64 {
+	const f = wgs84Flattening
+	const semiMinor = wgs84SemiMajor * (1 - f)
+	L := radians(b.Lng - a.Lng)
+	U1 := math.Atan((1 - f) * math.Tan(radians(a.Lat)))
+	U2 := math.Atan((1 - f) * math.Tan(radians(b.Lat)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	for i := 0; i < vincentyMaxIters; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			// not on the equator line
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) > 1e-12 {
+			continue
+		}
+
+		uSq := cosSqAlpha * (wgs84SemiMajor*wgs84SemiMajor - semiMinor*semiMinor) / (semiMinor * semiMinor)
+		A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+		B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		return semiMinor * A * (sigma - deltaSigma)
+	}
+	return Haversine(a, b)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Nearest returns the result whose location is closest to lat, lng, nil if there are no results.
+// Google orders results by relevance, which is not always the distance from the query point
+func (r *GoogleResponse) Nearest(lat, lng float64) *ResultSet {
+	query := Coordinate{Lat: lat, Lng: lng}
+	var nearest *ResultSet
+	best := math.Inf(1)
+	for _, res := range r.Results {
+		if d := Haversine(query, res.Geometry.Location); d < best {
+			nearest, best = res, d
+		}
+	}
+	return nearest
+}
+
+// SortByDistance sets Distance of every result to its distance from lat, lng and orders the
+// results nearest first. Results at the same distance keep Google's order. The response is
+// modified in place, copy it first if it may be shared, e.g. comes from a cache
+func (r *GoogleResponse) SortByDistance(lat, lng float64) {
+	query := Coordinate{Lat: lat, Lng: lng}
+	for _, res := range r.Results {
+		res.Distance = Haversine(query, res.Geometry.Location)
+	}
+	sort.SliceStable(r.Results, func(i, j int) bool {
+		return r.Results[i].Distance < r.Results[j].Distance
+	})
+}
@@ -0,0 +1,66 @@
+package geocoder
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_Distance(t *testing.T) {
+	berlin := Coordinate{Lat: 52.52, Lng: 13.405}
+	paris := Coordinate{Lat: 48.8566, Lng: 2.3522}
+	tests := []struct {
+		name     string
+		distance func(a, b Coordinate) float64
+		a, b     Coordinate
+		expected float64
+	}{
+		{"haversine", Haversine, berlin, paris, 877464.5},
+		{"vincenty", Vincenty, berlin, paris, 879699.3},
+		{"same point", Vincenty, berlin, berlin, 0},
+		{"antipodal fallback", Vincenty, Coordinate{Lat: 0, Lng: 0}, Coordinate{Lat: 0.5, Lng: 179.7}, 19950277.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.distance(tt.a, tt.b); math.Abs(got-tt.expected) > 1 {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_Nearest(t *testing.T) {
+	res := &GoogleResponse{Results: []*ResultSet{
+		{PlaceID: "far", Geometry: Geometry{Location: Coordinate{Lat: 52.53, Lng: 13.41}}},
+		{PlaceID: "near", Geometry: Geometry{Location: Coordinate{Lat: 52.5201, Lng: 13.4051}}},
+		{PlaceID: "farther", Geometry: Geometry{Location: Coordinate{Lat: 52.6, Lng: 13.5}}},
+	}}
+	if got := res.Nearest(52.52, 13.405); got == nil || got.PlaceID != "near" {
+		t.Errorf("test for nearest Failed - results not match\nGot:\n%v\nExpected:\n%v", got, "near")
+	}
+	if got := (&GoogleResponse{}).Nearest(52.52, 13.405); got != nil {
+		t.Errorf("test for no results Failed - results not match\nGot:\n%v\nExpected:\n%v", got, nil)
+	}
+}
+
+func Test_SortByDistance(t *testing.T) {
+	res := &GoogleResponse{Results: []*ResultSet{
+		{PlaceID: "far", Geometry: Geometry{Location: Coordinate{Lat: 52.53, Lng: 13.405}}},
+		{PlaceID: "near", Geometry: Geometry{Location: Coordinate{Lat: 52.5201, Lng: 13.405}}},
+		{PlaceID: "here", Geometry: Geometry{Location: Coordinate{Lat: 52.52, Lng: 13.405}}},
+	}}
+	res.SortByDistance(52.52, 13.405)
+
+	var got []string
+	for _, r := range res.Results {
+		got = append(got, r.PlaceID)
+	}
+	if expected := []string{"here", "near", "far"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("test for order Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+	// 0.0001 degree of latitude is about 11 m
+	if d := res.Results[1].Distance; math.Abs(d-11.1) > 0.1 {
+		t.Errorf("test for distance Failed - results not match\nGot:\n%v\nExpected:\n%v", d, 11.1)
+	}
+}
@@ -0,0 +1,67 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// maxElevationLocations is the number of locations the Elevation API accepts per request
+const maxElevationLocations = 512
+
+var apiElevation = &mapsAPI{path: "elevation", usage: UsageAPIElevation}
+
+// ElevationResult is the elevation of a location
+type ElevationResult struct {
+	// Meters above sea level, negative below it
+	Elevation float64    `json:"elevation"`
+	Location  Coordinate `json:"location"`
+	// Distance in meters between the data points the elevation was interpolated from
+	Resolution float64 `json:"resolution"`
+}
+
+// ElevationResponse is the response of the Elevation API
+type ElevationResponse struct {
+	Results      []ElevationResult    `json:"results"`
+	Status       GoogleResponseStatus `json:"status"`
+	ErrorMessage string               `json:"error_message,omitempty"`
+}
+
+// Elevation returns the elevations of points in their order. Points are sent in batches of
+// up to 512, each batch is a request limited by the EP_ELEVATION limiter. Statuses other than
+// OK fail with StatusError, with the results of the previous batches
+func (g *Geocoder) Elevation(ctx context.Context, points ...Coordinate) ([]ElevationResult, error) {
+	if len(points) == 0 {
+		return nil, errors.New("no points")
+	}
+	for _, p := range points {
+		if err := validateCoordinates(p.Lat, p.Lng); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]ElevationResult, 0, len(points))
+	for start := 0; start < len(points); start += maxElevationLocations {
+		end := min(start+maxElevationLocations, len(points))
+		locations := make([]string, 0, end-start)
+		for _, p := range points[start:end] {
+			locations = append(locations, formatLatLng(p.Lat, p.Lng, g.precision))
+		}
+		res := &ElevationResponse{}
+		status, err := g.callAPI(ctx, &request{
+			op:       OP_ELEVATION,
+			endpoint: EP_ELEVATION,
+			api:      apiElevation,
+			params:   url.Values{"locations": {strings.Join(locations, "|")}},
+		}, res)
+		if err == nil {
+			err = status.Err()
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, res.Results...)
+	}
+	return results, nil
+}
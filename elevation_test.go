@@ -0,0 +1,87 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// elevationClient answers with the latitude of every requested location as its elevation
+func elevationClient(batches *[]int) HttpRequester {
+	return requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		locations := strings.Split(u.Query().Get("locations"), "|")
+		*batches = append(*batches, len(locations))
+		results := make([]string, 0, len(locations))
+		for _, l := range locations {
+			lat, lng, err := ParseLatLng(l)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, fmt.Sprintf(`{"elevation":%v,"location":{"lat":%v,"lng":%v},"resolution":9.5}`, lat, lat, lng))
+		}
+		body := `{"status":"OK","results":[` + strings.Join(results, ",") + `]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func Test_Elevation(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   int
+		expected []int
+	}{
+		{"single", 1, []int{1}},
+		{"full batch", 512, []int{512}},
+		{"batches", 1100, []int{512, 512, 76}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batches []int
+			g, err := NewGeocoder(nil, "https://localhost", "en", elevationClient(&batches), 1000, time.Millisecond, nil,
+				WithAPIKey("key"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			points := make([]Coordinate, tt.points)
+			for i := range points {
+				points[i] = Coordinate{Lat: float64(i) / 100, Lng: 1}
+			}
+			results, err := g.Elevation(context.TODO(), points...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(batches) != fmt.Sprint(tt.expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, batches, tt.expected)
+			}
+			if len(results) != tt.points || results[tt.points-1].Elevation != points[tt.points-1].Lat {
+				t.Errorf("test for %v Failed - unexpected results %v", tt.name, len(results))
+			}
+		})
+	}
+}
+
+func Test_ElevationStatus(t *testing.T) {
+	g, err := NewGeocoder(nil, "https://localhost", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"INVALID_REQUEST","error_message":"Invalid locations","results":[]}`},
+		1000, time.Millisecond, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Elevation(context.TODO(), Coordinate{Lat: 1, Lng: 1}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("test for status Failed - unexpected error %v", err)
+	}
+	if _, err := g.Elevation(context.TODO(), Coordinate{Lat: 100, Lng: 1}); !errors.Is(err, ErrInvalidCoordinates) {
+		t.Errorf("test for invalid point Failed - unexpected error %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package geocoder
+
+import (
+	"errors"
+
+	"golang.org/x/time/rate"
+)
+
+// Endpoint is a family of Google Maps Platform APIs with its own quota
+type Endpoint string
+
+const (
+	EP_GEOCODING          Endpoint = "geocoding"
+	EP_PLACES             Endpoint = "places"
+	EP_TIMEZONE           Endpoint = "timezone"
+	EP_ELEVATION          Endpoint = "elevation"
+	EP_ADDRESS_VALIDATION Endpoint = "address_validation"
+	EP_DIRECTIONS         Endpoint = "directions"
+	EP_ROADS              Endpoint = "roads"
+	EP_GEOLOCATION        Endpoint = "geolocation"
+)
+
+// endpoints lists the families with a limiter of their own
+var endpoints = []Endpoint{EP_GEOCODING, EP_PLACES, EP_TIMEZONE, EP_ELEVATION, EP_ADDRESS_VALIDATION, EP_DIRECTIONS, EP_ROADS, EP_GEOLOCATION}
+
+// WithEndpointRPS limits requests to endpoint to requestPerSecond, independently of the other
+// endpoints. Endpoints without a limit of their own are limited to the requests per second given
+// to NewGeocoder, each on its own. For EP_GEOCODING it overrides the NewGeocoder value
+func WithEndpointRPS(endpoint Endpoint, requestPerSecond int) Option {
+	return func(g *Geocoder) {
+		if requestPerSecond <= 0 {
+			return
+		}
+		if endpoint == EP_GEOCODING {
+			g.rps = requestPerSecond
+			return
+		}
+		if g.endpointRPS == nil {
+			g.endpointRPS = make(map[Endpoint]int)
+		}
+		g.endpointRPS[endpoint] = requestPerSecond
+	}
+}
+
+// SetEndpointRPS changes the number of requests per second of endpoint at runtime.
+// For EP_GEOCODING it is SetRPS
+func (g *Geocoder) SetEndpointRPS(endpoint Endpoint, requestPerSecond int) error {
+	if endpoint == EP_GEOCODING || endpoint == "" {
+		return g.SetRPS(requestPerSecond)
+	}
+	if requestPerSecond <= 0 {
+		return errors.New("requestPerSecond must be a positive number")
+	}
+	limiter, ok := g.limiters[endpoint]
+	if !ok {
+		return errors.New("unknown endpoint " + string(endpoint))
+	}
+	limiter.SetLimitAt(g.clock.Now(), rate.Limit(requestPerSecond))
+	return nil
+}
+
+// newLimiters creates a limiter per endpoint family, the geocoding one is g.limiter
+func (g *Geocoder) newLimiters() {
+	g.limiter = rate.NewLimiter(rate.Limit(g.rps), g.burst)
+	g.limiters = make(map[Endpoint]*rate.Limiter, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint == EP_GEOCODING {
+			g.limiters[endpoint] = g.limiter
+			continue
+		}
+		rps, ok := g.endpointRPS[endpoint]
+		if !ok {
+			rps = g.rps
+		}
+		g.limiters[endpoint] = rate.NewLimiter(rate.Limit(rps), g.burst)
+	}
+}
+
+// limiterFor returns the limiter of endpoint, requests without an endpoint are geocoding requests
+func (g *Geocoder) limiterFor(endpoint Endpoint) *rate.Limiter {
+	if limiter, ok := g.limiters[endpoint]; ok {
+		return limiter
+	}
+	return g.limiter
+}
@@ -0,0 +1,54 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_EndpointRPS(t *testing.T) {
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: "bXlfdGVzdF9rZXk="}, "https://localhost", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1, time.Millisecond, nil,
+		WithEndpointRPS(EP_PLACES, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		endpoint Endpoint
+		expected float64
+	}{
+		{EP_GEOCODING, 1},
+		{EP_PLACES, 5},
+		{EP_TIMEZONE, 1},
+	}
+	for _, tt := range tests {
+		if got := float64(g.limiterFor(tt.endpoint).Limit()); got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.endpoint, got, tt.expected)
+		}
+	}
+
+	// exhausting the geocoding quota leaves the other endpoints alone
+	if _, err := g.TryReverseGeocode(context.TODO(), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.TryReverseGeocode(context.TODO(), 1, 1); err != ErrRateLimited {
+		t.Errorf("test for geocoding limit Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrRateLimited)
+	}
+	if err := g.acquire(context.TODO(), g.limiterFor(EP_TIMEZONE), true); err != nil {
+		t.Errorf("test for timezone limit Failed - unexpected error %v", err)
+	}
+
+	if err := g.SetEndpointRPS(EP_TIMEZONE, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := float64(g.limiterFor(EP_TIMEZONE).Limit()); got != 3 {
+		t.Errorf("test for SetEndpointRPS Failed - results not match\nGot:\n%v\nExpected:\n%v", got, 3)
+	}
+	if got := g.GetRPS(); got != 1 {
+		t.Errorf("test for geocoding rps Failed - results not match\nGot:\n%v\nExpected:\n%v", got, 1)
+	}
+	if err := g.SetEndpointRPS("unknown", 3); err == nil {
+		t.Errorf("test for unknown endpoint Failed - expected an error")
+	}
+}
@@ -0,0 +1,165 @@
+package geocoder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors of Google statuses, matched with errors.Is by StatusError, QuotaError and
+// HTTPError of HTTP 429. ReverseGeocode and Geocode return StatusError only with WithStatusErrors,
+// by default they return the response with a nil error
+var (
+	ErrOverQueryLimit = errors.New("over query limit")
+	ErrRequestDenied  = errors.New("request denied")
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrZeroResults    = errors.New("zero results")
+	ErrUnknownError   = errors.New("unknown error")
+)
+
+// statusSentinels maps response statuses to their sentinel errors
+var statusSentinels = map[GoogleResponseStatus]error{
+	GRS_OVER_QUERY_LIMIT: ErrOverQueryLimit,
+	GRS_REQUEST_DENIED:   ErrRequestDenied,
+	GRS_INVALID_REQUEST:  ErrInvalidRequest,
+	GRS_ZERO_RESULTS:     ErrZeroResults,
+	GRS_UNKNOWN_ERROR:    ErrUnknownError,
+}
+
+// maxErrorBodySnippet bounds the size of the response body kept in HTTPError
+const maxErrorBodySnippet = 512
+
+// HTTPError is returned when Google or an intermediate proxy responds with a non-200 HTTP status
+type HTTPError struct {
+	StatusCode int
+	// Beginning of the response body, at most 512 bytes
+	Body string
+	// Wait time requested by the Retry-After header of a 429 response, zero if absent
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("unexpected HTTP status %d, retry after %v: %s", e.StatusCode, e.RetryAfter, e.Body)
+	}
+	return fmt.Sprintf("unexpected HTTP status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is makes HTTP 429 match ErrOverQueryLimit
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrOverQueryLimit && e.StatusCode == http.StatusTooManyRequests
+}
+
+// Retryable reports whether the request may succeed if repeated, that is for 429 and 5xx statuses
+func (e *HTTPError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// DecodeError is returned when a response body can't be decoded
+type DecodeError struct {
+	// Beginning of the response body, at most 512 bytes
+	Snippet string
+	Err     error
+}
+
+func newDecodeError(body []byte, err error) *DecodeError {
+	if len(body) > maxErrorBodySnippet {
+		body = body[:maxErrorBodySnippet]
+	}
+	return &DecodeError{Snippet: string(body), Err: err}
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response failed: %v: %s", e.Err, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// parseRetryAfter parses Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// QuotaError is returned in non-blocking cooldown mode when Google responds with OVER_QUERY_LIMIT
+type QuotaError struct {
+	// Response as returned by Google
+	Response *GoogleResponse
+	// End of the cooldown, requests are held back until then
+	Until time.Time
+}
+
+func (e *QuotaError) Error() string {
+	if e.Response.ErrorMessage != "" {
+		return fmt.Sprintf("%s: %s, cooling down until %s", e.Response.Status, e.Response.ErrorMessage, e.Until.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s, cooling down until %s", e.Response.Status, e.Until.Format(time.RFC3339))
+}
+
+// Is makes QuotaError match ErrOverQueryLimit
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrOverQueryLimit
+}
+
+// StatusError is a response whose status is not OK, see GoogleResponse.Err and WithStatusErrors
+type StatusError struct {
+	Status GoogleResponseStatus
+	// Explanation Google gives for REQUEST_DENIED, INVALID_REQUEST and other errors, may be empty
+	ErrorMessage string
+	// Response as returned by Google
+	Response *GoogleResponse
+}
+
+func (e *StatusError) Error() string {
+	if e.ErrorMessage != "" {
+		return fmt.Sprintf("geocoding status %s: %s", e.Status, e.ErrorMessage)
+	}
+	return fmt.Sprintf("geocoding status %s", e.Status)
+}
+
+// Is makes StatusError match the sentinel error of its status, e.g. ErrZeroResults
+func (e *StatusError) Is(target error) bool {
+	return target != nil && statusSentinels[e.Status] == target
+}
+
+// Err returns StatusError if the status is not OK, nil otherwise
+func (r *GoogleResponse) Err() error {
+	if r.Status == GRS_OK {
+		return nil
+	}
+	return &StatusError{Status: r.Status, ErrorMessage: r.ErrorMessage, Response: r}
+}
+
+// WithStatusErrors makes ReverseGeocode, TryReverseGeocode and Geocode return StatusError
+// instead of a response whose status is not OK, ZERO_RESULTS included
+func WithStatusErrors() Option {
+	return func(g *Geocoder) {
+		g.statusErrors = true
+	}
+}
+
+// checkStatus turns a response whose status is not OK into StatusError if WithStatusErrors is set
+func (g *Geocoder) checkStatus(res *GoogleResponse, err error) (*GoogleResponse, error) {
+	if err != nil || !g.statusErrors {
+		return res, err
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,173 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_HTTPError(t *testing.T) {
+	client := &flakyHttpRequester{failures: 1, failureStatus: http.StatusBadGateway}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second, nil)
+
+	_, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("test for HTTPError Failed - unexpected error %v", err)
+	}
+	expected := &HTTPError{StatusCode: http.StatusBadGateway, Body: "<html>Bad Gateway</html>"}
+	if *he != *expected || !he.Retryable() {
+		t.Errorf("test for HTTPError Failed - results not match\nGot:\n%+v\nExpected:\n%+v", he, expected)
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{"Seconds", "120", 2 * time.Minute},
+		{"HTTP date", "Mon, 01 Mar 2021 12:00:30 GMT", 30 * time.Second},
+		{"Date in the past", "Mon, 01 Mar 2021 11:00:00 GMT", 0},
+		{"Empty", "", 0},
+		{"Garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if res := parseRetryAfter(tt.header, now); res != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_RetryAfterCooldown(t *testing.T) {
+	client := requesterFunc(func(string) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Retry-After", "3600")
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: http.NoBody}, nil
+	})
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil)
+
+	_, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+	var he *HTTPError
+	if !errors.As(err, &he) || he.RetryAfter != time.Hour {
+		t.Fatalf("test for Retry-After Failed - unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); err != ErrCoolingDown {
+		t.Errorf("test for Retry-After cooldown Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrCoolingDown)
+	}
+}
+
+type requesterFunc func(targetURL string) (*http.Response, error)
+
+func (f requesterFunc) Get(targetURL string) (*http.Response, error) { return f(targetURL) }
+
+func Test_StatusErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"ok", `{"status":"OK"}`, ""},
+		{"zero results", `{"status":"ZERO_RESULTS"}`, "geocoding status ZERO_RESULTS"},
+		{"request denied", `{"status":"REQUEST_DENIED","error_message":"The provided API key is invalid."}`,
+			"geocoding status REQUEST_DENIED: The provided API key is invalid."},
+		{"invalid request", `{"status":"INVALID_REQUEST","error_message":"Invalid request. Missing the 'address' parameter."}`,
+			"geocoding status INVALID_REQUEST: Invalid request. Missing the 'address' parameter."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGeocoder(nil, "https://localhost", "en", &fakeHttpRequester{responseBodyJSON: tt.body}, 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithStatusErrors())
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+			got := ""
+			if err != nil {
+				got = err.Error()
+				var se *StatusError
+				if !errors.As(err, &se) || res != nil || se.Response == nil {
+					t.Errorf("test for %v Failed - unexpected response %v, error %v", tt.name, res, err)
+				}
+			}
+			if got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_SentinelErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		expected bool
+	}{
+		{"zero results", &StatusError{Status: GRS_ZERO_RESULTS}, ErrZeroResults, true},
+		{"request denied", &StatusError{Status: GRS_REQUEST_DENIED}, ErrRequestDenied, true},
+		{"invalid request", &StatusError{Status: GRS_INVALID_REQUEST}, ErrInvalidRequest, true},
+		{"unknown error", &StatusError{Status: GRS_UNKNOWN_ERROR}, ErrUnknownError, true},
+		{"over query limit status", &StatusError{Status: GRS_OVER_QUERY_LIMIT}, ErrOverQueryLimit, true},
+		{"other status", &StatusError{Status: GRS_ZERO_RESULTS}, ErrRequestDenied, false},
+		{"quota error", &QuotaError{Response: &GoogleResponse{Status: GRS_OVER_QUERY_LIMIT}}, ErrOverQueryLimit, true},
+		{"HTTP 429", &HTTPError{StatusCode: http.StatusTooManyRequests}, ErrOverQueryLimit, true},
+		{"HTTP 503", &HTTPError{StatusCode: http.StatusServiceUnavailable}, ErrOverQueryLimit, false},
+		{"swapped coordinates", ErrSwappedCoordinates, ErrInvalidCoordinates, true},
+		{"wrapped", fmt.Errorf("verifying address: %w", &StatusError{Status: GRS_ZERO_RESULTS}), ErrZeroResults, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.sentinel); got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_SentinelErrorsReverseGeocode(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		opts     []Option
+		sentinel error
+		expected bool
+	}{
+		{"zero results by default", `{"status":"ZERO_RESULTS","results":[]}`, nil, ErrZeroResults, false},
+		{"zero results", `{"status":"ZERO_RESULTS","results":[]}`, []Option{WithStatusErrors()}, ErrZeroResults, true},
+		{"request denied", `{"status":"REQUEST_DENIED","results":[]}`, []Option{WithStatusErrors()}, ErrRequestDenied, true},
+		{"invalid request", `{"status":"INVALID_REQUEST","results":[]}`, []Option{WithStatusErrors()}, ErrInvalidRequest, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+				&fakeHttpRequester{responseBodyJSON: tt.body}, 1000, time.Second, nil, append(tt.opts, WithAPIKey("key"))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.ReverseGeocode(context.TODO(), 52.52, 13.405)
+			if got := errors.Is(err, tt.sentinel); got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+			if (res == nil) != tt.expected {
+				t.Errorf("test for %v Failed - unexpected response %v", tt.name, res)
+			}
+		})
+	}
+}
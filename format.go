@@ -0,0 +1,77 @@
+package geocoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+// OutputFormat is the response format of the geocoding endpoint
+type OutputFormat string
+
+const (
+	OF_JSON OutputFormat = "json"
+	OF_XML  OutputFormat = "xml"
+)
+
+// WithOutputFormat makes the Geocoder request and decode responses in format, JSON by default.
+// A base URL ending with the /json or /xml output segment is switched to format
+func WithOutputFormat(format OutputFormat) Option {
+	return func(g *Geocoder) {
+		if format != OF_JSON && format != OF_XML {
+			return
+		}
+		g.format = format
+		endpoint := g.builder.Endpoint
+		for _, f := range []OutputFormat{OF_JSON, OF_XML} {
+			if strings.HasSuffix(endpoint, "/"+string(f)) {
+				g.builder.Endpoint = strings.TrimSuffix(endpoint, string(f)) + string(format)
+				return
+			}
+		}
+	}
+}
+
+// WithStrictDecoding makes responses with fields unknown to GoogleResponse or without status
+// fail with DecodeError, to detect schema drift and broken proxies instead of getting zero
+// valued results. Unknown fields are only detected in JSON responses
+func WithStrictDecoding() Option {
+	return func(g *Geocoder) {
+		g.strictDecoding = true
+	}
+}
+
+// WithRawBody keeps the untouched response body in GoogleResponse.Raw, e.g. to archive
+// upstream payloads for audit or replay
+func WithRawBody() Option {
+	return func(g *Geocoder) {
+		g.rawBody = true
+	}
+}
+
+// decode decodes a geocoding response body in the configured format
+func (g *Geocoder) decode(raw []byte) (*GoogleResponse, error) {
+	res := &GoogleResponse{}
+	var err error
+	if g.format == OF_XML {
+		err = xml.Unmarshal(raw, res)
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if g.strictDecoding {
+			dec.DisallowUnknownFields()
+		}
+		err = dec.Decode(res)
+	}
+	if err == nil && g.strictDecoding && res.Status == "" {
+		err = errors.New("missing status")
+	}
+	if err != nil {
+		return nil, newDecodeError(raw, err)
+	}
+	if g.rawBody {
+		res.Raw = raw
+	}
+	return res, nil
+}
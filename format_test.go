@@ -0,0 +1,160 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const xmlResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<GeocodeResponse>
+ <status>OK</status>
+ <result>
+  <type>street_address</type>
+  <formatted_address>277 Bedford Ave, Brooklyn, NY 11211, USA</formatted_address>
+  <address_component>
+   <long_name>277</long_name>
+   <short_name>277</short_name>
+   <type>street_number</type>
+  </address_component>
+  <address_component>
+   <long_name>United States</long_name>
+   <short_name>US</short_name>
+   <type>country</type>
+   <type>political</type>
+  </address_component>
+  <geometry>
+   <location>
+    <lat>40.7142205</lat>
+    <lng>-73.9612903</lng>
+   </location>
+   <location_type>ROOFTOP</location_type>
+   <viewport>
+    <southwest>
+     <lat>40.7128715</lat>
+     <lng>-73.9626393</lng>
+    </southwest>
+    <northeast>
+     <lat>40.7155695</lat>
+     <lng>-73.9599413</lng>
+    </northeast>
+   </viewport>
+  </geometry>
+  <place_id>ChIJd8BlQ2BZwokRAFUEcm_qrcA</place_id>
+ </result>
+</GeocodeResponse>`
+
+func Test_OutputFormatXML(t *testing.T) {
+	var requested string
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		requested = targetURL
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(xmlResponse))}, nil
+	})
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: "bXlfdGVzdF9rZXk="},
+		"https://maps.googleapis.com/maps/api/geocode/json", "en", client, 100, time.Millisecond, nil,
+		WithOutputFormat(OF_XML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.ReverseGeocode(context.TODO(), 40.714224, -73.961452)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(requested, "https://maps.googleapis.com/maps/api/geocode/xml?") {
+		t.Errorf("test for endpoint Failed - results not match\nGot:\n%v\nExpected:\n%v", requested, ".../geocode/xml")
+	}
+	expected := &GoogleResponse{
+		Status: GRS_OK,
+		Results: []*ResultSet{{
+			AddressComponents: []AddressComponent{
+				{LongName: "277", ShortName: "277", Types: []string{"street_number"}},
+				{LongName: "United States", ShortName: "US", Types: []string{"country", "political"}},
+			},
+			FormattedAddress: "277 Bedford Ave, Brooklyn, NY 11211, USA",
+			Geometry: Geometry{
+				Location:     Coordinate{Lat: 40.7142205, Lng: -73.9612903},
+				LocationType: LT_ROOFTOP,
+				Viewport: Bounds{
+					SouthWest: Coordinate{Lat: 40.7128715, Lng: -73.9626393},
+					NorthEast: Coordinate{Lat: 40.7155695, Lng: -73.9599413},
+				},
+			},
+			PlaceID: "ChIJd8BlQ2BZwokRAFUEcm_qrcA",
+			Types:   []string{"street_address"},
+		}},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("test for XML decoding Failed - results not match\nGot:\n%+v\nExpected:\n%+v", res.Results[0], expected.Results[0])
+	}
+}
+
+func Test_StrictDecoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		strict bool
+		valid  bool
+	}{
+		{"known fields", `{"status":"OK","plus_code":{"global_code":"8FVC9G8F+5W"},"results":[{"place_id":"x","postcode_localities":["a"]}]}`, true, true},
+		{"unknown field", `{"status":"OK","results":[{"place_id":"x","new_field":1}]}`, true, false},
+		{"missing status", `{"results":[]}`, true, false},
+		{"proxy page", `<html>Bad Gateway</html>`, true, false},
+		{"unknown field lenient", `{"status":"OK","results":[{"place_id":"x","new_field":1}]}`, false, true},
+		{"proxy page lenient", `<html>Bad Gateway</html>`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithAPIKey("key")}
+			if tt.strict {
+				opts = append(opts, WithStrictDecoding())
+			}
+			g, err := NewGeocoder(nil, "https://localhost", "en", &fakeHttpRequester{responseBodyJSON: tt.body}, 1000, time.Millisecond, nil, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = g.ReverseGeocode(context.TODO(), 1, 1)
+			var decodeErr *DecodeError
+			if valid := !errors.As(err, &decodeErr); valid != tt.valid {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.valid)
+			}
+			if decodeErr != nil && decodeErr.Snippet != tt.body {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, decodeErr.Snippet, tt.body)
+			}
+		})
+	}
+}
+
+func Test_RawBody(t *testing.T) {
+	body := `{"status" : "OK", "results" : [], "unknown": true}`
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"enabled", []Option{WithAPIKey("key"), WithRawBody()}, body},
+		{"disabled", []Option{WithAPIKey("key")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGeocoder(nil, "https://localhost", "en", &fakeHttpRequester{responseBodyJSON: body}, 1000, time.Millisecond, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(res.Raw) != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%s\nExpected:\n%s", tt.name, res.Raw, tt.expected)
+			}
+		})
+	}
+}
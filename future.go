@@ -0,0 +1,47 @@
+package geocoder
+
+import "context"
+
+// Future is the pending result of an asynchronous geocoding call
+type Future struct {
+	done chan struct{}
+	res  *GoogleResponse
+	err  error
+}
+
+// NewFuture runs fn in a new goroutine and returns its Future, for Interface implementations
+func NewFuture(fn func() (*GoogleResponse, error)) *Future {
+	f := &Future{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		f.res, f.err = fn()
+	}()
+	return f
+}
+
+// Done returns a channel closed when the result is ready
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get waits for the call to complete and returns its result
+func (f *Future) Get() (*GoogleResponse, error) {
+	<-f.done
+	return f.res, f.err
+}
+
+// ReverseGeocodeAsync starts ReverseGeocode in background and returns its Future right away.
+// Cancelling ctx cancels the call
+func (g *Geocoder) ReverseGeocodeAsync(ctx context.Context, lat, lng float64) *Future {
+	return NewFuture(func() (*GoogleResponse, error) {
+		return g.ReverseGeocode(ctx, lat, lng)
+	})
+}
+
+// GeocodeAsync starts Geocode in background and returns its Future right away.
+// Cancelling ctx cancels the call
+func (g *Geocoder) GeocodeAsync(ctx context.Context, address string) *Future {
+	return NewFuture(func() (*GoogleResponse, error) {
+		return g.Geocode(ctx, address)
+	})
+}
@@ -0,0 +1,29 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_ReverseGeocodeAsync(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1000, time.Second, nil)
+
+	futures := make([]*Future, 5)
+	for i := range futures {
+		futures[i] = geocoder.ReverseGeocodeAsync(context.TODO(), 49.17584440, 7.30196070)
+	}
+	for i, f := range futures {
+		select {
+		case <-f.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("test for future %d Failed - not done in time", i)
+		}
+		res, err := f.Get()
+		if err != nil || res.Status != GRS_OK {
+			t.Errorf("test for future %d Failed - unexpected result %v %v", i, res, err)
+		}
+	}
+}
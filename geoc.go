@@ -2,20 +2,33 @@ package geocoder
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha1" //nolint
-	"encoding/base64"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
 )
 
+// ErrCoolingDown is returned when the Geocoder is cooling down after OVER_QUERY_LIMIT
+// and the caller's context expires before the cooldown ends
+var ErrCoolingDown = errors.New("geocoder is cooling down after OVER_QUERY_LIMIT")
+
+// ErrRateLimited is returned by non-blocking calls when the rate limit doesn't allow a request
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// ErrWouldExceedDeadline is returned right away when the limiter wait of a request is longer
+// than what is left of the caller's context deadline. Batch requests wait for the tokens of the
+// batch share and of the Geocoder limiter together, see WithInteractiveReserve
+var ErrWouldExceedDeadline = errors.New("rate limiter wait would exceed context deadline")
+
+// HttpRequester is the HTTP client of NewGeocoder. Clients implementing HttpDoer too, like
+// *http.Client and DefaultHTTPRequester, get the request context, headers and POST requests.
+// Wrap Do-only clients with DoerFunc and transports with NewRoundTripperRequester
 type HttpRequester interface {
 	Get(targetURL string) (*http.Response, error)
 }
@@ -24,30 +37,157 @@ type RequestObserver interface {
 	ObserveHTTPRequest(label string, duration time.Duration)
 }
 
+// RequestInfo describes a completed HTTP request to the geocoding API
+type RequestInfo struct {
+	// Provider label, e.g. "google"
+	Label    string
+	Duration time.Duration
+	// Status of the decoded response, empty if the response body could not be decoded
+	Status GoogleResponseStatus
+	// Attempt number starting with 1, greater numbers are retries
+	Attempt int
+	// Final is true for the last attempt, whose outcome is returned to the caller
+	Final bool
+	// HTTP status code, zero if no response was received
+	HTTPStatusCode int
+	// Transport or decoding error, if any
+	Err error
+	// Cached is true if the response was served from cache without an HTTP request.
+	// Duration and HTTPStatusCode are zero in this case
+	Cached bool
+}
+
+// DetailedRequestObserver is an optional extension of RequestObserver. When the observer passed
+// to NewGeocoder implements it, ObserveRequest is called instead of ObserveHTTPRequest once
+// the response is decoded, and ObserveLimiterWait is called after every rate limiter wait
+type DetailedRequestObserver interface {
+	RequestObserver
+	ObserveRequest(info RequestInfo)
+	ObserveLimiterWait(label string, wait time.Duration)
+}
+
+// observerLabel is the provider label passed to RequestObserver
+const observerLabel = "google"
+
 type Geocoder struct {
-	// Google BusinessKey
-	businessKey *BusinessKey
-	// Geocoding URL, e.g. https://maps.googleapis.com/maps/api/geocode/json
-	baseURL string
-	// Set language to control output language of the geocoder. Leave empty to keep default behavior
-	language string
-	// HTTP Client
-	client HttpRequester
+	// Builds signed geocoding URLs from credentials, endpoint and language
+	builder RequestBuilder
+	// HTTP Client, Get-only clients are adapted by legacyDoer
+	client HttpDoer
 	// Requests per second
 	rps int
 	// Sleep interval if OVER_QUERY_LIMIT status has been received
 	overQuerySleepDuration time.Duration
+	// Return OVER_QUERY_LIMIT as QuotaError without waiting for the cooldown
+	nonBlockingCooldown bool
 	// Measures HTTP requests duration
 	observer RequestObserver
-	limiter  *rate.Limiter
+	// Limiter of EP_GEOCODING
+	limiter *rate.Limiter
+	// Limiter per endpoint family, including limiter
+	limiters map[Endpoint]*rate.Limiter
+	// Requests per second of endpoints other than EP_GEOCODING, rps if absent
+	endpointRPS map[Endpoint]int
+	// Limiter burst size
+	burst int
+	// Counts billable requests, optional
+	usage *UsageTracker
+	// Reject coordinates which look swapped
+	detectSwaps bool
+	// Wrap longitudes into [-180, 180) before validation
+	normalizeLng bool
+	// Splits addresses before forward geocoding, optional
+	addressParser AddressParser
+	// Address Validation API URL, see WithAddressValidationURL
+	addressValidationURL string
+	// Roads API base URL, see WithRoadsURL
+	roadsURL string
+	// Geolocation API URL, see WithGeolocationURL
+	geolocationURL string
+	// Headers of every request, see WithHeaders and WithHeaderFunc
+	header     http.Header
+	headerFunc func(ctx context.Context, h http.Header)
+	logger     *slog.Logger
+	debugHook  DebugHook
+	// Total number of attempts per request, 1 means no retries
+	maxAttempts int
+	// Delay before the first retry, doubled for every next one
+	retryBackoff time.Duration
+	cache        Cache
+	// Caches unsuccessful responses, optional
+	negative *negativeCache
+	// Counts lookups of cache and negative
+	cacheLookups cacheCounter
+	// Adjusts the rate after throttled and successful requests, optional
+	adaptive *adaptiveRate
+	// Flag results whose country can't contain the queried coordinate
+	countryCheck bool
+	// Serves coarse results under quota pressure, optional
+	degradation *degradation
+	// Response format, JSON if empty
+	format OutputFormat
+	// Fail on unknown fields and missing status, see WithStrictDecoding
+	strictDecoding bool
+	// Keep response bodies in GoogleResponse.Raw
+	rawBody bool
+	// Return StatusError instead of responses whose status is not OK
+	statusErrors bool
+	// Response bodies larger than this fail with ResponseTooLargeError
+	maxResponseSize int64
+	// Additional signing keys of the client ID and the rotation callback, see WithSigningKeys
+	signingKeys   []string
+	onKeyRotation func(SigningKeyRotation)
+	// Signs with the active signing key, nil without additional keys
+	keyRotation *keyRotation
+	// Credentials of the pool, see WithCredentialPool
+	poolStrategy    PoolStrategy
+	poolCredentials []Credential
+	// Picks the credential of every request, nil without a pool
+	pool *credentialPool
+	// Daily quota per credential, optional
+	quota     *QuotaTracker
+	quotaMode QuotaMode
+	// Counts requests per operation for UsageReport
+	operations operationCounter
+	// Fails requests fast while Google is failing, optional
+	breaker *circuitBreaker
+	// Round reverse geocoded coordinates to snapDecimals decimal places, see WithCoordinateSnapping
+	snap         bool
+	snapDecimals int
+	// Decimal places of coordinates in request URLs and cache keys, see WithCoordinatePrecision
+	precision int
+	// Cache reverse geocoding by geohash of this length, disabled if zero
+	geohashPrecision int
+	// Delay before a slow request is hedged and the endpoint of the hedged request, see WithHedging
+	hedgeDelay    time.Duration
+	hedgeEndpoint string
+	// Source of time, the system clock unless WithClock is given
+	clock Clock
+	// In-flight calls and the closed state, see Close
+	lifecycle lifecycle
+	// Counters of Stats
+	counters requestCounter
+	// Coordinate reverse geocoded by Ping, see WithPingProbe
+	probe *Coordinate
+
+	// What requests do while paused, see WithPausePolicy
+	pausePolicy PausePolicy
+	// Share of the rate PR_BATCH requests can't use, see WithInteractiveReserve
+	interactiveReserve float64
+
+	mu sync.Mutex
+	// No requests are sent until cooldownUntil
+	cooldownUntil time.Time
+	// Closed by Resume, nil unless paused
+	resumed chan struct{}
+	// Limiters of PR_BATCH requests by the limiter they share
+	batchLimiters map[*rate.Limiter]*rate.Limiter
 }
 
-// NewGeocoder creates new instance of Geocoder
+// NewGeocoder creates new instance of Geocoder. bkey may be nil if an API key is given with
+// WithAPIKey or WithAPIKeyHeader
 func NewGeocoder(bkey *BusinessKey, baseURL, language string, client HttpRequester,
-	requestPerSecond int, overQuerySleepDuration time.Duration, observer RequestObserver) (*Geocoder, error) {
-	if bkey == nil {
-		return nil, errors.New("empty BusinessKey")
-	}
+	requestPerSecond int, overQuerySleepDuration time.Duration, observer RequestObserver, opts ...Option) (*Geocoder, error) {
 	if baseURL == "" {
 		return nil, errors.New("empty baseURL, use https://maps.googleapis.com/maps/api/geocode/json")
 	}
@@ -57,107 +197,563 @@ func NewGeocoder(bkey *BusinessKey, baseURL, language string, client HttpRequest
 	if requestPerSecond <= 0 {
 		return nil, errors.New("requestPerSecond must be a positive number")
 	}
-	return &Geocoder{
-			bkey,
-			baseURL,
-			language,
-			client,
-			requestPerSecond,
-			overQuerySleepDuration,
-			observer,
-			rate.NewLimiter(rate.Limit(requestPerSecond), 1)},
-		nil
+	g := &Geocoder{
+		builder:                RequestBuilder{Endpoint: baseURL, Language: language, BusinessKey: bkey},
+		client:                 asDoer(client),
+		rps:                    requestPerSecond,
+		overQuerySleepDuration: overQuerySleepDuration,
+		observer:               observer,
+		burst:                  1,
+		logger:                 slog.New(discardHandler{}),
+		maxAttempts:            1,
+		maxResponseSize:        defaultMaxResponseSize,
+		precision:              defaultCoordinatePrecision,
+		addressValidationURL:   defaultAddressValidationURL,
+		roadsURL:               defaultRoadsURL,
+		geolocationURL:         defaultGeolocationURL,
+		clock:                  systemClock{},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if bkey == nil && g.builder.APIKey == "" && len(g.poolCredentials) == 0 {
+		return nil, errors.New("empty BusinessKey")
+	}
+	if _, ok := client.(HttpDoer); g.builder.APIKeyInHeader && !ok {
+		return nil, errors.New("HTTPClient must implement HttpDoer to send the API key in a header")
+	}
+	if _, ok := client.(HttpDoer); g.customHeaders() && !ok {
+		return nil, errors.New("HTTPClient must implement HttpDoer to send custom headers")
+	}
+	if g.interactiveReserve < 0 || g.interactiveReserve >= 1 {
+		return nil, errors.New("interactive reserve must be at least 0 and less than 1")
+	}
+	if len(g.signingKeys) > 0 {
+		rotation, err := newKeyRotation(bkey, g.signingKeys, g.onKeyRotation)
+		if err != nil {
+			return nil, err
+		}
+		g.keyRotation = rotation
+		g.builder.Signer = rotation
+	}
+	g.newLimiters()
+	g.shareClock()
+	if observer, ok := observer.(CacheObserver); ok && (g.cache != nil || g.negative != nil) {
+		observer.ObserveCacheStats(observerLabel, g.CacheStats)
+	}
+	if len(g.poolCredentials) > 0 {
+		pool, err := newCredentialPool(g.builder, g.poolStrategy, g.poolCredentials, requestPerSecond, g.burst, g.adaptive)
+		if err != nil {
+			return nil, err
+		}
+		g.pool = pool
+	}
+	return g, nil
+}
+
+// SetRPS changes the number of requests per second at runtime keeping the limiter state.
+// With adaptive rate enabled it changes the upper bound of the adaptive rate. Credentials of
+// WithCredentialPool without their own RPS follow the new rate.
+// It is safe to call concurrently with requests
+func (g *Geocoder) SetRPS(requestPerSecond int) error {
+	if requestPerSecond <= 0 {
+		return errors.New("requestPerSecond must be a positive number")
+	}
+	g.mu.Lock()
+	g.rps = requestPerSecond
+	g.mu.Unlock()
+
+	limit := float64(requestPerSecond)
+	if a := g.adaptive; a != nil {
+		limit = a.setCeiling(limit)
+	}
+	g.limiter.SetLimitAt(g.clock.Now(), rate.Limit(limit))
+	g.pool.setRPS(g.clock.Now(), requestPerSecond)
+	return nil
+}
+
+// GetRPS returns the configured number of requests per second
+func (g *Geocoder) GetRPS() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rps
 }
 
 // ReverseGeocode makes reverse geocoding against latitude, longitude and returns GoogleResponse.
-// The number of requests per second is respected
+// The number of requests per second is respected. While the Geocoder is cooling down after
+// OVER_QUERY_LIMIT the call waits until the cooldown ends or ctx is done.
+// NaN, infinite and out of range coordinates fail with ErrInvalidCoordinates without a request.
+// Responses whose status is not OK, e.g. ZERO_RESULTS, are returned with a nil error unless
+// WithStatusErrors is set, only then errors.Is matches ErrZeroResults and the other status sentinels
 func (g *Geocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
-	err := g.limiter.Wait(ctx)
-	if err != nil {
-		return nil, err
+	return g.reverseGeocode(ctx, lat, lng, false)
+}
+
+// TryReverseGeocode is ReverseGeocode which never waits for the rate limiter or the cooldown.
+// ErrRateLimited or ErrCoolingDown is returned right away instead, and failed requests are not retried.
+// Cached responses are served as usual
+func (g *Geocoder) TryReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	return g.reverseGeocode(ctx, lat, lng, true)
+}
+
+func (g *Geocoder) reverseGeocode(ctx context.Context, lat, lng float64, noWait bool) (*GoogleResponse, error) {
+	if g.detectSwaps && looksSwapped(lat, lng) {
+		return nil, ErrSwappedCoordinates
 	}
-	ur, err := g.buildURL(lat, lng)
-	if err != nil {
+	if g.normalizeLng {
+		lng = normalizeLongitude(lng)
+	}
+	if err := validateCoordinates(lat, lng); err != nil {
 		return nil, err
 	}
+	if g.snap {
+		lat, lng = snapCoordinate(lat, lng, g.snapDecimals)
+	}
+	if g.degradation != nil && g.degradation.active() {
+		if res, ok := g.degradation.serve(ctx, lat, lng); ok {
+			return res, nil
+		}
+	}
+	key := "latlng:" + formatLatLng(lat, lng, g.precision)
+	if g.geohashPrecision > 0 {
+		key = "geohash:" + EncodeGeohash(lat, lng, g.geohashPrecision)
+	}
+	res, err := g.do(ctx, &request{
+		key:    key,
+		op:     OP_REVERSE_GEOCODING,
+		noWait: noWait,
+		params: latLngParams(lat, lng, g.precision),
+	})
+	if err == nil && g.countryCheck {
+		res = g.checkCountries(ctx, res, lat, lng)
+	}
+	if err == nil && g.degradation != nil {
+		g.degradation.learn(lat, lng, res)
+	}
+	return g.checkStatus(res, err)
+}
 
-	t := time.Now()
-	resp, err := g.client.Get(ur.String())
+// Geocode makes forward geocoding of address and returns GoogleResponse.
+// Rate limiting and cooldowns are the same as for ReverseGeocode.
+// Address may be a plus code, global or compound with a locality, invalid codes fail with ErrInvalidPlusCode.
+// Like ReverseGeocode, statuses other than OK are errors only with WithStatusErrors
+func (g *Geocoder) Geocode(ctx context.Context, address string) (*GoogleResponse, error) {
+	if strings.TrimSpace(address) == "" {
+		return nil, errors.New("empty address")
+	}
+	address, err := plusCodeAddress(address)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	params, key := g.addressParams(address)
+	return g.checkStatus(g.do(ctx, &request{
+		key:    key,
+		op:     OP_GEOCODING,
+		params: params,
+	}))
+}
 
-	if g.observer != nil {
-		g.observer.ObserveHTTPRequest("google", time.Since(t))
-	}
+// request describes a single geocoding call
+type request struct {
+	// Cache key, without language
+	key string
+	// Type of the request in UsageReport
+	op Operation
+	// Query parameters without credentials
+	params url.Values
+	// Fail instead of waiting for the limiter and the cooldown
+	noWait bool
+	// API family the request is limited by, EP_GEOCODING if empty
+	endpoint Endpoint
+	// Output language overriding the Geocoder one, part of the cache key
+	language string
+	// Hedged copy of a slow request, see WithHedging
+	hedge bool
+	// Maps web service of the request, geocoding if nil
+	api *mapsAPI
+	// JSON body of a POST request, the request is GET if nil
+	body []byte
+	// Skip the caches, e.g. for health probes
+	noCache bool
+	// Pool credential the request is sent with, picked by the pool strategy if nil
+	member *poolMember
+	// Return the response of the credentials as is, without key rotation or pool failover
+	diagnose bool
+	// Called once the limiter and the cooldown let the request through, right before it is sent
+	onSend func()
+}
 
-	var res *GoogleResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, err
+// do serves the request from cache or sends it, retrying failed attempts if retries are enabled
+func (g *Geocoder) do(ctx context.Context, req *request) (*GoogleResponse, error) {
+	if !g.lifecycle.enter() {
+		return nil, ErrClosed
+	}
+	defer g.lifecycle.leave()
+	ctx, cancel := g.lifecycle.context(ctx)
+	defer cancel()
+	detailed, _ := g.observer.(DetailedRequestObserver)
+	key := req.key
+	if g.quota != nil && g.quotaMode == QM_FAIL_FAST && g.quotaExhausted() {
+		return nil, ErrQuotaExhausted
+	}
+	if (g.cache != nil || g.negative != nil) && req.api == nil && !req.noCache {
+		language := req.language
+		if language == "" {
+			language = g.builder.Language
+		}
+		key = language + "|" + key
+		res, ok := g.cached(key)
+		g.cacheLookups.record(ok)
+		if ok {
+			g.logger.DebugContext(ctx, "geocoding cache hit", slog.String("key", key))
+			g.operations.cacheHit(req.op)
+			if detailed != nil {
+				detailed.ObserveRequest(RequestInfo{Label: observerLabel, Status: res.Status, Final: true, Cached: true})
+			}
+			return res, nil
+		}
+		g.logger.DebugContext(ctx, "geocoding cache miss", slog.String("key", key))
 	}
 
-	if res.Status == GRS_OVER_QUERY_LIMIT {
-		g.limiter.SetLimit(rate.Limit(0))
-		time.Sleep(g.overQuerySleepDuration)
-		g.limiter.SetLimit(rate.Limit(g.rps))
+	maxAttempts := g.maxAttempts
+	if req.noWait {
+		maxAttempts = 1
 	}
+	rotations := 0
+	for attempt := 1; ; attempt++ {
+		keyIndex := 0
+		if g.keyRotation != nil {
+			keyIndex = g.keyRotation.current()
+		}
+		member := req.member
+		if member == nil {
+			picked, err := g.pool.pick(g.quotaAvailable, g.clock.Now())
+			if err != nil {
+				return nil, err
+			}
+			member = picked
+		}
+		generation, err := g.breaker.allow(g.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		res, info, err := g.sendHedged(ctx, req, member)
+		g.breaker.record(g.clock.Now(), generation, classifyOutcome(ctx, err))
+		reason, rotate := "", false
+		if g.keyRotation != nil && rotations < len(g.keyRotation.signers)-1 && !req.diagnose {
+			reason, rotate = signatureRejection(res, err)
+		}
+		failover := false
+		if member != nil && !rotate && !req.diagnose {
+			if reason, failover = credentialRejection(res, err); failover {
+				failover = g.pool.disable(ctx, g.logger, member, reason)
+			}
+		}
+		final := !rotate && !failover && (err == nil || attempt >= maxAttempts || !isRetryable(ctx, err))
+		if detailed != nil && info != nil {
+			info.Attempt = attempt
+			info.Final = final
+			info.Err = err
+			detailed.ObserveRequest(*info)
+		}
+		if rotate {
+			g.keyRotation.rotate(ctx, g.logger, keyIndex, reason)
+			rotations++
+			continue
+		}
+		if failover {
+			continue
+		}
+		if final {
+			if err == nil && g.snap {
+				res.QueryKey = req.key
+			}
+			if err == nil && g.cache != nil && res.Status == GRS_OK && req.api == nil && !req.noCache {
+				g.cache.Set(key, res)
+			}
+			if err == nil && g.negative != nil && req.api == nil && !req.noCache {
+				g.negative.Set(key, res)
+			}
+			return res, err
+		}
 
-	return res, nil
+		g.counters.retries.Add(1)
+		backoff := g.retryBackoff << (attempt - 1)
+		g.logger.InfoContext(ctx, "retrying geocoding request",
+			slog.Int("attempt", attempt+1), slog.Duration("backoff", backoff), slog.Any("error", err))
+		if err := g.sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+	}
 }
 
-// buildURL constructs url for further reverse geocode request
-func (g *Geocoder) buildURL(lat, lng float64) (*url.URL, error) {
-	ur, err := url.Parse(g.baseURL)
+// send makes a single attempt with the pool credential member, if any, once the limiter and the cooldown allow it.
+// The returned RequestInfo is nil if no request was sent
+func (g *Geocoder) send(ctx context.Context, req *request, member *poolMember) (*GoogleResponse, *RequestInfo, error) {
+	builder, limiter := &g.builder, g.limiterFor(req.endpoint)
+	// Pool member whose limiter is used, nil for the Geocoder one
+	var limitedBy *poolMember
+	if member != nil {
+		builder = &member.builder
+		if req.endpoint == "" || req.endpoint == EP_GEOCODING {
+			limiter, limitedBy = member.limiter, member
+		}
+	}
+	if req.hedge && g.hedgeEndpoint != "" {
+		secondary := *builder
+		secondary.Endpoint = g.hedgeEndpoint
+		builder = &secondary
+	}
+	usageAPI := UsageAPIGeocoding
+	if req.api != nil {
+		service := *builder
+		service.Endpoint = apiEndpoint(builder.Endpoint, req.api)
+		if req.api.rest {
+			// a pool credential without API key, the REST APIs would answer 403 to a BusinessKey
+			if service.APIKey == "" {
+				return nil, nil, ErrAPIKeyRequired
+			}
+			service.Endpoint, service.Language, service.BusinessKey = req.api.url, "", nil
+		}
+		builder = &service
+		usageAPI = req.api.usage
+	}
+	if err := g.acquire(ctx, limiter, req.noWait); err != nil {
+		return nil, nil, err
+	}
+	if g.quota != nil && !g.quota.take(g.clock.Now(), credentialName(builder)) {
+		return nil, nil, ErrQuotaExhausted
+	}
+	g.operations.request(req.op)
+	var ur *url.URL
+	var err error
+	if req.api != nil && req.api.rest {
+		ur, err = builder.URL(req.params)
+	} else {
+		ur, err = g.signedURLWith(builder, req.params)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	query := url.Values{}
-	query.Add("latlng", fmt.Sprintf("%.8f,%.8f", lat, lng))
-	query.Add("sensor", "false")
-	if g.language != "" {
-		query.Add("language", g.language)
+	if req.onSend != nil {
+		req.onSend()
+	}
+	g.logger.DebugContext(ctx, "geocoding request started", slog.String("url", redactURL(ur.String())))
+	t := g.clock.Now()
+	var resp *http.Response
+	if req.body != nil {
+		resp, err = g.post(ctx, builder, ur.String(), req.body)
+	} else {
+		resp, err = g.get(ctx, builder, ur.String())
 	}
-	if g.businessKey != nil {
-		query.Add("client", g.businessKey.ClientID)
-		if g.businessKey.Channel != "" {
-			query.Add("channel", g.businessKey.Channel)
+	if err != nil {
+		g.logger.DebugContext(ctx, "geocoding request failed", slog.Any("error", err))
+		if g.debugHook != nil {
+			g.debugHook(ctx, DebugInfo{URL: redactURL(ur.String()), Duration: g.clock.Now().Sub(t), Err: err})
 		}
+		return nil, &RequestInfo{Label: observerLabel, Duration: g.clock.Now().Sub(t)}, &transportError{err}
 	}
+	defer resp.Body.Close()
 
-	ur.RawQuery = query.Encode()
+	if g.usage != nil {
+		g.usage.Record(t, builder.clientID(), builder.channel(), usageAPI)
+	}
 
-	signature, err := g.getSignature(ur.Path + "?" + ur.RawQuery)
+	if _, detailed := g.observer.(DetailedRequestObserver); g.observer != nil && !detailed {
+		g.observer.ObserveHTTPRequest(observerLabel, g.clock.Now().Sub(t))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySnippet))
+		err := &HTTPError{StatusCode: resp.StatusCode, Body: string(snippet)}
+		g.logger.DebugContext(ctx, "geocoding request failed", slog.Any("error", err))
+		if resp.StatusCode == http.StatusTooManyRequests {
+			g.adaptRate(limitedBy, true)
+			err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), g.clock.Now())
+			cooldown := err.RetryAfter
+			if cooldown == 0 {
+				cooldown = g.overQuerySleepDuration
+			}
+			g.logger.InfoContext(ctx, "cooling down after HTTP 429", slog.Duration("cooldown", cooldown))
+			g.startCooldown(cooldown)
+		}
+		if g.debugHook != nil {
+			g.debugHook(ctx, DebugInfo{URL: redactURL(ur.String()), StatusCode: resp.StatusCode, Body: snippet, Duration: g.clock.Now().Sub(t), Err: err})
+		}
+		return nil, &RequestInfo{Label: observerLabel, Duration: g.clock.Now().Sub(t), HTTPStatusCode: resp.StatusCode}, err
+	}
+
+	raw, err := readBody(resp.Body, g.maxResponseSize)
 	if err != nil {
-		return nil, err
+		g.logger.DebugContext(ctx, "geocoding response reading failed", slog.Any("error", err))
+		if g.debugHook != nil {
+			g.debugHook(ctx, DebugInfo{URL: redactURL(ur.String()), StatusCode: resp.StatusCode, Duration: g.clock.Now().Sub(t), Err: err})
+		}
+		return nil, &RequestInfo{Label: observerLabel, Duration: g.clock.Now().Sub(t), HTTPStatusCode: resp.StatusCode}, err
+	}
+
+	var res *GoogleResponse
+	if req.api != nil {
+		res, err = decodeAPI(raw, req.api.rest)
+	} else {
+		res, err = g.decode(raw)
+	}
+	if g.debugHook != nil {
+		g.debugHook(ctx, DebugInfo{URL: redactURL(ur.String()), StatusCode: resp.StatusCode, Body: raw, Duration: g.clock.Now().Sub(t), Err: err})
+	}
+	info := &RequestInfo{Label: observerLabel, Duration: g.clock.Now().Sub(t), HTTPStatusCode: resp.StatusCode}
+	if err != nil {
+		g.logger.DebugContext(ctx, "geocoding response decoding failed", slog.Any("error", err))
+		return nil, info, err
 	}
+	info.Status = res.Status
+	g.logger.DebugContext(ctx, "geocoding request finished",
+		slog.String("status", string(res.Status)), slog.Duration("duration", info.Duration))
 
-	query.Add("signature", signature)
-	ur.RawQuery = query.Encode()
+	g.adaptRate(limitedBy, res.Status == GRS_OVER_QUERY_LIMIT)
+	if res.Status == GRS_OVER_QUERY_LIMIT {
+		g.logger.InfoContext(ctx, "cooling down after OVER_QUERY_LIMIT", slog.Duration("cooldown", g.overQuerySleepDuration))
+		until := g.startCooldown(g.overQuerySleepDuration)
+		if g.nonBlockingCooldown {
+			return nil, info, &QuotaError{Response: res, Until: until}
+		}
+		if err := g.waitCooldown(ctx); err != nil {
+			return nil, info, err
+		}
+	}
 
-	return ur, nil
+	return res, info, nil
 }
 
-// getSignature returns a signature of the targetURL using Google client's signing key
-func (g *Geocoder) getSignature(targetURL string) (string, error) {
-	sKey := strings.ReplaceAll(g.businessKey.SigningKey, "-", "+")
-	sKey = strings.ReplaceAll(sKey, "_", "/")
+// acquire waits until the Geocoder is resumed and the cooldown and limiter allow to send a request.
+// With noWait it fails right away instead of waiting
+func (g *Geocoder) acquire(ctx context.Context, limiter *rate.Limiter, noWait bool) error {
+	if err := g.waitResumed(ctx, noWait); err != nil {
+		return err
+	}
+	batch := g.batchLimiter(ctx, limiter)
+	if noWait {
+		if g.coolingDown() {
+			return ErrCoolingDown
+		}
+		return g.allow(batch, limiter)
+	}
 
-	signingKeyBytes, err := base64.StdEncoding.DecodeString(sKey)
+	if err := g.waitCooldown(ctx); err != nil {
+		return err
+	}
+	waitStart := g.clock.Now()
+	delay, err := g.waitLimiters(ctx, batch, limiter)
 	if err != nil {
-		return "", err
+		return err
+	}
+	g.counters.limiterWait(delay)
+	if detailed, ok := g.observer.(DetailedRequestObserver); ok {
+		detailed.ObserveLimiterWait(observerLabel, g.clock.Now().Sub(waitStart))
 	}
+	return g.waitCooldown(ctx)
+}
 
-	h := hmac.New(sha1.New, signingKeyBytes)
-	_, err = h.Write([]byte(targetURL))
-	if err != nil {
-		return "", err
+// allow takes a token of every limiter right away, or none of them if any is empty.
+// Nil limiters are skipped
+func (g *Geocoder) allow(limiters ...*rate.Limiter) error {
+	now := g.clock.Now()
+	reserved := make([]*rate.Reservation, 0, len(limiters))
+	for _, l := range limiters {
+		if l == nil {
+			continue
+		}
+		r := l.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, prev := range reserved {
+				prev.CancelAt(now)
+			}
+			return ErrRateLimited
+		}
+		reserved = append(reserved, r)
 	}
+	return nil
+}
 
-	hash := base64.StdEncoding.EncodeToString(h.Sum(nil))
-	hash = strings.ReplaceAll(hash, "+", "-")
-	hash = strings.ReplaceAll(hash, "/", "_")
+// coolingDown reports whether a cooldown is in progress
+func (g *Geocoder) coolingDown() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.clock.Now().Before(g.cooldownUntil)
+}
 
-	return hash, nil
+// startCooldown suspends sending requests for d and returns the end of the cooldown.
+// A running longer cooldown is kept
+func (g *Geocoder) startCooldown(d time.Duration) time.Time {
+	until := g.clock.Now().Add(d)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until.After(g.cooldownUntil) {
+		g.cooldownUntil = until
+	}
+	return g.cooldownUntil
+}
+
+// waitCooldown blocks until the cooldown ends or ctx is done. ErrCoolingDown is returned
+// right away if ctx deadline comes before the end of the cooldown, ctx.Err() if ctx is already done
+func (g *Geocoder) waitCooldown(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		until := g.cooldownUntil
+		g.mu.Unlock()
+
+		wait := until.Sub(g.clock.Now())
+		if wait <= 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// ctx deadlines are wall clock times, whatever the clock of the Geocoder
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return ErrCoolingDown
+		}
+
+		timer := g.clock.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+		}
+	}
+}
+
+// buildURL constructs url for further reverse geocode request
+func (g *Geocoder) buildURL(lat, lng float64) (*url.URL, error) {
+	return g.signedURL(latLngParams(lat, lng, g.precision))
+}
+
+// buildAddressURL constructs url for further forward geocode request
+func (g *Geocoder) buildAddressURL(address string) (*url.URL, error) {
+	query := url.Values{}
+	query.Add("address", address)
+	return g.signedURL(query)
+}
+
+// signedURL adds common and credential parameters to query and signs the resulting url
+func (g *Geocoder) signedURL(query url.Values) (*url.URL, error) {
+	return g.signedURLWith(&g.builder, query)
+}
+
+// signedURLWith is signedURL with the credentials of b
+func (g *Geocoder) signedURLWith(b *RequestBuilder, params url.Values) (*url.URL, error) {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Add("sensor", "false")
+	return b.URL(query)
+}
+
+// getSignature returns a signature of the targetURL using Google client's signing key
+func (g *Geocoder) getSignature(targetURL string) (string, error) {
+	return g.builder.signature(targetURL)
 }
@@ -19,7 +19,7 @@ type fakeHttpRequester struct {
 
 func (c *fakeHttpRequester) Get(targetURL string) (*http.Response, error) {
 	r := ioutil.NopCloser(bytes.NewReader([]byte(c.responseBodyJSON)))
-	return &http.Response{Body: r}, c.err
+	return &http.Response{StatusCode: http.StatusOK, Body: r}, c.err
 }
 
 type fakeRequestObserver struct{}
@@ -191,3 +191,175 @@ func Test_buildURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReverseGeocodeCooldown(t *testing.T) {
+	client := &fakeHttpRequester{responseBodyJSON: `{"status":"OVER_QUERY_LIMIT"}`}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 100, time.Hour, nil)
+	geocoder.startCooldown(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); err != ErrCoolingDown {
+		t.Errorf("test for deadline Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrCoolingDown)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); err != context.Canceled {
+		t.Errorf("test for cancel Failed - results not match\nGot:\n%v\nExpected:\n%v", err, context.Canceled)
+	}
+}
+
+func Test_CooldownCancellation(t *testing.T) {
+	client := &fakeHttpRequester{responseBodyJSON: `{"status":"OVER_QUERY_LIMIT"}`}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	newGeocoder := func() *Geocoder {
+		geocoder, err := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 100, time.Hour, nil,
+			WithRetries(3, time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return geocoder
+	}
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, g *Geocoder) error
+		cooldown bool
+	}{
+		{"reverse geocoding waiting for a running cooldown", func(ctx context.Context, g *Geocoder) error {
+			_, err := g.ReverseGeocode(ctx, 49.17584440, 7.30196070)
+			return err
+		}, true},
+		{"forward geocoding waiting for a running cooldown", func(ctx context.Context, g *Geocoder) error {
+			_, err := g.Geocode(ctx, "Berlin")
+			return err
+		}, true},
+		{"time zone waiting for a running cooldown", func(ctx context.Context, g *Geocoder) error {
+			_, err := g.Timezone(ctx, 52.52, 13.40, time.Now())
+			return err
+		}, true},
+		{"reverse geocoding waiting for the cooldown it started", func(ctx context.Context, g *Geocoder) error {
+			_, err := g.ReverseGeocode(ctx, 49.17584440, 7.30196070)
+			return err
+		}, false},
+	}
+	for _, tt := range tests {
+		g := newGeocoder()
+		if tt.cooldown {
+			g.startCooldown(time.Hour)
+		}
+
+		// several callers blocked in the same cooldown
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 3)
+		for i := 0; i < cap(errs); i++ {
+			go func() { errs <- tt.call(ctx, g) }()
+		}
+		time.Sleep(20 * time.Millisecond)
+		// a longer cooldown started meanwhile doesn't delay the cancellation
+		g.startCooldown(2 * time.Hour)
+		start := time.Now()
+		cancel()
+		for i := 0; i < cap(errs); i++ {
+			select {
+			case err := <-errs:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, context.Canceled)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("test for %v Failed - call not returned after cancellation", tt.name)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("test for %v Failed - cancellation took %v", tt.name, elapsed)
+		}
+
+		// a context done before the call fails with its own error, not ErrCoolingDown
+		ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		if err := tt.call(ctx, g); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("test for %v with expired context Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, context.DeadlineExceeded)
+		}
+		cancel()
+	}
+}
+
+func Test_buildAddressURL(t *testing.T) {
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second, nil)
+	res, err := geocoder.buildAddressURL("Alexanderplatz 1, Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Query().Get("address") != "Alexanderplatz 1, Berlin" || res.Query().Get("signature") == "" {
+		t.Errorf("test for buildAddressURL Failed - unexpected url %v", res)
+	}
+}
+
+func Test_NonBlockingCooldown(t *testing.T) {
+	client := &fakeHttpRequester{responseBodyJSON: `{"status":"OVER_QUERY_LIMIT"}`}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 100, time.Hour, nil,
+		WithNonBlockingCooldown())
+
+	start := time.Now()
+	_, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+	var qe *QuotaError
+	if !errors.As(err, &qe) || qe.Response.Status != GRS_OVER_QUERY_LIMIT {
+		t.Fatalf("test for non-blocking cooldown Failed - unexpected error %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("test for non-blocking cooldown Failed - call blocked for %v", elapsed)
+	}
+	if time.Until(qe.Until) < 59*time.Minute {
+		t.Errorf("test for non-blocking cooldown Failed - unexpected cooldown end %v", qe.Until)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); err != ErrCoolingDown {
+		t.Errorf("test for non-blocking cooldown Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrCoolingDown)
+	}
+}
+
+func Test_TryReverseGeocode(t *testing.T) {
+	client := &fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1, time.Second, nil)
+
+	if _, err := geocoder.TryReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+		t.Fatalf("test for first call Failed - unexpected error %v", err)
+	}
+	if _, err := geocoder.TryReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != ErrRateLimited {
+		t.Errorf("test for second call Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrRateLimited)
+	}
+
+	geocoder.startCooldown(time.Hour)
+	if _, err := geocoder.TryReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != ErrCoolingDown {
+		t.Errorf("test for cooldown Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrCoolingDown)
+	}
+}
+
+func Test_LimiterDeadline(t *testing.T) {
+	client := &fakeHttpRequester{responseBodyJSON: `{"status":"OK","results":[]}`}
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1, time.Hour, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); !errors.Is(err, ErrWouldExceedDeadline) {
+		t.Errorf("test for limiter deadline Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrWouldExceedDeadline)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("test for limiter deadline Failed - waited %v", elapsed)
+	}
+}
@@ -0,0 +1,104 @@
+package geocodertest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+// FakeClock is a geocoder.Clock standing still until advanced, pass it with geocoder.WithClock
+// to test cooldowns, rate limiting and retries without sleeping. It is safe for concurrent use
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+var _ geocoder.Clock = (*FakeClock)(nil)
+
+// NewFakeClock creates new instance of FakeClock showing now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now implements geocoder.Clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer implements geocoder.Clock
+func (c *FakeClock) NewTimer(d time.Duration) geocoder.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires the timers due, in order
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].when.Before(c.timers[j].when) })
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+// Timers returns the number of timers waiting to fire, use it to wait until the code under
+// test blocks before advancing the clock
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil waits until n timers are waiting to fire or timeout passes in real time,
+// it reports whether they are
+func (c *FakeClock) BlockUntil(n int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for c.Timers() < n {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	c     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, pending := range t.clock.timers {
+		if pending == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package geocodertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+func Test_FakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		rps      int
+		faults   []Fault
+		opts     []geocoder.Option
+		advance  time.Duration
+		requests int
+	}{
+		{"cooldown after OVER_QUERY_LIMIT", 1000, []Fault{FaultOverQueryLimit}, []geocoder.Option{geocoder.WithNonBlockingCooldown()}, time.Hour, 2},
+		{"rate limiting", 1, nil, nil, time.Second, 2},
+		{"retry backoff", 1000, []Fault{FaultServerError}, []geocoder.Option{geocoder.WithRetries(2, time.Hour)}, time.Hour, 2},
+	}
+	for _, tt := range tests {
+		s := NewServer()
+		clock := NewFakeClock(start)
+		g, err := geocoder.NewGeocoder(s.BusinessKey(), s.Endpoint(), "en", s.Client(), tt.rps, time.Hour, nil,
+			append(tt.opts, geocoder.WithClock(clock))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.InjectFaults(tt.faults...)
+
+		if tt.name != "retry backoff" {
+			// the first call takes the only token or starts the cooldown
+			g.ReverseGeocode(context.TODO(), 1, 1)
+		}
+		done := make(chan error, 1)
+		go func() {
+			_, err := g.ReverseGeocode(context.TODO(), 2, 2)
+			done <- err
+		}()
+		if !clock.BlockUntil(1, time.Second) {
+			t.Fatalf("test for %v Failed - call is not waiting for the clock", tt.name)
+		}
+		select {
+		case err := <-done:
+			t.Fatalf("test for %v Failed - call returned before the clock advanced: %v", tt.name, err)
+		default:
+		}
+		clock.Advance(tt.advance)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("test for %v Failed - call not returned after the clock advanced", tt.name)
+		}
+		if requests := len(s.Requests()); requests != tt.requests {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, requests, tt.requests)
+		}
+		s.Close()
+	}
+}
+
+func Test_FakeClockTimers(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	early, late, stopped := clock.NewTimer(time.Second), clock.NewTimer(time.Minute), clock.NewTimer(time.Second)
+	if !stopped.Stop() || stopped.Stop() {
+		t.Errorf("test for Stop Failed - unexpected result")
+	}
+	clock.Advance(time.Second)
+	select {
+	case now := <-early.C():
+		if !now.Equal(clock.Now()) {
+			t.Errorf("test for fired timer Failed - results not match\nGot:\n%v\nExpected:\n%v", now, clock.Now())
+		}
+	default:
+		t.Errorf("test for fired timer Failed - timer due not fired")
+	}
+	select {
+	case <-late.C():
+		t.Errorf("test for pending timer Failed - fired early")
+	case <-stopped.C():
+		t.Errorf("test for stopped timer Failed - fired")
+	default:
+	}
+	if clock.Timers() != 1 {
+		t.Errorf("test for pending timers Failed - results not match\nGot:\n%v\nExpected:\n%v", clock.Timers(), 1)
+	}
+}
+
+func Test_FakeClockDeadline(t *testing.T) {
+	past, future := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		start   time.Time
+		rps     int
+		faults  []Fault
+		timeout time.Duration
+		advance time.Duration
+		err     error
+	}{
+		{"rate limiting beyond deadline, clock behind", past, 1, nil, 100 * time.Millisecond, 0, geocoder.ErrWouldExceedDeadline},
+		{"rate limiting within deadline, clock ahead", future, 1, nil, time.Hour, time.Second, nil},
+		{"cooldown beyond deadline, clock behind", past, 1000, []Fault{FaultOverQueryLimit}, 100 * time.Millisecond, 0, geocoder.ErrCoolingDown},
+		{"cooldown within deadline, clock ahead", future, 1000, []Fault{FaultOverQueryLimit}, 2 * time.Hour, time.Hour, nil},
+	}
+	for _, tt := range tests {
+		s := NewServer()
+		clock := NewFakeClock(tt.start)
+		g, err := geocoder.NewGeocoder(s.BusinessKey(), s.Endpoint(), "en", s.Client(), tt.rps, time.Hour, nil, geocoder.WithClock(clock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.faults == nil {
+			// the first call takes the only token
+			g.ReverseGeocode(context.TODO(), 1, 1)
+		}
+		s.InjectFaults(tt.faults...)
+
+		ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+		done := make(chan error, 1)
+		go func() {
+			_, err := g.ReverseGeocode(ctx, 2, 2)
+			done <- err
+		}()
+		if tt.advance > 0 {
+			if !clock.BlockUntil(1, time.Second) {
+				t.Fatalf("test for %v Failed - call is not waiting for the clock", tt.name)
+			}
+			clock.Advance(tt.advance)
+		}
+		select {
+		case err := <-done:
+			if !errors.Is(err, tt.err) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Errorf("test for %v Failed - call not returned", tt.name)
+		}
+		cancel()
+		s.Close()
+	}
+}
+
+func Test_FakeClockCombinedDeadline(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	// the batch limiter allows 0.5 requests per second
+	g, err := geocoder.NewGeocoder(s.BusinessKey(), s.Endpoint(), "en", s.Client(), 1, time.Hour, nil,
+		geocoder.WithClock(clock), geocoder.WithInteractiveReserve(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := geocoder.WithPriority(context.TODO(), geocoder.PR_BATCH)
+	if _, err := g.ReverseGeocode(batch, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	// two interactive requests queue up on the Geocoder limiter for 1s and 2s
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.ReverseGeocode(context.TODO(), 2, 2)
+		}()
+	}
+	if !clock.BlockUntil(2, time.Second) {
+		t.Fatal("test for combined deadline Failed - interactive requests are not waiting")
+	}
+
+	// the batch token comes in 2s and the Geocoder one 1s later, each within the deadline but not both
+	ctx, cancel := context.WithTimeout(batch, 2500*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.ReverseGeocode(ctx, 3, 3)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, geocoder.ErrWouldExceedDeadline) {
+			t.Errorf("test for combined deadline Failed - results not match\nGot:\n%v\nExpected:\n%v", err, geocoder.ErrWouldExceedDeadline)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("test for combined deadline Failed - call waited for the limiters")
+	}
+	clock.Advance(2 * time.Second)
+	wg.Wait()
+}
@@ -0,0 +1,193 @@
+// Package geocodertest provides test doubles of the geocoder package
+package geocodertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/alvillain/geocoder"
+)
+
+// Call is a recorded call of Mock
+type Call struct {
+	// Method name, e.g. "ReverseGeocode"
+	Method   string
+	Lat      float64
+	Lng      float64
+	Address  string
+	Language string
+}
+
+type reply struct {
+	res  *geocoder.GoogleResponse
+	addr *geocoder.DualAddress
+	err  error
+}
+
+// Mock is a configurable geocoder.Interface. Responses are canned per coordinate or address,
+// unknown queries get Default. All calls are recorded. It is safe for concurrent use
+type Mock struct {
+	// Default is returned for queries without a canned response, ZERO_RESULTS if nil
+	Default *geocoder.GoogleResponse
+
+	mu      sync.Mutex
+	reverse map[string]reply
+	forward map[string]reply
+	verify  map[string]reply
+	err     error
+	calls   []Call
+}
+
+var _ geocoder.Interface = (*Mock)(nil)
+
+// NewMock creates new instance of Mock without canned responses
+func NewMock() *Mock {
+	return &Mock{
+		reverse: make(map[string]reply),
+		forward: make(map[string]reply),
+		verify:  make(map[string]reply),
+	}
+}
+
+// OnReverseGeocode makes reverse geocoding of lat, lng return res and err.
+// Coordinates are matched with 8 decimal places, like Geocoder sends them
+func (m *Mock) OnReverseGeocode(lat, lng float64, res *geocoder.GoogleResponse, err error) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reverse[latLngKey(lat, lng)] = reply{res: res, err: err}
+	return m
+}
+
+// OnGeocode makes geocoding of address return res and err
+func (m *Mock) OnGeocode(address string, res *geocoder.GoogleResponse, err error) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.forward[address] = reply{res: res, err: err}
+	return m
+}
+
+// OnVerifyAddress makes VerifyAddress of address return addr and err
+func (m *Mock) OnVerifyAddress(address string, addr *geocoder.DualAddress, err error) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verify[address] = reply{addr: addr, err: err}
+	return m
+}
+
+// SetError makes every call fail with err, regardless of canned responses. nil restores them
+func (m *Mock) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// Calls returns the calls made so far, in order
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// Reset forgets recorded calls
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// ReverseGeocode implements geocoder.Interface
+func (m *Mock) ReverseGeocode(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, error) {
+	return m.reverseGeocode(ctx, Call{Method: "ReverseGeocode", Lat: lat, Lng: lng})
+}
+
+// TryReverseGeocode implements geocoder.Interface
+func (m *Mock) TryReverseGeocode(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, error) {
+	return m.reverseGeocode(ctx, Call{Method: "TryReverseGeocode", Lat: lat, Lng: lng})
+}
+
+// ReverseGeocodeLngLat implements geocoder.Interface
+func (m *Mock) ReverseGeocodeLngLat(ctx context.Context, lng, lat float64) (*geocoder.GoogleResponse, error) {
+	return m.reverseGeocode(ctx, Call{Method: "ReverseGeocodeLngLat", Lat: lat, Lng: lng})
+}
+
+// ReverseGeocodeAsync implements geocoder.Interface
+func (m *Mock) ReverseGeocodeAsync(ctx context.Context, lat, lng float64) *geocoder.Future {
+	call := Call{Method: "ReverseGeocodeAsync", Lat: lat, Lng: lng}
+	return geocoder.NewFuture(func() (*geocoder.GoogleResponse, error) {
+		return m.reverseGeocode(ctx, call)
+	})
+}
+
+// Geocode implements geocoder.Interface
+func (m *Mock) Geocode(ctx context.Context, address string) (*geocoder.GoogleResponse, error) {
+	return m.geocode(ctx, Call{Method: "Geocode", Address: address})
+}
+
+// GeocodeAsync implements geocoder.Interface
+func (m *Mock) GeocodeAsync(ctx context.Context, address string) *geocoder.Future {
+	call := Call{Method: "GeocodeAsync", Address: address}
+	return geocoder.NewFuture(func() (*geocoder.GoogleResponse, error) {
+		return m.geocode(ctx, call)
+	})
+}
+
+// VerifyAddress implements geocoder.Interface. Addresses without a canned response are not found,
+// the error wraps geocoder.ErrZeroResults
+func (m *Mock) VerifyAddress(ctx context.Context, address, language string) (*geocoder.DualAddress, error) {
+	if err := m.record(ctx, Call{Method: "VerifyAddress", Address: address, Language: language}); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	r, ok := m.verify[address]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("verifying address: %w", &geocoder.StatusError{Status: geocoder.GRS_ZERO_RESULTS})
+	}
+	return r.addr, r.err
+}
+
+func (m *Mock) reverseGeocode(ctx context.Context, call Call) (*geocoder.GoogleResponse, error) {
+	if err := m.record(ctx, call); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	r, ok := m.reverse[latLngKey(call.Lat, call.Lng)]
+	m.mu.Unlock()
+	return m.result(r, ok)
+}
+
+func (m *Mock) geocode(ctx context.Context, call Call) (*geocoder.GoogleResponse, error) {
+	if err := m.record(ctx, call); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	r, ok := m.forward[call.Address]
+	m.mu.Unlock()
+	return m.result(r, ok)
+}
+
+// record records call and returns the injected or ctx error
+func (m *Mock) record(ctx context.Context, call Call) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+	if m.err != nil {
+		return m.err
+	}
+	return ctx.Err()
+}
+
+func (m *Mock) result(r reply, ok bool) (*geocoder.GoogleResponse, error) {
+	if ok {
+		return r.res, r.err
+	}
+	if m.Default != nil {
+		return m.Default, nil
+	}
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}, nil
+}
+
+func latLngKey(lat, lng float64) string {
+	return fmt.Sprintf("%.8f,%.8f", lat, lng)
+}
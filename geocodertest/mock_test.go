@@ -0,0 +1,68 @@
+package geocodertest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+)
+
+func Test_Mock(t *testing.T) {
+	errDenied := errors.New("denied")
+	berlin := &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{FormattedAddress: "Berlin"}}}
+	m := NewMock().
+		OnReverseGeocode(52.52, 13.40, berlin, nil).
+		OnReverseGeocode(0, 0, nil, errDenied).
+		OnGeocode("Berlin", berlin, nil)
+
+	var g geocoder.Interface = m
+	tests := []struct {
+		name          string
+		call          func() (*geocoder.GoogleResponse, error)
+		expectedRes   *geocoder.GoogleResponse
+		expectedError error
+	}{
+		{"canned reverse", func() (*geocoder.GoogleResponse, error) { return g.ReverseGeocode(context.TODO(), 52.52, 13.40) }, berlin, nil},
+		{"lng lat order", func() (*geocoder.GoogleResponse, error) { return g.ReverseGeocodeLngLat(context.TODO(), 13.40, 52.52) }, berlin, nil},
+		{"injected error", func() (*geocoder.GoogleResponse, error) { return g.TryReverseGeocode(context.TODO(), 0, 0) }, nil, errDenied},
+		{"unknown", func() (*geocoder.GoogleResponse, error) { return g.ReverseGeocode(context.TODO(), 1, 1) },
+			&geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}, nil},
+		{"async geocode", func() (*geocoder.GoogleResponse, error) { return g.GeocodeAsync(context.TODO(), "Berlin").Get() }, berlin, nil},
+	}
+	for _, tt := range tests {
+		res, err := tt.call()
+		if !reflect.DeepEqual(res, tt.expectedRes) || err != tt.expectedError {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v %v", tt.name, res, err, tt.expectedRes, tt.expectedError)
+		}
+	}
+
+	expectedCalls := []Call{
+		{Method: "ReverseGeocode", Lat: 52.52, Lng: 13.40},
+		{Method: "ReverseGeocodeLngLat", Lat: 52.52, Lng: 13.40},
+		{Method: "TryReverseGeocode"},
+		{Method: "ReverseGeocode", Lat: 1, Lng: 1},
+		{Method: "GeocodeAsync", Address: "Berlin"},
+	}
+	if calls := m.Calls(); !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("test for calls Failed - results not match\nGot:\n%v\nExpected:\n%v", calls, expectedCalls)
+	}
+
+	m.SetError(errDenied)
+	if _, err := g.Geocode(context.TODO(), "Berlin"); err != errDenied {
+		t.Errorf("test for SetError Failed - results not match\nGot:\n%v\nExpected:\n%v", err, errDenied)
+	}
+	m.Reset()
+	if len(m.Calls()) != 0 {
+		t.Errorf("test for Reset Failed - calls are kept")
+	}
+}
+
+func Test_MockVerifyAddressMiss(t *testing.T) {
+	_, err := NewMock().VerifyAddress(context.TODO(), "Nowhere 1", "en")
+	var statusErr *geocoder.StatusError
+	if !errors.Is(err, geocoder.ErrZeroResults) || !errors.As(err, &statusErr) {
+		t.Errorf("test for unknown address Failed - results not match\nGot:\n%v\nExpected:\n%v", err, geocoder.ErrZeroResults)
+	}
+}
@@ -0,0 +1,185 @@
+package geocodertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/alvillain/geocoder"
+)
+
+const (
+	// TestClientID is the client ID accepted by Server
+	TestClientID = "gme-test"
+	// TestSigningKey is the signing key Server validates signatures with
+	TestSigningKey = "dGVzdC1zaWduaW5nLWtleQ=="
+	// TestAPIKey is the API key accepted by Server
+	TestAPIKey = "test-api-key"
+	// GeocodePath is the path of the geocoding endpoint served by Server
+	GeocodePath = "/maps/api/geocode/json"
+)
+
+// Fault is a failure Server responds with instead of a fixture
+type Fault int
+
+const (
+	// FaultOverQueryLimit responds with status OVER_QUERY_LIMIT
+	FaultOverQueryLimit Fault = iota + 1
+	// FaultTooManyRequests responds with HTTP 429 and Retry-After of 1 second
+	FaultTooManyRequests
+	// FaultServerError responds with HTTP 500
+	FaultServerError
+	// FaultMalformedJSON responds with a truncated JSON body
+	FaultMalformedJSON
+)
+
+// Server is a fake of the Google geocoding web service. It validates credentials like Google does,
+// serves fixture responses per latlng or address and responds with injected faults in order
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	reverse  map[string]*geocoder.GoogleResponse
+	forward  map[string]*geocoder.GoogleResponse
+	faults   []Fault
+	requests []string
+}
+
+// NewServer starts new instance of Server, close it with Close
+func NewServer() *Server {
+	s := &Server{
+		reverse: make(map[string]*geocoder.GoogleResponse),
+		forward: make(map[string]*geocoder.GoogleResponse),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(GeocodePath, s.geocode)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Endpoint returns the URL of the geocoding endpoint, pass it to geocoder.NewGeocoder
+func (s *Server) Endpoint() string {
+	return s.URL + GeocodePath
+}
+
+// BusinessKey returns credentials accepted by the Server
+func (s *Server) BusinessKey() *geocoder.BusinessKey {
+	return &geocoder.BusinessKey{ClientID: TestClientID, SigningKey: TestSigningKey}
+}
+
+// SetReverse serves res for the latlng of lat, lng
+func (s *Server) SetReverse(lat, lng float64, res *geocoder.GoogleResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reverse[fmt.Sprintf("%.8f,%.8f", lat, lng)] = res
+}
+
+// SetGeocode serves res for address
+func (s *Server) SetGeocode(address string, res *geocoder.GoogleResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forward[address] = res
+}
+
+// InjectFaults makes the next requests fail with faults, one fault per request
+func (s *Server) InjectFaults(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, faults...)
+}
+
+// Requests returns the URLs of the requests received so far, including rejected ones
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) geocode(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.String())
+	var fault Fault
+	if len(s.faults) > 0 {
+		fault, s.faults = s.faults[0], s.faults[1:]
+	}
+	s.mu.Unlock()
+
+	if msg, ok := authenticate(r); !ok {
+		http.Error(w, msg, http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	key := query.Get("key")
+	if key == "" {
+		key = r.Header.Get("X-Goog-Api-Key")
+	}
+	if key != "" && key != TestAPIKey {
+		writeJSON(w, &geocoder.GoogleResponse{Status: geocoder.GRS_REQUEST_DENIED, ErrorMessage: "The provided API key is invalid."})
+		return
+	}
+
+	switch fault {
+	case FaultOverQueryLimit:
+		writeJSON(w, &geocoder.GoogleResponse{Status: geocoder.GRS_OVER_QUERY_LIMIT, ErrorMessage: "You have exceeded your rate-limit for this API."})
+		return
+	case FaultTooManyRequests:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	case FaultServerError:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	case FaultMalformedJSON:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results":[{"formatted_address":`)) //nolint:errcheck
+		return
+	}
+
+	var res *geocoder.GoogleResponse
+	var ok bool
+	s.mu.Lock()
+	switch {
+	case query.Get("latlng") != "":
+		res, ok = s.reverse[query.Get("latlng")]
+	case query.Get("address") != "":
+		res, ok = s.forward[query.Get("address")]
+	default:
+		res, ok = &geocoder.GoogleResponse{Status: geocoder.GRS_INVALID_REQUEST, ErrorMessage: "Invalid request. Missing the 'address' or 'latlng' parameter."}, true
+	}
+	s.mu.Unlock()
+	if !ok {
+		res = &geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}
+	}
+	writeJSON(w, res)
+}
+
+// authenticate checks the client ID and the URL signature, returning Google's message on failure.
+// Requests with an API key in the query or X-Goog-Api-Key header instead are accepted here,
+// the key is checked against TestAPIKey by the caller
+func authenticate(r *http.Request) (string, bool) {
+	query := r.URL.Query()
+	if query.Get("client") == "" {
+		if query.Get("key") == "" && r.Header.Get("X-Goog-Api-Key") == "" {
+			return "The request is missing a valid API key or client ID.", false
+		}
+		return "", true
+	}
+	if query.Get("client") != TestClientID {
+		return "Unable to authenticate the request. Unknown client ID '" + query.Get("client") + "'.", false
+	}
+	valid, err := geocoder.VerifySignature(TestSigningKey, r.URL.String())
+	if err != nil {
+		return err.Error(), false
+	}
+	if !valid {
+		return "Unable to authenticate the request. Provided 'signature' is not valid for the provided client ID.", false
+	}
+	return "", true
+}
+
+func writeJSON(w http.ResponseWriter, res *geocoder.GoogleResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res) //nolint:errcheck
+}
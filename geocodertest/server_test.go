@@ -0,0 +1,144 @@
+package geocodertest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+func newGeocoder(t *testing.T, s *Server, bkey *geocoder.BusinessKey, opts ...geocoder.Option) *geocoder.Geocoder {
+	g, err := geocoder.NewGeocoder(bkey, s.Endpoint(), "en", s.Client(), 1000, 10*time.Millisecond, nil, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func Test_ServerFixtures(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	berlin := &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{FormattedAddress: "Berlin, Germany"}}}
+	s.SetReverse(52.52, 13.40, berlin)
+	s.SetGeocode("Berlin", berlin)
+
+	g := newGeocoder(t, s, s.BusinessKey())
+	res, err := g.ReverseGeocode(context.TODO(), 52.52, 13.40)
+	if err != nil || res.Status != geocoder.GRS_OK || res.Results[0].FormattedAddress != "Berlin, Germany" {
+		t.Errorf("test for reverse fixture Failed - unexpected response %v, error %v", res, err)
+	}
+	res, err = g.Geocode(context.TODO(), "Berlin")
+	if err != nil || res.Status != geocoder.GRS_OK {
+		t.Errorf("test for address fixture Failed - unexpected response %v, error %v", res, err)
+	}
+	res, err = g.ReverseGeocode(context.TODO(), 1, 1)
+	if err != nil || res.Status != geocoder.GRS_ZERO_RESULTS {
+		t.Errorf("test for unknown latlng Failed - unexpected response %v, error %v", res, err)
+	}
+
+	apiKey := newGeocoder(t, s, nil, geocoder.WithAPIKey(TestAPIKey))
+	if res, err := apiKey.ReverseGeocode(context.TODO(), 52.52, 13.40); err != nil || res.Status != geocoder.GRS_OK {
+		t.Errorf("test for API key Failed - unexpected response %v, error %v", res, err)
+	}
+}
+
+func Test_ServerAPIKey(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.SetReverse(52.52, 13.40, &geocoder.GoogleResponse{Status: geocoder.GRS_OK})
+
+	tests := []struct {
+		name     string
+		opt      geocoder.Option
+		expected geocoder.GoogleResponseStatus
+	}{
+		{"query key", geocoder.WithAPIKey(TestAPIKey), geocoder.GRS_OK},
+		{"header key", geocoder.WithAPIKeyHeader(TestAPIKey), geocoder.GRS_OK},
+		{"wrong query key", geocoder.WithAPIKey("wrong-key"), geocoder.GRS_REQUEST_DENIED},
+		{"wrong header key", geocoder.WithAPIKeyHeader("wrong-key"), geocoder.GRS_REQUEST_DENIED},
+	}
+
+	for _, tt := range tests {
+		g := newGeocoder(t, s, nil, tt.opt, geocoder.WithStatusErrors())
+		got := geocoder.GRS_OK
+		var statusErr *geocoder.StatusError
+		if _, err := g.ReverseGeocode(context.TODO(), 52.52, 13.40); errors.As(err, &statusErr) {
+			got = statusErr.Status
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_ServerSignature(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	g := newGeocoder(t, s, &geocoder.BusinessKey{ClientID: TestClientID, SigningKey: "d3Jvbmcta2V5"})
+
+	_, err := g.ReverseGeocode(context.TODO(), 52.52, 13.40)
+	var httpErr *geocoder.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusForbidden {
+		t.Errorf("test for wrong signing key Failed - results not match\nGot:\n%v\nExpected:\n%v", err, "HTTP 403")
+	}
+}
+
+func Test_ServerFaults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.SetReverse(52.52, 13.40, &geocoder.GoogleResponse{Status: geocoder.GRS_OK})
+
+	tests := []struct {
+		name             string
+		faults           []Fault
+		opts             []geocoder.Option
+		check            func(res *geocoder.GoogleResponse, err error) bool
+		expectedRequests int
+	}{
+		{
+			"retried 500 and 429",
+			[]Fault{FaultServerError, FaultTooManyRequests},
+			[]geocoder.Option{geocoder.WithRetries(3, time.Millisecond)},
+			func(res *geocoder.GoogleResponse, err error) bool { return err == nil && res.Status == geocoder.GRS_OK },
+			3,
+		},
+		{
+			"over query limit",
+			[]Fault{FaultOverQueryLimit},
+			[]geocoder.Option{geocoder.WithNonBlockingCooldown()},
+			func(res *geocoder.GoogleResponse, err error) bool {
+				var quotaErr *geocoder.QuotaError
+				return errors.As(err, &quotaErr)
+			},
+			1,
+		},
+		{
+			"malformed JSON",
+			[]Fault{FaultMalformedJSON},
+			nil,
+			func(res *geocoder.GoogleResponse, err error) bool { return err != nil },
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(s.Requests())
+			s.InjectFaults(tt.faults...)
+			g := newGeocoder(t, s, s.BusinessKey(), tt.opts...)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := g.ReverseGeocode(ctx, 52.52, 13.40)
+			if !tt.check(res, err) {
+				t.Errorf("test for %v Failed - unexpected response %v, error %v", tt.name, res, err)
+			}
+			if got := len(s.Requests()) - before; got != tt.expectedRequests {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expectedRequests)
+			}
+		})
+	}
+}
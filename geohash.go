@@ -0,0 +1,53 @@
+package geocoder
+
+// geohashAlphabet is the base32 alphabet of geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// maxGeohashPrecision is the longest geohash, about 3.7 cm
+const maxGeohashPrecision = 12
+
+// WithGeohashCacheKeys caches reverse geocoding responses by the geohash of the coordinate with
+// precision characters, so that all coordinates within a cell share one cached response. The
+// request is still made with the exact coordinate of the first lookup in the cell.
+// Precision 7 cells are about 150 m, street level, precision 5 cells about 5 km, city level
+func WithGeohashCacheKeys(precision int) Option {
+	return func(g *Geocoder) {
+		if precision < 1 || precision > maxGeohashPrecision {
+			return
+		}
+		g.geohashPrecision = precision
+	}
+}
+
+// EncodeGeohash returns the geohash of lat, lng with precision characters, up to 12
+func EncodeGeohash(lat, lng float64, precision int) string {
+	if precision > maxGeohashPrecision {
+		precision = maxGeohashPrecision
+	}
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	hash := make([]byte, 0, precision)
+	even := true
+	bit, ch := 0, 0
+	for len(hash) < precision {
+		// even bits refine the longitude, odd bits the latitude
+		r, v := &latRange, lat
+		if even {
+			r, v = &lngRange, lng
+		}
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+		if bit++; bit == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
@@ -0,0 +1,61 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_EncodeGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat       float64
+		lng       float64
+		precision int
+		expected  string
+	}{
+		{"Jutland", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"Leon", 42.6, -5.6, 5, "ezs42"},
+		{"south west", -33.86785, 151.20732, 7, "r3gx2f9"},
+		{"too long", 0, 0, 20, "s00000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hash := EncodeGeohash(tt.lat, tt.lng, tt.precision); hash != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, hash, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_GeohashCacheKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int
+		expected  int
+	}{
+		// the coordinates are about 60 m apart
+		{"street level", 7, 1},
+		{"building level", 9, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []string
+			g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithGeohashCacheKeys(tt.precision), WithCache(NewMemoryCache(10, time.Hour)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []Coordinate{{Lat: 52.52050, Lng: 13.40930}, {Lat: 52.52070, Lng: 13.41010}} {
+				if _, err := g.ReverseGeocode(context.TODO(), c.Lat, c.Lng); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(used) != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, len(used), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// defaultGeolocationURL is the endpoint of the Geolocation API
+const defaultGeolocationURL = "https://www.googleapis.com/geolocation/v1/geolocate"
+
+// WithGeolocationURL sends Geolocate requests to url instead of the Google endpoint,
+// e.g. to a proxy or a test server
+func WithGeolocationURL(url string) Option {
+	return func(g *Geocoder) {
+		if url != "" {
+			g.geolocationURL = url
+		}
+	}
+}
+
+// GeolocationRequest holds the radio observations of a device, all fields are optional.
+// Without observations the location is estimated from the IP address the request comes from,
+// which is the server's unless ConsiderIP is false
+type GeolocationRequest struct {
+	// Mobile country code and network code of the home network of the device
+	HomeMobileCountryCode int `json:"homeMobileCountryCode,omitempty"`
+	HomeMobileNetworkCode int `json:"homeMobileNetworkCode,omitempty"`
+	// gsm, cdma, wcdma, lte or nr
+	RadioType string `json:"radioType,omitempty"`
+	Carrier   string `json:"carrier,omitempty"`
+	// Fall back to the IP address if the observations are not enough, true if nil
+	ConsiderIP       *bool             `json:"considerIp,omitempty"`
+	CellTowers       []CellTower       `json:"cellTowers,omitempty"`
+	WiFiAccessPoints []WiFiAccessPoint `json:"wifiAccessPoints,omitempty"`
+}
+
+// CellTower is a cell the device sees
+type CellTower struct {
+	CellID            int `json:"cellId"`
+	LocationAreaCode  int `json:"locationAreaCode"`
+	MobileCountryCode int `json:"mobileCountryCode"`
+	MobileNetworkCode int `json:"mobileNetworkCode"`
+	// Milliseconds since the cell was primary
+	Age int `json:"age,omitempty"`
+	// In dBm
+	SignalStrength int `json:"signalStrength,omitempty"`
+	TimingAdvance  int `json:"timingAdvance,omitempty"`
+}
+
+// WiFiAccessPoint is an access point the device sees, at least two are required
+type WiFiAccessPoint struct {
+	// BSSID, e.g. 01:23:45:67:89:ab
+	MACAddress string `json:"macAddress"`
+	// In dBm
+	SignalStrength int `json:"signalStrength,omitempty"`
+	// Milliseconds since the access point was detected
+	Age                int `json:"age,omitempty"`
+	Channel            int `json:"channel,omitempty"`
+	SignalToNoiseRatio int `json:"signalToNoiseRatio,omitempty"`
+}
+
+// GeolocationResponse is the estimated location of a device
+type GeolocationResponse struct {
+	Location Coordinate `json:"location"`
+	// Radius of 68% confidence around Location, in meters
+	Accuracy float64 `json:"accuracy"`
+}
+
+var apiGeolocation = mapsAPI{usage: UsageAPIGeolocation, rest: true}
+
+// Geolocate estimates the location of a device without GPS from the cell towers and WiFi
+// access points it sees. Requests are limited by the EP_GEOLOCATION limiter and are not cached.
+// It requires API key authentication, ErrAPIKeyRequired is returned without one, errors are
+// reported as HTTPError, a location which can't be found as 404
+func (g *Geocoder) Geolocate(ctx context.Context, req GeolocationRequest) (*GeolocationResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	api := apiGeolocation
+	api.url = g.geolocationURL
+
+	res := &GeolocationResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_GEOLOCATION,
+		endpoint: EP_GEOLOCATION,
+		api:      &api,
+		params:   url.Values{},
+		body:     body,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Geolocate(t *testing.T) {
+	var requested string
+	var body GeolocationRequest
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		requested, body = req.Method+" "+req.URL.String(), GeolocationRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		if len(body.CellTowers) == 0 && len(body.WiFiAccessPoints) == 0 {
+			res := `{"error":{"code":404,"message":"Not Found","errors":[{"reason":"notFound"}]}}`
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(res))}, nil
+		}
+		res := `{"location":{"lat":52.5201,"lng":13.4049},"accuracy":42.5}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(res))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	considerIP := false
+	req := GeolocationRequest{
+		RadioType:  "lte",
+		ConsiderIP: &considerIP,
+		CellTowers: []CellTower{{CellID: 21532831, LocationAreaCode: 2862, MobileCountryCode: 262, MobileNetworkCode: 2}},
+		WiFiAccessPoints: []WiFiAccessPoint{
+			{MACAddress: "3c:37:86:5d:75:d4", SignalStrength: -35},
+			{MACAddress: "94:b4:0f:fd:c1:40", SignalStrength: -40},
+		},
+	}
+	res, err := g.Geolocate(context.TODO(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "POST https://www.googleapis.com/geolocation/v1/geolocate?key=key"; requested != expected {
+		t.Errorf("test for request Failed - results not match\nGot:\n%v\nExpected:\n%v", requested, expected)
+	}
+	if !reflect.DeepEqual(body, req) {
+		t.Errorf("test for body Failed - results not match\nGot:\n%+v\nExpected:\n%+v", body, req)
+	}
+	expected := &GeolocationResponse{Location: Coordinate{Lat: 52.5201, Lng: 13.4049}, Accuracy: 42.5}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("test for response Failed - results not match\nGot:\n%+v\nExpected:\n%+v", res, expected)
+	}
+
+	var he *HTTPError
+	if _, err := g.Geolocate(context.TODO(), GeolocationRequest{ConsiderIP: &considerIP}); !errors.As(err, &he) || he.StatusCode != http.StatusNotFound {
+		t.Errorf("test for not found Failed - unexpected error %v", err)
+	}
+
+	requested = ""
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	business, err := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := business.Geolocate(context.TODO(), req); !errors.Is(err, ErrAPIKeyRequired) || requested != "" {
+		t.Errorf("test for BusinessKey Failed - results not match\nGot:\n%v %q\nExpected:\n%v", err, requested, ErrAPIKeyRequired)
+	}
+}
@@ -0,0 +1,112 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// GroupOption configures GeocodeGroup
+type GroupOption func(*GeocodeGroup)
+
+// WithGroupLimit sets the number of calls of a GeocodeGroup running at once
+func WithGroupLimit(n int) GroupOption {
+	return func(gg *GeocodeGroup) {
+		if n > 0 {
+			gg.limit = n
+		}
+	}
+}
+
+// WithGroupFatal sets which errors cancel the remaining calls of a GeocodeGroup, every error
+// does by default. Other errors are collected and returned by Wait once all calls are done
+func WithGroupFatal(fatal func(error) bool) GroupOption {
+	return func(gg *GeocodeGroup) {
+		gg.fatal = fatal
+	}
+}
+
+// GeocodeGroup runs geocoding calls concurrently, errgroup style, storing responses in slots
+// provided by the caller. It is created by Geocoder.Group
+type GeocodeGroup struct {
+	geocoder *Geocoder
+	group    *errgroup.Group
+	ctx      context.Context
+	limit    int
+	fatal    func(error) bool
+
+	mu sync.Mutex
+	// Non-fatal errors
+	errs []error
+}
+
+// Group returns a GeocodeGroup and the context its calls run with, cancelled on the first fatal
+// error or when Wait returns. By default as many calls run at once as the limiter allows per
+// second, the sum of the credential limits with WithCredentialPool, so calls wait in the limiter
+// rather than piling up
+func (g *Geocoder) Group(ctx context.Context, opts ...GroupOption) (*GeocodeGroup, context.Context) {
+	group, ctx := errgroup.WithContext(ctx)
+	gg := &GeocodeGroup{geocoder: g, group: group, ctx: ctx, limit: g.groupLimit()}
+	for _, opt := range opts {
+		opt(gg)
+	}
+	group.SetLimit(gg.limit)
+	return gg, ctx
+}
+
+// groupLimit returns the number of requests the limiters allow per second, at least the burst
+func (g *Geocoder) groupLimit() int {
+	limit := float64(g.limiter.Limit())
+	if g.pool != nil {
+		limit = 0
+		for _, m := range g.pool.members {
+			limit += float64(m.limiter.Limit())
+		}
+	}
+	return max(int(math.Ceil(limit)), g.burst, 1)
+}
+
+// ReverseGeocode reverse geocodes lat, lng into *slot. It blocks while the group limit is reached
+func (gg *GeocodeGroup) ReverseGeocode(lat, lng float64, slot **GoogleResponse) {
+	gg.Go(func(ctx context.Context) (*GoogleResponse, error) {
+		return gg.geocoder.ReverseGeocode(ctx, lat, lng)
+	}, slot)
+}
+
+// Geocode geocodes address into *slot. It blocks while the group limit is reached
+func (gg *GeocodeGroup) Geocode(address string, slot **GoogleResponse) {
+	gg.Go(func(ctx context.Context) (*GoogleResponse, error) {
+		return gg.geocoder.Geocode(ctx, address)
+	}, slot)
+}
+
+// Go runs call with the group context and stores its response into *slot, which may be nil.
+// It blocks while the group limit is reached
+func (gg *GeocodeGroup) Go(call func(ctx context.Context) (*GoogleResponse, error), slot **GoogleResponse) {
+	gg.group.Go(func() error {
+		res, err := call(gg.ctx)
+		if slot != nil {
+			*slot = res
+		}
+		if err == nil || gg.fatal == nil || gg.fatal(err) {
+			return err
+		}
+		gg.mu.Lock()
+		gg.errs = append(gg.errs, err)
+		gg.mu.Unlock()
+		return nil
+	})
+}
+
+// Wait waits for all calls and returns the first fatal error, if any, or the non-fatal ones joined
+func (gg *GeocodeGroup) Wait() error {
+	if err := gg.group.Wait(); err != nil {
+		return err
+	}
+	gg.mu.Lock()
+	defer gg.mu.Unlock()
+	return errors.Join(gg.errs...)
+}
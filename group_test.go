@@ -0,0 +1,70 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_GeocodeGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []GroupOption
+		cancelled bool
+	}{
+		{"fatal", nil, true},
+		{"not fatal", []GroupOption{WithGroupFatal(func(err error) bool { return !errors.Is(err, ErrInvalidCoordinates) })}, false},
+	}
+	for _, tt := range tests {
+		client := requesterFunc(func(targetURL string) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+		})
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Hour, nil, WithAPIKey("key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		slots := make([]*GoogleResponse, 3)
+		group, ctx := g.Group(context.TODO(), append(tt.opts, WithGroupLimit(1))...)
+		group.ReverseGeocode(91, 0, &slots[0])
+		group.ReverseGeocode(52.52, 13.405, &slots[1])
+		group.Geocode("Berlin", &slots[2])
+		err = group.Wait()
+		if !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, ErrInvalidCoordinates)
+		}
+		if ctx.Err() == nil {
+			t.Errorf("test for %v Failed - context not cancelled by Wait", tt.name)
+		}
+		// with a limit of 1 calls run in order, the failed one first
+		filled := slots[1] != nil && slots[2] != nil
+		if filled == tt.cancelled {
+			t.Errorf("test for %v Failed - slots %v", tt.name, slots)
+		}
+	}
+}
+
+func Test_GeocodeGroupLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected int
+	}{
+		{"rate", nil, 5},
+		{"burst", []Option{WithBurst(8)}, 8},
+		{"pool", []Option{WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "a", RPS: 3}, Credential{APIKey: "b"})}, 8},
+	}
+	for _, tt := range tests {
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 5, time.Hour, nil,
+			append(tt.opts, WithAPIKey("key"))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.groupLimit(); got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package geocoderpb contains the geocoder.v1 protocol buffers and gRPC stubs
+package geocoderpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative geocoder.proto
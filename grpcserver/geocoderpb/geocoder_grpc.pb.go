@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.27.1
+// source: geocoder.proto
+
+package geocoderpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Geocoder_ReverseGeocode_FullMethodName = "/geocoder.v1.Geocoder/ReverseGeocode"
+	Geocoder_Geocode_FullMethodName        = "/geocoder.v1.Geocoder/Geocode"
+	Geocoder_Batch_FullMethodName          = "/geocoder.v1.Geocoder/Batch"
+)
+
+// GeocoderClient is the client API for Geocoder service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Geocoder exposes the caching and rate limiting geocoding layer
+type GeocoderClient interface {
+	// ReverseGeocode returns addresses of a coordinate
+	ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error)
+	// Geocode returns coordinates of an address
+	Geocode(ctx context.Context, in *GeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error)
+	// Batch geocodes a stream of items, responses are streamed back in request order.
+	// A failed item doesn't end the stream, its error is reported in BatchResponse
+	Batch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchRequest, BatchResponse], error)
+}
+
+type geocoderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeocoderClient(cc grpc.ClientConnInterface) GeocoderClient {
+	return &geocoderClient{cc}
+}
+
+func (c *geocoderClient) ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeocodeResponse)
+	err := c.cc.Invoke(ctx, Geocoder_ReverseGeocode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geocoderClient) Geocode(ctx context.Context, in *GeocodeRequest, opts ...grpc.CallOption) (*GeocodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeocodeResponse)
+	err := c.cc.Invoke(ctx, Geocoder_Geocode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geocoderClient) Batch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchRequest, BatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Geocoder_ServiceDesc.Streams[0], Geocoder_Batch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchRequest, BatchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Geocoder_BatchClient = grpc.BidiStreamingClient[BatchRequest, BatchResponse]
+
+// GeocoderServer is the server API for Geocoder service.
+// All implementations must embed UnimplementedGeocoderServer
+// for forward compatibility.
+//
+// Geocoder exposes the caching and rate limiting geocoding layer
+type GeocoderServer interface {
+	// ReverseGeocode returns addresses of a coordinate
+	ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*GeocodeResponse, error)
+	// Geocode returns coordinates of an address
+	Geocode(context.Context, *GeocodeRequest) (*GeocodeResponse, error)
+	// Batch geocodes a stream of items, responses are streamed back in request order.
+	// A failed item doesn't end the stream, its error is reported in BatchResponse
+	Batch(grpc.BidiStreamingServer[BatchRequest, BatchResponse]) error
+	mustEmbedUnimplementedGeocoderServer()
+}
+
+// UnimplementedGeocoderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGeocoderServer struct{}
+
+func (UnimplementedGeocoderServer) ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*GeocodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReverseGeocode not implemented")
+}
+func (UnimplementedGeocoderServer) Geocode(context.Context, *GeocodeRequest) (*GeocodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Geocode not implemented")
+}
+func (UnimplementedGeocoderServer) Batch(grpc.BidiStreamingServer[BatchRequest, BatchResponse]) error {
+	return status.Error(codes.Unimplemented, "method Batch not implemented")
+}
+func (UnimplementedGeocoderServer) mustEmbedUnimplementedGeocoderServer() {}
+func (UnimplementedGeocoderServer) testEmbeddedByValue()                  {}
+
+// UnsafeGeocoderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeocoderServer will
+// result in compilation errors.
+type UnsafeGeocoderServer interface {
+	mustEmbedUnimplementedGeocoderServer()
+}
+
+func RegisterGeocoderServer(s grpc.ServiceRegistrar, srv GeocoderServer) {
+	// If the following call panics, it indicates UnimplementedGeocoderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Geocoder_ServiceDesc, srv)
+}
+
+func _Geocoder_ReverseGeocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseGeocodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeocoderServer).ReverseGeocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Geocoder_ReverseGeocode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeocoderServer).ReverseGeocode(ctx, req.(*ReverseGeocodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geocoder_Geocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeocodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeocoderServer).Geocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Geocoder_Geocode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeocoderServer).Geocode(ctx, req.(*GeocodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Geocoder_Batch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GeocoderServer).Batch(&grpc.GenericServerStream[BatchRequest, BatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Geocoder_BatchServer = grpc.BidiStreamingServer[BatchRequest, BatchResponse]
+
+// Geocoder_ServiceDesc is the grpc.ServiceDesc for Geocoder service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Geocoder_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "geocoder.v1.Geocoder",
+	HandlerType: (*GeocoderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReverseGeocode",
+			Handler:    _Geocoder_ReverseGeocode_Handler,
+		},
+		{
+			MethodName: "Geocode",
+			Handler:    _Geocoder_Geocode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Batch",
+			Handler:       _Geocoder_Batch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "geocoder.proto",
+}
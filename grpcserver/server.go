@@ -0,0 +1,177 @@
+// Package grpcserver implements the geocoder.v1.Geocoder gRPC service on top of a Geocoder,
+// so that services in any language share its cache and rate limiter
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/alvillain/geocoder"
+	pb "github.com/alvillain/geocoder/grpcserver/geocoderpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Geocoder is implemented by geocoder.Geocoder
+type Geocoder interface {
+	geocoder.ReverseGeocoder
+	geocoder.ForwardGeocoder
+}
+
+// Server implements pb.GeocoderServer, register it with pb.RegisterGeocoderServer
+type Server struct {
+	pb.UnimplementedGeocoderServer
+	geocoder Geocoder
+	// Parallel requests per Batch stream
+	batchConcurrency int
+}
+
+var _ pb.GeocoderServer = (*Server)(nil)
+
+// New creates new instance of Server. Each Batch stream sends up to batchConcurrency requests at once
+func New(g Geocoder, batchConcurrency int) (*Server, error) {
+	if g == nil {
+		return nil, errors.New("empty Geocoder")
+	}
+	if batchConcurrency <= 0 {
+		return nil, errors.New("batchConcurrency must be a positive number")
+	}
+	return &Server{geocoder: g, batchConcurrency: batchConcurrency}, nil
+}
+
+// ReverseGeocode implements pb.GeocoderServer
+func (s *Server) ReverseGeocode(ctx context.Context, req *pb.ReverseGeocodeRequest) (*pb.GeocodeResponse, error) {
+	if req.GetLocation() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty location")
+	}
+	res, err := s.geocoder.ReverseGeocode(ctx, req.GetLocation().GetLat(), req.GetLocation().GetLng())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProto(res), nil
+}
+
+// Geocode implements pb.GeocoderServer
+func (s *Server) Geocode(ctx context.Context, req *pb.GeocodeRequest) (*pb.GeocodeResponse, error) {
+	if req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty address")
+	}
+	res, err := s.geocoder.Geocode(ctx, req.GetAddress())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProto(res), nil
+}
+
+// Batch implements pb.GeocoderServer. Responses are sent in request order as soon as they are done.
+// A request without a query ends the stream with InvalidArgument
+func (s *Server) Batch(stream pb.Geocoder_BatchServer) error {
+	processor, err := geocoder.NewBatchProcessor(s.geocoder, s.batchConcurrency)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var recvErr error
+	items := make(chan geocoder.BatchItem)
+	go func() {
+		defer close(items)
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				recvErr = err
+				return
+			}
+			item := geocoder.BatchItem{ID: req.GetId()}
+			switch q := req.GetQuery().(type) {
+			case *pb.BatchRequest_Location:
+				item.Lat, item.Lng = q.Location.GetLat(), q.Location.GetLng()
+			case *pb.BatchRequest_Address:
+				item.Address = q.Address
+			}
+			if item.Address == "" && req.GetLocation() == nil {
+				recvErr = status.Errorf(codes.InvalidArgument, "item %q: empty query", req.GetId())
+				return
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err = processor.Process(ctx, items, func(r geocoder.BatchResult) error {
+		resp := &pb.BatchResponse{Id: r.Item.ID}
+		if r.Err != nil {
+			resp.Error = r.Err.Error()
+		} else {
+			resp.Response = toProto(r.Response)
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		return statusError(err)
+	}
+	if recvErr != nil {
+		return statusError(recvErr)
+	}
+	return nil
+}
+
+// statusError maps a Geocoder error to a gRPC status. StatusError of WithStatusErrors is
+// mapped by its Google status
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var quotaErr *geocoder.QuotaError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, geocoder.ErrWouldExceedDeadline):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, geocoder.ErrInvalidCoordinates), errors.Is(err, geocoder.ErrInvalidPlusCode),
+		errors.Is(err, geocoder.ErrInvalidRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, geocoder.ErrZeroResults):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, geocoder.ErrRequestDenied), errors.Is(err, geocoder.ErrNoCredentials):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &quotaErr), errors.Is(err, geocoder.ErrOverQueryLimit), errors.Is(err, geocoder.ErrCoolingDown),
+		errors.Is(err, geocoder.ErrRateLimited), errors.Is(err, geocoder.ErrQuotaExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, geocoder.ErrClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	// paused Geocoder, open circuit breaker, UNKNOWN_ERROR, transport failures and HTTP errors of Google
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func toProto(res *geocoder.GoogleResponse) *pb.GeocodeResponse {
+	out := &pb.GeocodeResponse{Status: string(res.Status), ErrorMessage: res.ErrorMessage, Degradation: string(res.Degradation)}
+	for _, rs := range res.Results {
+		result := &pb.Result{
+			FormattedAddress: rs.FormattedAddress,
+			PlaceId:          rs.PlaceID,
+			Location:         &pb.LatLng{Lat: rs.Geometry.Location.Lat, Lng: rs.Geometry.Location.Lng},
+			LocationType:     rs.Geometry.LocationType,
+			Types:            rs.Types,
+			PartialMatch:     rs.PartialMatch,
+		}
+		for _, c := range rs.AddressComponents {
+			result.AddressComponents = append(result.AddressComponents, &pb.AddressComponent{
+				LongName:  c.LongName,
+				ShortName: c.ShortName,
+				Types:     c.Types,
+			})
+		}
+		out.Results = append(out.Results, result)
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+	pb "github.com/alvillain/geocoder/grpcserver/geocoderpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeGeocoder struct{}
+
+func (fakeGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, error) {
+	if lat < 0 {
+		return nil, geocoder.ErrRateLimited
+	}
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{
+		FormattedAddress: "Berlin, Germany",
+		Geometry:         geocoder.Geometry{Location: geocoder.Coordinate{Lat: lat, Lng: lng}},
+	}}}, nil
+}
+
+func (fakeGeocoder) Geocode(ctx context.Context, address string) (*geocoder.GoogleResponse, error) {
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}, nil
+}
+
+func newClient(t *testing.T) pb.GeocoderClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	s, err := New(fakeGeocoder{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb.RegisterGeocoderServer(srv, s)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewGeocoderClient(conn)
+}
+
+func Test_Unary(t *testing.T) {
+	client := newClient(t)
+
+	res, err := client.ReverseGeocode(context.TODO(), &pb.ReverseGeocodeRequest{Location: &pb.LatLng{Lat: 52.5, Lng: 13.4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetStatus() != "OK" || res.GetResults()[0].GetFormattedAddress() != "Berlin, Germany" {
+		t.Errorf("test for ReverseGeocode Failed - unexpected response %v", res)
+	}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected codes.Code
+	}{
+		{"rate limited", func() error {
+			_, err := client.ReverseGeocode(context.TODO(), &pb.ReverseGeocodeRequest{Location: &pb.LatLng{Lat: -1}})
+			return err
+		}, codes.ResourceExhausted},
+		{"empty location", func() error {
+			_, err := client.ReverseGeocode(context.TODO(), &pb.ReverseGeocodeRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"geocode", func() error {
+			_, err := client.Geocode(context.TODO(), &pb.GeocodeRequest{Address: "nowhere"})
+			return err
+		}, codes.OK},
+	}
+	for _, tt := range tests {
+		if got := status.Code(tt.call()); got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_Batch(t *testing.T) {
+	client := newClient(t)
+	stream, err := client.Batch(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := []*pb.BatchRequest{
+		{Id: "a", Query: &pb.BatchRequest_Location{Location: &pb.LatLng{Lat: 1, Lng: 2}}},
+		{Id: "b", Query: &pb.BatchRequest_Location{Location: &pb.LatLng{Lat: -1, Lng: 2}}},
+		{Id: "c", Query: &pb.BatchRequest_Address{Address: "nowhere"}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, resp.GetId()+":"+resp.GetResponse().GetStatus()+resp.GetError())
+	}
+	expected := []string{"a:OK", "b:rate limit exceeded", "c:ZERO_RESULTS"}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] || got[2] != expected[2] {
+		t.Errorf("test for Batch Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
+
+func Test_StatusError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"Canceled", context.Canceled, codes.Canceled},
+		{"Would exceed deadline", fmt.Errorf("%w: wait 1s", geocoder.ErrWouldExceedDeadline), codes.DeadlineExceeded},
+		{"Swapped coordinates", geocoder.ErrSwappedCoordinates, codes.InvalidArgument},
+		{"Invalid plus code", geocoder.ErrInvalidPlusCode, codes.InvalidArgument},
+		{"INVALID_REQUEST", &geocoder.StatusError{Status: geocoder.GRS_INVALID_REQUEST}, codes.InvalidArgument},
+		{"ZERO_RESULTS", &geocoder.StatusError{Status: geocoder.GRS_ZERO_RESULTS}, codes.NotFound},
+		{"REQUEST_DENIED", &geocoder.StatusError{Status: geocoder.GRS_REQUEST_DENIED}, codes.PermissionDenied},
+		{"OVER_QUERY_LIMIT", &geocoder.StatusError{Status: geocoder.GRS_OVER_QUERY_LIMIT}, codes.ResourceExhausted},
+		{"UNKNOWN_ERROR", &geocoder.StatusError{Status: geocoder.GRS_UNKNOWN_ERROR}, codes.Unavailable},
+		{"HTTP 429", &geocoder.HTTPError{StatusCode: 429}, codes.ResourceExhausted},
+		{"HTTP 503", &geocoder.HTTPError{StatusCode: 503}, codes.Unavailable},
+		{"Rate limited", geocoder.ErrRateLimited, codes.ResourceExhausted},
+		{"Closed", geocoder.ErrClosed, codes.FailedPrecondition},
+		{"Paused", geocoder.ErrPaused, codes.Unavailable},
+		{"Circuit open", geocoder.ErrCircuitOpen, codes.Unavailable},
+		{"gRPC status", status.Error(codes.Aborted, "aborted"), codes.Aborted},
+	}
+
+	for _, tt := range tests {
+		if res := status.Code(statusError(tt.err)); res != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package geocoder
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithHeaders adds h to every request, e.g. X-Request-ID of the calling service.
+// Headers added by several calls are merged. The HttpRequester must implement HttpDoer
+func WithHeaders(h http.Header) Option {
+	return func(g *Geocoder) {
+		if g.header == nil {
+			g.header = http.Header{}
+		}
+		for k, v := range h {
+			for _, value := range v {
+				g.header.Add(k, value)
+			}
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request. The HttpRequester must implement HttpDoer
+func WithUserAgent(userAgent string) Option {
+	return func(g *Geocoder) {
+		if g.header == nil {
+			g.header = http.Header{}
+		}
+		g.header.Set("User-Agent", userAgent)
+	}
+}
+
+// WithHeaderFunc calls f for every request to set headers depending on the request context,
+// e.g. to propagate a trace. It is called after the headers of WithHeaders are set and may
+// change them. The HttpRequester must implement HttpDoer
+func WithHeaderFunc(f func(ctx context.Context, h http.Header)) Option {
+	return func(g *Geocoder) {
+		g.headerFunc = f
+	}
+}
+
+// customHeaders reports whether requests carry headers set by options
+func (g *Geocoder) customHeaders() bool {
+	return len(g.header) > 0 || g.headerFunc != nil
+}
+
+// setHeaders adds the headers of the options to req. Credential headers are set afterwards
+// and can't be overridden
+func (g *Geocoder) setHeaders(ctx context.Context, req *http.Request) {
+	for k, v := range g.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	if g.headerFunc != nil {
+		g.headerFunc(ctx, req.Header)
+	}
+}
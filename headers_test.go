@@ -0,0 +1,53 @@
+package geocoder
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+func Test_Headers(t *testing.T) {
+	client := &fakeHttpDoer{fakeHttpRequester: fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}}
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second, nil,
+		WithAPIKeyHeader("my_api_key"),
+		WithUserAgent("my-service/1.0"),
+		WithHeaders(http.Header{"X-Team": {"geo"}, "X-Goog-Api-Key": {"overridden"}}),
+		WithHeaderFunc(func(ctx context.Context, h http.Header) {
+			if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+				h.Set("X-Request-ID", id)
+			}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"req-1", "req-2"} {
+		ctx := context.WithValue(context.TODO(), requestIDKey{}, id)
+		if _, err := geocoder.Geocode(ctx, "Berlin "+id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, id := range []string{"req-1", "req-2"} {
+		req := client.requests[i]
+		expected := map[string]string{
+			"User-Agent":     "my-service/1.0",
+			"X-Team":         "geo",
+			"X-Request-Id":   id,
+			"X-Goog-Api-Key": "my_api_key",
+		}
+		for name, value := range expected {
+			if got := req.Header.Get(name); got != value {
+				t.Errorf("test for %v of %v Failed - results not match\nGot:\n%v\nExpected:\n%v", name, id, got, value)
+			}
+		}
+	}
+
+	if _, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 1000, time.Second, nil,
+		WithAPIKey("my_api_key"), WithUserAgent("my-service/1.0")); err == nil {
+		t.Errorf("test for headers without HttpDoer Failed - expected error")
+	}
+}
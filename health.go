@@ -0,0 +1,71 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Health is the outcome of Ping
+type Health string
+
+const (
+	// Google answered the probe
+	HS_HEALTHY Health = "healthy"
+	// Requests fail or are held back for reasons expected to pass: cooldowns, quota,
+	// the circuit breaker, network and server errors
+	HS_DEGRADED Health = "degraded"
+	// Google rejects the credentials, e.g. a revoked API key or a wrong signing key
+	HS_AUTH_BROKEN Health = "auth_broken"
+)
+
+// WithPingProbe makes Ping reverse geocode lat, lng instead of the Google headquarters
+func WithPingProbe(lat, lng float64) Option {
+	return func(g *Geocoder) {
+		g.probe = &Coordinate{Lat: lat, Lng: lng}
+	}
+}
+
+// Ping reverse geocodes the probe coordinate, bypassing the caches, and classifies the outcome
+// for readiness probes. While paused, cooling down or with the circuit breaker open no request is sent
+// and HS_DEGRADED is returned. The error tells why the Geocoder is not healthy
+func (g *Geocoder) Ping(ctx context.Context) (Health, error) {
+	if g.Paused() {
+		return HS_DEGRADED, ErrPaused
+	}
+	if g.coolingDown() {
+		return HS_DEGRADED, ErrCoolingDown
+	}
+	if g.breaker.current() == CS_OPEN {
+		return HS_DEGRADED, ErrCircuitOpen
+	}
+	probe := Coordinate{Lat: probeLat, Lng: probeLng}
+	if g.probe != nil {
+		probe = *g.probe
+	}
+	res, err := g.do(ctx, &request{
+		op:      OP_REVERSE_GEOCODING,
+		params:  latLngParams(probe.Lat, probe.Lng, g.precision),
+		noCache: true,
+	})
+	if err == nil {
+		err = res.Err()
+		if res.Status == GRS_ZERO_RESULTS {
+			err = nil
+		}
+	}
+	return classifyHealth(err), err
+}
+
+// classifyHealth returns the Health of a Geocoder whose probe failed with err
+func classifyHealth(err error) Health {
+	if err == nil {
+		return HS_HEALTHY
+	}
+	var he *HTTPError
+	if errors.Is(err, ErrRequestDenied) || errors.Is(err, ErrNoCredentials) ||
+		errors.As(err, &he) && (he.StatusCode == http.StatusUnauthorized || he.StatusCode == http.StatusForbidden) {
+		return HS_AUTH_BROKEN
+	}
+	return HS_DEGRADED
+}
@@ -0,0 +1,79 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Ping(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		opts     []Option
+		expected Health
+	}{
+		{"ok", http.StatusOK, `{"status":"OK","results":[{"formatted_address":"Mountain View"}]}`, nil, HS_HEALTHY},
+		{"zero results", http.StatusOK, `{"status":"ZERO_RESULTS"}`, []Option{WithStatusErrors()}, HS_HEALTHY},
+		{"request denied", http.StatusOK, `{"status":"REQUEST_DENIED","error_message":"The provided API key is invalid."}`, nil, HS_AUTH_BROKEN},
+		{"request denied with status errors", http.StatusOK, `{"status":"REQUEST_DENIED"}`, []Option{WithStatusErrors()}, HS_AUTH_BROKEN},
+		{"forbidden", http.StatusForbidden, `{}`, nil, HS_AUTH_BROKEN},
+		{"over query limit", http.StatusOK, `{"status":"OVER_QUERY_LIMIT"}`, []Option{WithNonBlockingCooldown()}, HS_DEGRADED},
+		{"server error", http.StatusInternalServerError, `{}`, nil, HS_DEGRADED},
+	}
+	for _, tt := range tests {
+		var requested []string
+		client := requesterFunc(func(targetURL string) (*http.Response, error) {
+			u, _ := url.Parse(targetURL)
+			requested = append(requested, u.Query().Get("latlng"))
+			return &http.Response{StatusCode: tt.status, Body: io.NopCloser(strings.NewReader(tt.body))}, nil
+		})
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Hour, nil,
+			append(tt.opts, WithAPIKey("key"), WithCache(NewMemoryCache(10, time.Hour)))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			health, err := g.Ping(context.TODO())
+			if i == 1 && tt.name == "over query limit" {
+				// no request is sent during the cooldown
+				if health != HS_DEGRADED || !errors.Is(err, ErrCoolingDown) || len(requested) != 1 {
+					t.Errorf("test for %v during cooldown Failed - unexpected %v %v", tt.name, health, err)
+				}
+				continue
+			}
+			if health != tt.expected || (health == HS_HEALTHY) != (err == nil) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v", tt.name, health, err, tt.expected)
+			}
+		}
+		if tt.name != "over query limit" && (len(requested) != 2 || requested[0] != "37.42247640,-122.08424990") {
+			t.Errorf("test for %v Failed - probes not sent past the cache %v", tt.name, requested)
+		}
+		if stats := g.CacheStats(); stats.Hits+stats.Misses != 0 {
+			t.Errorf("test for %v Failed - probe used the cache %+v", tt.name, stats)
+		}
+	}
+}
+
+func Test_PingProbe(t *testing.T) {
+	var requested string
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, _ := url.Parse(targetURL)
+		requested = u.Query().Get("latlng")
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Hour, nil,
+		WithAPIKey("key"), WithPingProbe(52.52, 13.405))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health, err := g.Ping(context.TODO()); health != HS_HEALTHY || err != nil || requested != "52.52000000,13.40500000" {
+		t.Errorf("test for probe Failed - unexpected %v %v %v", health, err, requested)
+	}
+}
@@ -0,0 +1,87 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// WithHedging sends a second, hedged request if the first one hasn't completed within delay
+// of being sent, the time it waits for the limiter and the cooldown doesn't count, and returns whichever succeeds first, cancelling the other. The hedged request goes to
+// secondaryEndpoint, or to the same endpoint if it is empty. It is only sent if the limiter
+// allows it right away, and it counts against the quota like any other request
+func WithHedging(delay time.Duration, secondaryEndpoint string) Option {
+	return func(g *Geocoder) {
+		g.hedgeDelay = delay
+		g.hedgeEndpoint = secondaryEndpoint
+	}
+}
+
+// hedgedResult is the outcome of one of the hedged requests
+type hedgedResult struct {
+	res   *GoogleResponse
+	info  *RequestInfo
+	err   error
+	hedge bool
+}
+
+// sendHedged sends req and hedges it once the hedge delay has passed after the HTTP request
+// was sent without a response.
+// The first successful response wins, the error of req is returned if both fail
+func (g *Geocoder) sendHedged(ctx context.Context, req *request, member *poolMember) (*GoogleResponse, *RequestInfo, error) {
+	if g.hedgeDelay <= 0 || req.noWait {
+		return g.sendCounted(ctx, req, member)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan hedgedResult, 2)
+	run := func(r *request) {
+		res, info, err := g.sendCounted(ctx, r, member)
+		results <- hedgedResult{res: res, info: info, err: err, hedge: r.hedge}
+	}
+	sent := make(chan struct{})
+	primary := *req
+	primary.onSend = func() { close(sent) }
+	go run(&primary)
+
+	// a request queued behind the limiter is not slow yet, hedging it would use up tokens when they are scarce
+	select {
+	case r := <-results:
+		return r.res, r.info, r.err
+	case <-sent:
+	}
+	timer := g.clock.NewTimer(g.hedgeDelay)
+	defer timer.Stop()
+	select {
+	case r := <-results:
+		return r.res, r.info, r.err
+	case <-timer.C():
+	}
+	g.logger.DebugContext(ctx, "hedging geocoding request", slog.Duration("delay", g.hedgeDelay))
+	hedge := *req
+	hedge.noWait = true
+	hedge.hedge = true
+	go run(&hedge)
+
+	var failed hedgedResult
+	for i := 0; i < 2; i++ {
+		r := <-results
+		if r.err == nil {
+			return r.res, r.info, nil
+		}
+		if !r.hedge {
+			failed = r
+		} else if !errors.Is(r.err, ErrRateLimited) && !errors.Is(r.err, ErrCoolingDown) {
+			g.logger.DebugContext(ctx, "hedged geocoding request failed", slog.Any("error", r.err))
+		}
+	}
+	return failed.res, failed.info, failed.err
+}
+
+// sendCounted is send recording the attempt in Stats
+func (g *Geocoder) sendCounted(ctx context.Context, req *request, member *poolMember) (*GoogleResponse, *RequestInfo, error) {
+	res, info, err := g.send(ctx, req, member)
+	g.counters.attempt(info)
+	return res, info, err
+}
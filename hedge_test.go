@@ -0,0 +1,109 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// doerFunc adapts a function to HttpRequester and HttpDoer
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func (f doerFunc) Get(targetURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return f(req)
+}
+
+func Test_Hedging(t *testing.T) {
+	tests := []struct {
+		name      string
+		secondary string
+		expected  string
+	}{
+		{"same endpoint", "", "primary"},
+		{"secondary endpoint", "https://secondary/maps/api/geocode/json", "secondary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var hosts []string
+			cancelled := make(chan struct{})
+			client := doerFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				hosts = append(hosts, req.URL.Host)
+				first := len(hosts) == 1
+				mu.Unlock()
+				if first {
+					// the first request hangs until the hedged one wins
+					<-req.Context().Done()
+					close(cancelled)
+					return nil, req.Context().Err()
+				}
+				body := `{"status":"OK","results":[{"formatted_address":"` + req.URL.Host + `"}]}`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+			})
+			g, err := NewGeocoder(nil, "https://primary/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithHedging(10*time.Millisecond, tt.secondary))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := res.Results[0].FormattedAddress; got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+			select {
+			case <-cancelled:
+			case <-time.After(time.Second):
+				t.Errorf("test for %v Failed - slow request was not cancelled", tt.name)
+			}
+		})
+	}
+}
+
+func Test_HedgingFastResponse(t *testing.T) {
+	var requests int
+	g, err := NewGeocoder(nil, "https://localhost", "en", flakyClient(&requests, http.StatusOK), 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithHedging(50*time.Millisecond, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil || requests != 1 {
+		t.Errorf("test for fast response Failed - error %v after %d requests", err, requests)
+	}
+}
+
+func Test_HedgingLimiterWait(t *testing.T) {
+	var requests int
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g, err := NewGeocoder(nil, "https://localhost", "en", flakyClient(&requests, http.StatusOK), 20, time.Millisecond, nil,
+		WithAPIKey("key"), WithHedging(10*time.Millisecond, ""), WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the second request waits 50ms for the limiter, longer than the hedge delay, then responds right away
+	for i := 0; i < 2; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 1, float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Contains(logs.String(), "hedging geocoding request") || requests != 2 {
+		t.Errorf("test for limiter wait Failed - request waiting for the limiter was hedged after %d requests", requests)
+	}
+}
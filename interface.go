@@ -0,0 +1,17 @@
+package geocoder
+
+import "context"
+
+// Interface is the geocoding API of Geocoder. Depend on it instead of *Geocoder to substitute
+// geocodertest.Mock in unit tests
+type Interface interface {
+	ReverseGeocoder
+	ForwardGeocoder
+	TryReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error)
+	ReverseGeocodeLngLat(ctx context.Context, lng, lat float64) (*GoogleResponse, error)
+	ReverseGeocodeAsync(ctx context.Context, lat, lng float64) *Future
+	GeocodeAsync(ctx context.Context, address string) *Future
+	VerifyAddress(ctx context.Context, address, language string) (*DualAddress, error)
+}
+
+var _ Interface = (*Geocoder)(nil)
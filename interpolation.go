@@ -0,0 +1,29 @@
+package geocoder
+
+// Interpolation describes a street address whose location was interpolated
+// between two precise points, e.g. on a range of house numbers
+type Interpolation struct {
+	// Interpolated point
+	Location Coordinate
+	// Bounding range of the interpolation, nil if Google did not return it
+	Range *Bounds
+}
+
+// IsInterpolated reports whether the result location is an interpolated point
+// rather than an exact position
+func (r *ResultSet) IsInterpolated() bool {
+	return r.Geometry.LocationType == LT_RANGE_INTERPOLATED
+}
+
+// IsExactBuilding reports whether the result is a precise, rooftop level location of a building
+func (r *ResultSet) IsExactBuilding() bool {
+	return r.Geometry.LocationType == LT_ROOFTOP
+}
+
+// Interpolation returns interpolation details of a RANGE_INTERPOLATED result and nil otherwise
+func (r *ResultSet) Interpolation() *Interpolation {
+	if !r.IsInterpolated() {
+		return nil
+	}
+	return &Interpolation{Location: r.Geometry.Location, Range: r.Geometry.Bounds}
+}
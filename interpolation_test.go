@@ -0,0 +1,53 @@
+package geocoder
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_Interpolation(t *testing.T) {
+	tests := []struct {
+		name          string
+		geometryJSON  string
+		exact         bool
+		expectedInter *Interpolation
+	}{
+		{
+			"Rooftop",
+			`{"location":{"lat":1,"lng":2},"location_type":"ROOFTOP"}`,
+			true,
+			nil,
+		},
+		{
+			"Interpolated with range",
+			`{"location":{"lat":1,"lng":2},"location_type":"RANGE_INTERPOLATED","bounds":{"southwest":{"lat":0.5,"lng":1.5},"northeast":{"lat":1.5,"lng":2.5}}}`,
+			false,
+			&Interpolation{
+				Location: Coordinate{Lat: 1, Lng: 2},
+				Range:    &Bounds{SouthWest: Coordinate{Lat: 0.5, Lng: 1.5}, NorthEast: Coordinate{Lat: 1.5, Lng: 2.5}},
+			},
+		},
+		{
+			"Interpolated without range",
+			`{"location":{"lat":1,"lng":2},"location_type":"RANGE_INTERPOLATED"}`,
+			false,
+			&Interpolation{Location: Coordinate{Lat: 1, Lng: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r ResultSet
+			if err := json.Unmarshal([]byte(tt.geometryJSON), &r.Geometry); err != nil {
+				t.Fatal(err)
+			}
+			if r.IsExactBuilding() != tt.exact {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, r.IsExactBuilding(), tt.exact)
+			}
+			if res := r.Interpolation(); !reflect.DeepEqual(res, tt.expectedInter) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res, tt.expectedInter)
+			}
+		})
+	}
+}
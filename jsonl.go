@@ -0,0 +1,116 @@
+package geocoder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxJSONLLine is the longest input line of ProcessJSONL
+const maxJSONLLine = 1 << 20
+
+// JSONLRecord is an input line of ProcessJSONL. Address is geocoded if set, the coordinate is
+// reverse geocoded otherwise
+type JSONLRecord struct {
+	ID      string   `json:"id,omitempty"`
+	Address string   `json:"address,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lng     *float64 `json:"lng,omitempty"`
+}
+
+// JSONLResult is an output line of ProcessJSONL, the input record enriched with the best result
+type JSONLResult struct {
+	JSONLRecord
+	Status           GoogleResponseStatus `json:"status,omitempty"`
+	FormattedAddress string               `json:"formatted_address,omitempty"`
+	PlaceID          string               `json:"place_id,omitempty"`
+	Location         *Coordinate          `json:"location,omitempty"`
+	Error            string               `json:"error,omitempty"`
+}
+
+// ProcessJSONL geocodes newline-delimited JSON records read from r and writes a JSONLResult line per
+// record to w, in input order as soon as it is done. Blank lines are skipped. Item failures are
+// reported in JSONLResult.Error. A line which isn't a valid record stops processing: the results of
+// the records before it are written and the error tells the line number. With WithCheckpointer
+// records are identified by their id
+func (p *BatchProcessor) ProcessJSONL(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var readErr error
+	items := make(chan BatchItem)
+	go func() {
+		defer close(items)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxJSONLLine)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			record, err := parseJSONLRecord(scanner.Bytes())
+			if err != nil {
+				readErr = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+			item := BatchItem{ID: record.ID, Address: record.Address}
+			if record.Lat != nil && record.Lng != nil {
+				item.Lat, item.Lng = *record.Lat, *record.Lng
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr = scanner.Err()
+	}()
+
+	encoder := json.NewEncoder(w)
+	err := p.Process(ctx, items, func(res BatchResult) error {
+		return encoder.Encode(jsonlResult(res))
+	})
+	cancel()
+	if err != nil {
+		return err
+	}
+	return readErr
+}
+
+func parseJSONLRecord(line []byte) (JSONLRecord, error) {
+	var record JSONLRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return record, err
+	}
+	record.Address = strings.TrimSpace(record.Address)
+	if record.Address == "" && (record.Lat == nil || record.Lng == nil) {
+		return record, errors.New("address or lat and lng are required")
+	}
+	return record, nil
+}
+
+// jsonlResult returns the output line of res, the input record is made of the item
+func jsonlResult(res BatchResult) JSONLResult {
+	result := JSONLResult{JSONLRecord: JSONLRecord{ID: res.Item.ID, Address: res.Item.Address}}
+	if res.Item.Address == "" || res.Item.Lat != 0 || res.Item.Lng != 0 {
+		lat, lng := res.Item.Lat, res.Item.Lng
+		result.Lat, result.Lng = &lat, &lng
+	}
+	if res.Response != nil {
+		result.Status = res.Response.Status
+		if len(res.Response.Results) > 0 {
+			best := res.Response.Results[0]
+			result.FormattedAddress = best.FormattedAddress
+			result.PlaceID = best.PlaceID
+			result.Location = &best.Geometry.Location
+		}
+	}
+	if res.Err != nil {
+		result.Error = res.Err.Error()
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// jsonlGeocoder answers with the queried address, ocean for negative latitudes
+type jsonlGeocoder struct {
+	oceanReverseGeocoder
+}
+
+func (jsonlGeocoder) Geocode(ctx context.Context, address string) (*GoogleResponse, error) {
+	return &GoogleResponse{Status: GRS_OK, Results: []*ResultSet{{
+		FormattedAddress: address + ", France",
+		PlaceID:          "p1",
+		Geometry:         Geometry{Location: Coordinate{Lat: 48.8566, Lng: 2.3522}},
+	}}}, nil
+}
+
+func Test_ProcessJSONL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{
+			name:  "records",
+			input: "{\"id\":\"a\",\"lat\":1,\"lng\":2}\n\n{\"id\":\"b\",\"address\":\"Paris\"}\n{\"id\":\"c\",\"lat\":-1,\"lng\":0}\n",
+			expected: `{"id":"a","lat":1,"lng":2,"status":"OK"}
+{"id":"b","address":"Paris","status":"OK","formatted_address":"Paris, France","place_id":"p1","location":{"lat":48.8566,"lng":2.3522}}
+{"id":"c","lat":-1,"lng":0,"error":"ocean"}
+`,
+		},
+		{
+			name:     "invalid line",
+			input:    "{\"id\":\"a\",\"lat\":1,\"lng\":2}\n{\"id\":\"b\"}\n{\"id\":\"c\",\"lat\":3,\"lng\":4}\n",
+			expected: "{\"id\":\"a\",\"lat\":1,\"lng\":2,\"status\":\"OK\"}\n",
+			err:      "line 2: address or lat and lng are required",
+		},
+	}
+	for _, tt := range tests {
+		processor, err := NewBatchProcessor(jsonlGeocoder{}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		err = processor.ProcessJSONL(context.TODO(), strings.NewReader(tt.input), &out)
+		if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.err)
+		}
+		if out.String() != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, out.String(), tt.expected)
+		}
+	}
+}
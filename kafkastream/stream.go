@@ -0,0 +1,220 @@
+// Package kafkastream geocodes a Kafka topic of coordinates and addresses into an output topic.
+// It doesn't depend on a Kafka client: Consumer and Producer are small enough to wrap any of
+// them, e.g. with github.com/segmentio/kafka-go:
+//
+//	type consumer struct{ r *kafka.Reader }
+//
+//	func (c consumer) Fetch(ctx context.Context) (kafkastream.Message, error) {
+//		m, err := c.r.FetchMessage(ctx)
+//		return kafkastream.Message{Key: m.Key, Value: m.Value, Opaque: m}, err
+//	}
+//
+//	func (c consumer) Commit(ctx context.Context, m kafkastream.Message) error {
+//		return c.r.CommitMessages(ctx, m.Opaque.(kafka.Message))
+//	}
+//
+// Offsets are committed in fetch order and only after the enriched message was produced, so
+// delivery is at-least-once: messages in flight when the stream stops are geocoded again.
+// Records which can never be geocoded, e.g. invalid JSON or ZERO_RESULTS, are produced with the
+// error. Any other geocoding error, e.g. an HTTP 5xx or an exhausted quota, stops the stream
+// before the record is committed, so it is geocoded again after a restart
+package kafkastream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/alvillain/geocoder"
+)
+
+// windowFactor bounds the number of messages in flight or waiting to be committed in order,
+// relative to the concurrency
+const windowFactor = 4
+
+// Message is a Kafka record
+type Message struct {
+	Key   []byte
+	Value []byte
+	// Client specific record, e.g. with the partition and offset to commit, passed through
+	// from Consumer.Fetch to Consumer.Commit
+	Opaque any
+}
+
+// Consumer fetches records of the input topic without committing them
+type Consumer interface {
+	Fetch(ctx context.Context) (Message, error)
+	// Commit commits the offset of m, every earlier record of its partition is done too
+	Commit(ctx context.Context, m Message) error
+}
+
+// Producer writes records to the output topic, it returns once the record is acknowledged
+type Producer interface {
+	Produce(ctx context.Context, m Message) error
+}
+
+// Geocoder is the geocoding API the stream needs, implemented by geocoder.Geocoder
+type Geocoder interface {
+	geocoder.ReverseGeocoder
+	geocoder.ForwardGeocoder
+}
+
+// Job is the JSON value of an input record. Address is geocoded if set, the coordinate is
+// reverse geocoded otherwise
+type Job struct {
+	ID      string   `json:"id,omitempty"`
+	Address string   `json:"address,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lng     *float64 `json:"lng,omitempty"`
+}
+
+// Result is the JSON value of an output record, keyed like the input record. Records which
+// can never be geocoded, e.g. invalid JSON, are produced with Error set
+type Result struct {
+	Job
+	Response *geocoder.GoogleResponse `json:"response,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// Stream geocodes records of a Consumer into a Producer
+type Stream struct {
+	geocoder    Geocoder
+	in          Consumer
+	out         Producer
+	concurrency int
+}
+
+// NewStream creates new instance of Stream geocoding up to concurrency records at once. Rate
+// limiting and retries are up to g, e.g. WithRetries of geocoder.Geocoder
+func NewStream(g Geocoder, in Consumer, out Producer, concurrency int) (*Stream, error) {
+	if g == nil {
+		return nil, errors.New("empty Geocoder")
+	}
+	if in == nil || out == nil {
+		return nil, errors.New("empty Consumer or Producer")
+	}
+	if concurrency <= 0 {
+		return nil, errors.New("concurrency must be a positive number")
+	}
+	return &Stream{geocoder: g, in: in, out: out, concurrency: concurrency}, nil
+}
+
+// pending is a fetched record, done is closed once result or err is set
+type pending struct {
+	msg    Message
+	result Message
+	err    error
+	done   chan struct{}
+}
+
+// Run geocodes records until ctx is done or fetching, producing or committing fails, and
+// returns the error. A Consumer returning io.EOF ends the stream once the fetched records are
+// committed, Run returns nil then. Permanent geocoding failures are produced as results, other
+// ones stop the stream. Run returns once every geocoding call has returned
+func (s *Stream) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan *pending, s.concurrency*windowFactor)
+	slots := make(chan struct{}, s.concurrency)
+	fetchErr := make(chan error, 1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(queue)
+		for {
+			msg, err := s.in.Fetch(ctx)
+			if err != nil {
+				fetchErr <- err
+				return
+			}
+			p := &pending{msg: msg, done: make(chan struct{})}
+			select {
+			case queue <- p:
+			case <-ctx.Done():
+				fetchErr <- ctx.Err()
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				fetchErr <- ctx.Err()
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				p.result, p.err = s.process(ctx, p.msg)
+				close(p.done)
+			}()
+		}
+	}()
+
+	for p := range queue {
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		// the record is geocoded again after a restart
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if p.err != nil {
+			return p.err
+		}
+		if err := s.out.Produce(ctx, p.result); err != nil {
+			return err
+		}
+		if err := s.in.Commit(ctx, p.msg); err != nil {
+			return err
+		}
+	}
+	if err := <-fetchErr; !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// process geocodes the job of msg and returns the output record. Permanent failures are
+// returned in the record, other ones as the error
+func (s *Stream) process(ctx context.Context, msg Message) (Message, error) {
+	var result Result
+	if err := json.Unmarshal(msg.Value, &result.Job); err != nil {
+		result.Error = "invalid job: " + err.Error()
+	} else {
+		var err error
+		switch {
+		case strings.TrimSpace(result.Address) != "":
+			result.Response, err = s.geocoder.Geocode(ctx, result.Address)
+		case result.Lat != nil && result.Lng != nil:
+			result.Response, err = s.geocoder.ReverseGeocode(ctx, *result.Lat, *result.Lng)
+		default:
+			err = errors.New("invalid job: address or lat and lng are required")
+			result.Error = err.Error()
+		}
+		if err != nil && result.Error == "" {
+			if !permanent(err) {
+				return Message{}, err
+			}
+			result.Error = err.Error()
+		}
+	}
+	value, _ := json.Marshal(result)
+	return Message{Key: msg.Key, Value: value}, nil
+}
+
+// permanent reports whether a geocoding error is final for the job, whatever the number of attempts
+func permanent(err error) bool {
+	return errors.Is(err, geocoder.ErrInvalidCoordinates) ||
+		errors.Is(err, geocoder.ErrInvalidRequest) ||
+		errors.Is(err, geocoder.ErrZeroResults) ||
+		errors.Is(err, geocoder.ErrInvalidPlusCode)
+}
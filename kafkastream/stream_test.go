@@ -0,0 +1,153 @@
+package kafkastream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+	"github.com/alvillain/geocoder/geocodertest"
+)
+
+// topic is an in-memory Consumer and Producer
+type topic struct {
+	mu        sync.Mutex
+	records   []Message
+	next      int
+	committed []string
+	produced  []Message
+	// Produce fails for the record with this key
+	failKey string
+}
+
+func (t *topic) Fetch(ctx context.Context) (Message, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.next == len(t.records) {
+		return Message{}, io.EOF
+	}
+	t.next++
+	return t.records[t.next-1], nil
+}
+
+func (t *topic) Commit(ctx context.Context, m Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.committed = append(t.committed, string(m.Key))
+	return nil
+}
+
+func (t *topic) Produce(ctx context.Context, m Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if string(m.Key) == t.failKey {
+		return errors.New("broker unavailable")
+	}
+	t.produced = append(t.produced, m)
+	return nil
+}
+
+func ok(address string) *geocoder.GoogleResponse {
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{FormattedAddress: address}}}
+}
+
+func Test_Stream(t *testing.T) {
+	mock := geocodertest.NewMock().
+		OnReverseGeocode(52.52, 13.405, ok("Berlin"), nil).
+		OnGeocode("Paris", ok("Paris, France"), nil)
+	in := &topic{records: []Message{
+		{Key: []byte("1"), Value: []byte(`{"id":"a","lat":52.52,"lng":13.405}`)},
+		{Key: []byte("2"), Value: []byte(`{"id":"b","address":"Paris"}`)},
+		{Key: []byte("3"), Value: []byte(`not json`)},
+		{Key: []byte("4"), Value: []byte(`{"id":"d"}`)},
+	}}
+	out := &topic{}
+	s, err := NewStream(mock, in, out, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"1", "2", "3", "4"}
+	if !reflect.DeepEqual(in.committed, expected) {
+		t.Errorf("test for commits Failed - results not match\nGot:\n%v\nExpected:\n%v", in.committed, expected)
+	}
+	tests := []struct {
+		address string
+		err     bool
+	}{
+		{"Berlin", false},
+		{"Paris, France", false},
+		{"", true},
+		{"", true},
+	}
+	for i, tt := range tests {
+		var result Result
+		if err := json.Unmarshal(out.produced[i].Value, &result); err != nil {
+			t.Fatal(err)
+		}
+		var address string
+		if result.Response != nil && len(result.Response.Results) > 0 {
+			address = result.Response.Results[0].FormattedAddress
+		}
+		if string(out.produced[i].Key) != expected[i] || address != tt.address || (result.Error != "") != tt.err {
+			t.Errorf("test for record %v Failed - results not match\nGot:\n%+v\nExpected:\n%+v", i, result, tt)
+		}
+	}
+}
+
+func Test_StreamProduceFailure(t *testing.T) {
+	mock := geocodertest.NewMock()
+	in := &topic{records: []Message{
+		{Key: []byte("1"), Value: []byte(`{"lat":1,"lng":1}`)},
+		{Key: []byte("2"), Value: []byte(`{"lat":2,"lng":2}`)},
+		{Key: []byte("3"), Value: []byte(`{"lat":3,"lng":3}`)},
+	}}
+	s, err := NewStream(mock, in, &topic{failKey: "2"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run(context.TODO()); err == nil {
+		t.Errorf("test for produce failure Failed - no error")
+	}
+	// nothing after the failed record is committed
+	if expected := []string{"1"}; !reflect.DeepEqual(in.committed, expected) {
+		t.Errorf("test for produce failure Failed - results not match\nGot:\n%v\nExpected:\n%v", in.committed, expected)
+	}
+}
+
+func Test_StreamTransientFailure(t *testing.T) {
+	unavailable := &geocoder.HTTPError{StatusCode: 503}
+	mock := geocodertest.NewMock().
+		OnReverseGeocode(1, 1, ok("first"), nil).
+		OnReverseGeocode(2, 2, nil, geocoder.ErrZeroResults).
+		OnReverseGeocode(3, 3, nil, unavailable).
+		OnReverseGeocode(4, 4, ok("fourth"), nil)
+	in := &topic{records: []Message{
+		{Key: []byte("1"), Value: []byte(`{"lat":1,"lng":1}`)},
+		{Key: []byte("2"), Value: []byte(`{"lat":2,"lng":2}`)},
+		{Key: []byte("3"), Value: []byte(`{"lat":3,"lng":3}`)},
+		{Key: []byte("4"), Value: []byte(`{"lat":4,"lng":4}`)},
+	}}
+	out := &topic{}
+	s, err := NewStream(mock, in, out, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run(context.TODO()); !errors.Is(err, unavailable) {
+		t.Errorf("test for transient failure Failed - results not match\nGot:\n%v\nExpected:\n%v", err, unavailable)
+	}
+	// the permanent failure is produced, the record of the transient one is neither produced nor committed
+	if expected := []string{"1", "2"}; !reflect.DeepEqual(in.committed, expected) {
+		t.Errorf("test for transient failure Failed - results not match\nGot:\n%v\nExpected:\n%v", in.committed, expected)
+	}
+	if len(out.produced) != 2 {
+		t.Errorf("test for transient failure Failed - results not match\nGot:\n%v\nExpected:\n%v", len(out.produced), 2)
+	}
+}
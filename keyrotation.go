@@ -0,0 +1,99 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// SigningKeyRotation is emitted when the Geocoder switches to another signing key
+type SigningKeyRotation struct {
+	ClientID string
+	// Indexes of the keys in the rotation, 0 is the BusinessKey signing key
+	From int
+	To   int
+	// Response or error which rejected the signature of From
+	Reason string
+}
+
+// WithSigningKeys adds signing keys of the BusinessKey client ID to fail over to while Google
+// rotates keys. When Google rejects a signature, the request is repeated right away with the next
+// key, which is kept for subsequent requests, and onRotate is called if not nil.
+// Keys are tried in order after the BusinessKey signing key, each at most once per request
+func WithSigningKeys(onRotate func(SigningKeyRotation), keys ...string) Option {
+	return func(g *Geocoder) {
+		g.signingKeys = keys
+		g.onKeyRotation = onRotate
+	}
+}
+
+// keyRotation is a Signer switching between the signing keys of a client ID
+type keyRotation struct {
+	clientID string
+	signers  []Signer
+	onRotate func(SigningKeyRotation)
+
+	mu     sync.Mutex
+	active int
+}
+
+func newKeyRotation(bkey *BusinessKey, keys []string, onRotate func(SigningKeyRotation)) (*keyRotation, error) {
+	if bkey == nil {
+		return nil, errors.New("signing keys require a BusinessKey")
+	}
+	r := &keyRotation{clientID: bkey.ClientID, onRotate: onRotate}
+	for _, key := range append([]string{bkey.SigningKey}, keys...) {
+		signer, err := NewHMACSHA1Signer(key)
+		if err != nil {
+			return nil, err
+		}
+		r.signers = append(r.signers, signer)
+	}
+	return r, nil
+}
+
+// Sign implements Signer with the active key
+func (r *keyRotation) Sign(pathAndQuery string) (string, error) {
+	return r.signers[r.current()].Sign(pathAndQuery)
+}
+
+func (r *keyRotation) current() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.active
+}
+
+// rotate switches from the key at index from to the next one. A concurrent request which already
+// rotated away from it wins, the key is not switched twice
+func (r *keyRotation) rotate(ctx context.Context, logger *slog.Logger, from int, reason string) {
+	r.mu.Lock()
+	if r.active != from {
+		r.mu.Unlock()
+		return
+	}
+	r.active = (from + 1) % len(r.signers)
+	event := SigningKeyRotation{ClientID: r.clientID, From: from, To: r.active, Reason: reason}
+	r.mu.Unlock()
+
+	logger.WarnContext(ctx, "signature rejected, rotating signing key",
+		slog.Int("from", event.From), slog.Int("to", event.To), slog.String("reason", reason))
+	if r.onRotate != nil {
+		r.onRotate(event)
+	}
+}
+
+// signatureRejection returns the reason if Google rejected the request signature
+func signatureRejection(res *GoogleResponse, err error) (string, bool) {
+	var httpErr *HTTPError
+	switch {
+	case err == nil && res != nil && diagnoseCredentials(res) == CredentialsBadSignature:
+		return string(res.Status) + ": " + res.ErrorMessage, true
+	case errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden &&
+		strings.Contains(strings.ToLower(httpErr.Body), "signature"):
+		return httpErr.Error(), true
+	}
+	return "", false
+}
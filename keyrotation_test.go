@@ -0,0 +1,73 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SigningKeyRotation(t *testing.T) {
+	const (
+		oldKey = "b2xkLWtleQ=="
+		newKey = "bmV3LWtleQ=="
+	)
+	var requests int
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		requests++
+		if valid, _ := VerifySignature(newKey, targetURL); !valid {
+			body := "Unable to authenticate the request. Provided 'signature' is not valid for the provided client ID."
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+	})
+	var events []SigningKeyRotation
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: oldKey}, "https://localhost/maps/api/geocode/json", "en",
+		client, 100, time.Millisecond, nil,
+		WithSigningKeys(func(e SigningKeyRotation) { events = append(events, e) }, "bXlfdGVzdF9rZXk=", newKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+	if err != nil || res.Status != GRS_OK {
+		t.Fatalf("test for failover Failed - unexpected response %v, error %v", res, err)
+	}
+	if requests != 3 {
+		t.Errorf("test for failover requests Failed - results not match\nGot:\n%v\nExpected:\n%v", requests, 3)
+	}
+	var moves [][2]int
+	for _, e := range events {
+		moves = append(moves, [2]int{e.From, e.To})
+	}
+	if expected := [][2]int{{0, 1}, {1, 2}}; !reflect.DeepEqual(moves, expected) {
+		t.Errorf("test for events Failed - results not match\nGot:\n%v\nExpected:\n%v", moves, expected)
+	}
+
+	// the working key is kept
+	requests = 0
+	if _, err := g.ReverseGeocode(context.TODO(), 2, 2); err != nil || requests != 1 {
+		t.Errorf("test for active key Failed - %d requests, error %v", requests, err)
+	}
+}
+
+func Test_SigningKeyRotationExhausted(t *testing.T) {
+	var requests int
+	client := requesterFunc(func(string) (*http.Response, error) {
+		requests++
+		body := `{"status":"REQUEST_DENIED","error_message":"Invalid request. Invalid 'signature' parameter."}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: "b2xkLWtleQ=="}, "https://localhost", "en",
+		client, 100, time.Millisecond, nil, WithSigningKeys(nil, "bmV3LWtleQ=="))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+	if err != nil || res.Status != GRS_REQUEST_DENIED || requests != 2 {
+		t.Errorf("test for exhausted keys Failed - response %v, error %v after %d requests", res, err, requests)
+	}
+}
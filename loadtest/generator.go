@@ -0,0 +1,100 @@
+// Package loadtest generates realistic coordinate streams and drives a geocoder with them at a
+// target rate, to make capacity planning reproducible
+package loadtest
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/alvillain/geocoder"
+)
+
+// metersPerDegree is the length of a degree of latitude
+const metersPerDegree = 111320.0
+
+// Jitter moves a coordinate randomly around center using r as the only source of randomness
+type Jitter func(r *rand.Rand, center geocoder.Coordinate) geocoder.Coordinate
+
+// GaussianJitter offsets coordinates by normally distributed distances with the given
+// standard deviation in meters, in both directions
+func GaussianJitter(stddevMeters float64) Jitter {
+	return func(r *rand.Rand, center geocoder.Coordinate) geocoder.Coordinate {
+		return offset(center, r.NormFloat64()*stddevMeters, r.NormFloat64()*stddevMeters)
+	}
+}
+
+// UniformJitter spreads coordinates uniformly over a disk with the given radius in meters
+func UniformJitter(radiusMeters float64) Jitter {
+	return func(r *rand.Rand, center geocoder.Coordinate) geocoder.Coordinate {
+		distance := radiusMeters * math.Sqrt(r.Float64())
+		angle := 2 * math.Pi * r.Float64()
+		return offset(center, distance*math.Cos(angle), distance*math.Sin(angle))
+	}
+}
+
+// offset moves c by north and east meters, clamping latitude and wrapping longitude
+func offset(c geocoder.Coordinate, north, east float64) geocoder.Coordinate {
+	lat := c.Lat + north/metersPerDegree
+	lng := c.Lng + east/(metersPerDegree*math.Max(math.Cos(c.Lat*math.Pi/180), 1e-6))
+	lat = math.Max(-90, math.Min(90, lat))
+	lng = math.Mod(lng+540, 360) - 180
+	return geocoder.Coordinate{Lat: lat, Lng: lng}
+}
+
+// Hotspot is an area requests cluster around, e.g. a city center
+type Hotspot struct {
+	Center geocoder.Coordinate
+	// Relative share of requests, hotspots with zero weight are never picked
+	Weight float64
+	// Spread of coordinates around Center, the Generator default if nil
+	Jitter Jitter
+}
+
+// Generator produces a reproducible stream of coordinates clustered around hotspots.
+// It is not safe for concurrent use
+type Generator struct {
+	rand       *rand.Rand
+	hotspots   []Hotspot
+	cumulative []float64
+	jitter     Jitter
+}
+
+// NewGenerator creates new instance of Generator. The same seed yields the same stream.
+// jitter is used for hotspots without one of their own
+func NewGenerator(seed int64, jitter Jitter, hotspots ...Hotspot) (*Generator, error) {
+	if len(hotspots) == 0 {
+		return nil, errors.New("no hotspots")
+	}
+	if jitter == nil {
+		return nil, errors.New("empty jitter")
+	}
+	g := &Generator{rand: rand.New(rand.NewSource(seed)), hotspots: hotspots, jitter: jitter}
+	total := 0.0
+	for _, h := range hotspots {
+		if h.Weight < 0 {
+			return nil, errors.New("negative hotspot weight")
+		}
+		total += h.Weight
+		g.cumulative = append(g.cumulative, total)
+	}
+	if total == 0 {
+		return nil, errors.New("all hotspot weights are zero")
+	}
+	return g, nil
+}
+
+// Next returns the next coordinate of the stream
+func (g *Generator) Next() geocoder.Coordinate {
+	pick := g.rand.Float64() * g.cumulative[len(g.cumulative)-1]
+	i := 0
+	for i < len(g.cumulative)-1 && pick >= g.cumulative[i] {
+		i++
+	}
+	h := g.hotspots[i]
+	jitter := h.Jitter
+	if jitter == nil {
+		jitter = g.jitter
+	}
+	return jitter(g.rand, h.Center)
+}
@@ -0,0 +1,90 @@
+package loadtest
+
+import (
+	"context"
+	"math"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+func Test_Generator(t *testing.T) {
+	berlin := geocoder.Coordinate{Lat: 52.52, Lng: 13.40}
+	tokyo := geocoder.Coordinate{Lat: 35.68, Lng: 139.69}
+	newGenerator := func() *Generator {
+		gen, err := NewGenerator(42, GaussianJitter(500),
+			Hotspot{Center: berlin, Weight: 3},
+			Hotspot{Center: tokyo, Weight: 1, Jitter: UniformJitter(1000)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gen
+	}
+
+	a, b := newGenerator(), newGenerator()
+	nearBerlin := 0
+	for i := 0; i < 1000; i++ {
+		p, q := a.Next(), b.Next()
+		if !reflect.DeepEqual(p, q) {
+			t.Fatalf("test for reproducibility Failed - results not match\nGot:\n%v\nExpected:\n%v", q, p)
+		}
+		switch {
+		case math.Abs(p.Lat-berlin.Lat) < 0.05 && math.Abs(p.Lng-berlin.Lng) < 0.1:
+			nearBerlin++
+		case math.Abs(p.Lat-tokyo.Lat) < 0.01 && math.Abs(p.Lng-tokyo.Lng) < 0.012:
+		default:
+			t.Fatalf("test for clustering Failed - %v is far from hotspots", p)
+		}
+	}
+	if nearBerlin < 700 || nearBerlin > 800 {
+		t.Errorf("test for weights Failed - results not match\nGot:\n%v\nExpected:\n%v", nearBerlin, "about 750")
+	}
+}
+
+func Test_Offset(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        geocoder.Coordinate
+		north    float64
+		east     float64
+		expected geocoder.Coordinate
+	}{
+		{"north", geocoder.Coordinate{}, metersPerDegree, 0, geocoder.Coordinate{Lat: 1}},
+		{"antimeridian", geocoder.Coordinate{Lng: 179.5}, 0, metersPerDegree, geocoder.Coordinate{Lng: -179.5}},
+		{"pole", geocoder.Coordinate{Lat: 89.5}, metersPerDegree, 0, geocoder.Coordinate{Lat: 90}},
+	}
+	for _, tt := range tests {
+		got := offset(tt.c, tt.north, tt.east)
+		if math.Abs(got.Lat-tt.expected.Lat) > 1e-9 || math.Abs(got.Lng-tt.expected.Lng) > 1e-9 {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func Test_Run(t *testing.T) {
+	srv := httptest.NewServer(NewMockHandler(5 * time.Millisecond))
+	defer srv.Close()
+	g, err := geocoder.NewGeocoder(&geocoder.BusinessKey{ClientID: "client", SigningKey: "bXlfdGVzdF9rZXk="},
+		srv.URL, "en", srv.Client(), 1000, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := NewGenerator(1, GaussianJitter(100), Hotspot{Center: geocoder.Coordinate{Lat: 40.71, Lng: -74.0}, Weight: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(context.TODO(), g, gen, Config{RPS: 100, Duration: 300 * time.Millisecond, Concurrency: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Sent < 20 || report.Sent > 31 || report.Failed != 0 || report.Statuses[geocoder.GRS_OK] != report.Sent {
+		t.Errorf("test for run Failed - unexpected report %v", report)
+	}
+	if report.P50 < 5*time.Millisecond || report.Max < report.P99 {
+		t.Errorf("test for latency Failed - unexpected report %v", report)
+	}
+}
@@ -0,0 +1,25 @@
+package loadtest
+
+import (
+	"net/http"
+	"time"
+)
+
+// mockResponse is a typical street address response
+const mockResponse = `{"status":"OK","results":[{"formatted_address":"1 Main St, Springfield, USA","place_id":"mock",` +
+	`"types":["street_address"],"geometry":{"location":{"lat":0,"lng":0},"location_type":"ROOFTOP"},` +
+	`"address_components":[{"long_name":"United States","short_name":"US","types":["country","political"]}]}]}`
+
+// NewMockHandler returns a handler answering every request with a street address after latency,
+// serve it with httptest.NewServer and point the Geocoder base URL at it
+func NewMockHandler(latency time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(mockResponse)) //nolint:errcheck
+	})
+}
@@ -0,0 +1,134 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+// Config of a load test run
+type Config struct {
+	// Target requests per second. Requests are started on schedule regardless of the latency of
+	// previous ones, like independent clients would
+	RPS float64
+	// Length of the run
+	Duration time.Duration
+	// Maximum requests in flight. A scheduled request is dropped if all of them are busy
+	Concurrency int
+}
+
+// Report summarizes a load test run
+type Report struct {
+	// Requests started
+	Sent int
+	// Requests returned without error, by response status
+	Statuses map[geocoder.GoogleResponseStatus]int
+	// Requests failed with an error
+	Failed int
+	// Requests not started because Concurrency requests were in flight
+	Dropped int
+	Elapsed time.Duration
+	// Latency percentiles of started requests
+	P50, P95, P99, Max time.Duration
+}
+
+// AchievedRPS returns the rate requests were actually started at
+func (r *Report) AchievedRPS() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Sent) / r.Elapsed.Seconds()
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("sent %d (%.1f rps), failed %d, dropped %d, statuses %v, latency p50 %v p95 %v p99 %v max %v",
+		r.Sent, r.AchievedRPS(), r.Failed, r.Dropped, r.Statuses, r.P50, r.P95, r.P99, r.Max)
+}
+
+// Run reverse geocodes coordinates of gen with rg at the configured rate until the duration
+// elapses or ctx is done, then waits for requests in flight
+func Run(ctx context.Context, rg geocoder.ReverseGeocoder, gen *Generator, cfg Config) (*Report, error) {
+	if cfg.RPS <= 0 {
+		return nil, errors.New("RPS must be a positive number")
+	}
+	if cfg.Concurrency <= 0 {
+		return nil, errors.New("concurrency must be a positive number")
+	}
+
+	report := &Report{Statuses: make(map[geocoder.GoogleResponseStatus]int)}
+	var mu sync.Mutex
+	var latencies []time.Duration
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, cfg.Concurrency)
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.RPS))
+	defer ticker.Stop()
+	timer := time.NewTimer(cfg.Duration)
+	defer timer.Stop()
+
+	start := time.Now()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+			break loop
+		case <-ticker.C:
+		}
+		point := gen.Next()
+		select {
+		case slots <- struct{}{}:
+		default:
+			report.Dropped++
+			continue
+		}
+		report.Sent++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			t := time.Now()
+			res, err := rg.ReverseGeocode(ctx, point.Lat, point.Lng)
+			latency := time.Since(t)
+
+			mu.Lock()
+			defer mu.Unlock()
+			latencies = append(latencies, latency)
+			if err != nil {
+				report.Failed++
+				return
+			}
+			report.Statuses[res.Status]++
+		}()
+	}
+	report.Elapsed = time.Since(start)
+	wg.Wait()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report.P50 = percentile(latencies, 0.50)
+	report.P95 = percentile(latencies, 0.95)
+	report.P99 = percentile(latencies, 0.99)
+	report.Max = percentile(latencies, 1)
+	return report, nil
+}
+
+// percentile returns the p-th percentile of sorted latencies using the nearest rank
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
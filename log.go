@@ -0,0 +1,37 @@
+package geocoder
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+)
+
+// redacted replaces secrets in logged values
+const redacted = "REDACTED"
+
+// secretParams are query parameters never logged as is
+var secretParams = []string{"signature", "client", "key"}
+
+// discardHandler drops all records, it is used when no logger is configured
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// redactURL returns targetURL with signature, client ID and API key masked
+func redactURL(targetURL string) string {
+	ur, err := url.Parse(targetURL)
+	if err != nil {
+		return redacted
+	}
+	query := ur.Query()
+	for _, param := range secretParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	ur.RawQuery = query.Encode()
+	return ur.String()
+}
@@ -0,0 +1,37 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_redactURL(t *testing.T) {
+	got := redactURL("https://maps.googleapis.com/maps/api/geocode/json?client=my_test_client&latlng=1%2C2&signature=abc%3D")
+	expected := "https://maps.googleapis.com/maps/api/geocode/json?client=REDACTED&latlng=1%2C2&signature=REDACTED"
+	if got != expected {
+		t.Errorf("test for redactURL Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
+
+func Test_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1000, time.Second, nil, WithLogger(logger))
+
+	if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "geocoding request finished") || !strings.Contains(out, "status=OK") {
+		t.Errorf("test for logger Failed - request was not logged:\n%v", out)
+	}
+	if strings.Contains(out, "my_test_client") {
+		t.Errorf("test for logger Failed - client ID leaked:\n%v", out)
+	}
+}
@@ -0,0 +1,93 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// ErrAPIKeyRequired is returned by googleapis.com REST APIs, like ValidateAddress and Geolocate,
+// which don't accept a BusinessKey, if the Geocoder has no API key. Nothing is sent
+var ErrAPIKeyRequired = errors.New("API key required")
+
+// mapsAPI is a Maps Platform web service other than geocoding. Its requests share signing,
+// rate limiting, quota, retries, the circuit breaker and observers with geocoding requests,
+// responses are not cached and are decoded by the caller from GoogleResponse.Raw
+type mapsAPI struct {
+	// Path segment of the service, e.g. timezone in /maps/api/timezone/json
+	path string
+	// API name in usage reports
+	usage string
+	// Service is a googleapis.com REST API at url: errors are HTTP statuses only, successful
+	// responses have no status and the query carries nothing but the credentials
+	rest bool
+	url  string
+}
+
+// apiEndpoint returns the URL of api on the host of endpoint
+func apiEndpoint(endpoint string, api *mapsAPI) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	u.Path = "/maps/api/" + api.path + "/json"
+	u.RawQuery = ""
+	return u.String()
+}
+
+// apiStatus is the status envelope of the Maps web services. Some of them use
+// errorMessage instead of error_message
+type apiStatus struct {
+	Status            GoogleResponseStatus `json:"status"`
+	ErrorMessage      string               `json:"error_message"`
+	ErrorMessageCamel string               `json:"errorMessage"`
+}
+
+// decodeAPI decodes the status of a Maps web service response, the body is kept in Raw.
+// Responses of REST APIs reaching the decoder are successful
+func decodeAPI(raw []byte, rest bool) (*GoogleResponse, error) {
+	var status apiStatus
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&status); err != nil {
+		return nil, newDecodeError(raw, err)
+	}
+	if status.ErrorMessage == "" {
+		status.ErrorMessage = status.ErrorMessageCamel
+	}
+	if rest {
+		status.Status = GRS_OK
+	}
+	return &GoogleResponse{Status: status.Status, ErrorMessage: status.ErrorMessage, Raw: raw}, nil
+}
+
+// callAPI sends req to a Maps web service and decodes the response into out. The returned
+// GoogleResponse carries the status, with WithStatusErrors statuses other than OK fail with StatusError
+func (g *Geocoder) callAPI(ctx context.Context, req *request, out any) (*GoogleResponse, error) {
+	if req.api.rest && !g.hasAPIKey() {
+		return nil, ErrAPIKeyRequired
+	}
+	res, err := g.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(res.Raw, out); err != nil {
+		return nil, newDecodeError(res.Raw, err)
+	}
+	return g.checkStatus(res, nil)
+}
+
+// hasAPIKey reports whether the Geocoder or a credential of its pool has an API key
+func (g *Geocoder) hasAPIKey() bool {
+	if g.builder.APIKey != "" {
+		return true
+	}
+	if g.pool != nil {
+		for _, member := range g.pool.members {
+			if member.builder.APIKey != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var apiNearbySearch = &mapsAPI{path: "place/nearbysearch", usage: UsageAPIPlaces}
+
+const (
+	// Pages of 20 results Google returns at most for a search
+	maxSearchPages = 3
+	// Attempts to fetch a page whose token is not valid yet
+	pageTokenAttempts = 3
+)
+
+// pageTokenDelay is the time a next_page_token takes to become valid
+var pageTokenDelay = 2 * time.Second
+
+// NearbySearchOptions narrows down nearby search results, all fields are optional
+type NearbySearchOptions struct {
+	// Term matched against names, types and addresses, e.g. pizza
+	Keyword string
+	// Place type, e.g. restaurant
+	Type string
+	// Only places open at the time of the request
+	OpenNow bool
+	// Price levels from 0 to 4, ignored if negative or both zero
+	MinPrice, MaxPrice int
+	// Order by distance instead of prominence. The radius is not sent then,
+	// Keyword or Type is required
+	RankByDistance bool
+	// Number of pages of up to 20 results to fetch, 1 to 3, all 3 if zero
+	MaxPages int
+	// Output language overriding the Geocoder one
+	Language string
+}
+
+// PlacesSearchResponse is the response of a places search, results of all fetched pages combined
+type PlacesSearchResponse struct {
+	Results          []*Place             `json:"results"`
+	HTMLAttributions []string             `json:"html_attributions"`
+	Status           GoogleResponseStatus `json:"status"`
+	ErrorMessage     string               `json:"error_message,omitempty"`
+	// Token of the page following the fetched ones, empty if there are no more results
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// NearbySearch returns places within radius meters of lat, lng. Following pages are fetched
+// with next_page_token, waiting the two seconds until a token becomes valid. Every page is a
+// request limited by the EP_PLACES limiter
+func (g *Geocoder) NearbySearch(ctx context.Context, lat, lng float64, radius int, opts NearbySearchOptions) (*PlacesSearchResponse, error) {
+	if err := validateCoordinates(lat, lng); err != nil {
+		return nil, err
+	}
+	params := url.Values{"location": {formatLatLng(lat, lng, g.precision)}}
+	switch {
+	case opts.RankByDistance && opts.Keyword == "" && opts.Type == "":
+		return nil, errors.New("ranking by distance requires keyword or type")
+	case opts.RankByDistance:
+		params.Set("rankby", "distance")
+	case radius <= 0:
+		return nil, errors.New("radius must be a positive number")
+	default:
+		params.Set("radius", strconv.Itoa(radius))
+	}
+	if opts.Keyword != "" {
+		params.Set("keyword", opts.Keyword)
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.OpenNow {
+		params.Set("opennow", "true")
+	}
+	if opts.MinPrice > 0 || opts.MaxPrice > 0 {
+		params.Set("minprice", strconv.Itoa(max(opts.MinPrice, 0)))
+		params.Set("maxprice", strconv.Itoa(max(opts.MaxPrice, 0)))
+	}
+	if opts.Language != "" {
+		params.Set("language", opts.Language)
+	}
+	return g.searchPlaces(ctx, &request{
+		op:       OP_NEARBY_SEARCH,
+		endpoint: EP_PLACES,
+		api:      apiNearbySearch,
+		params:   params,
+	}, opts.MaxPages)
+}
+
+// searchPlaces sends the search req and fetches up to maxPages pages of its results
+func (g *Geocoder) searchPlaces(ctx context.Context, req *request, maxPages int) (*PlacesSearchResponse, error) {
+	if maxPages <= 0 || maxPages > maxSearchPages {
+		maxPages = maxSearchPages
+	}
+	combined := &PlacesSearchResponse{}
+	if _, err := g.callAPI(ctx, req, combined); err != nil {
+		return nil, err
+	}
+	for page := 2; page <= maxPages && combined.Status == GRS_OK && combined.NextPageToken != ""; page++ {
+		res, err := g.nextPage(ctx, req, combined.NextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		combined.Results = append(combined.Results, res.Results...)
+		combined.HTMLAttributions = append(combined.HTMLAttributions, res.HTMLAttributions...)
+		combined.Status, combined.ErrorMessage, combined.NextPageToken = res.Status, res.ErrorMessage, res.NextPageToken
+	}
+	return combined, nil
+}
+
+// nextPage fetches the page of token once it becomes valid. Google answers INVALID_REQUEST
+// to tokens used too early, they are retried after pageTokenDelay
+func (g *Geocoder) nextPage(ctx context.Context, search *request, token string) (*PlacesSearchResponse, error) {
+	req := &request{op: search.op, endpoint: search.endpoint, api: search.api, params: url.Values{"pagetoken": {token}}}
+	for attempt := 1; ; attempt++ {
+		if err := g.sleep(ctx, pageTokenDelay); err != nil {
+			return nil, err
+		}
+		res := &PlacesSearchResponse{}
+		_, err := g.callAPI(ctx, req, res)
+		tooEarly := errors.Is(err, ErrInvalidRequest) || err == nil && res.Status == GRS_INVALID_REQUEST
+		if !tooEarly || attempt >= pageTokenAttempts {
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// placesPagesClient serves pages of one place each, chained by page tokens. The token of
+// the second page is rejected once as used too early
+func placesPagesClient(requests *[]url.Values, pages int) HttpRequester {
+	rejected := false
+	return requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		query := u.Query()
+		*requests = append(*requests, query)
+		page := 1
+		if token := query.Get("pagetoken"); token != "" {
+			fmt.Sscanf(token, "page%d", &page)
+		}
+		body := fmt.Sprintf(`{"status":"OK","html_attributions":[],"results":[{"place_id":"place%d"}]`, page)
+		if page < pages {
+			body += fmt.Sprintf(`,"next_page_token":"page%d"`, page+1)
+		}
+		body += "}"
+		if page == 2 && !rejected {
+			rejected = true
+			body = `{"status":"INVALID_REQUEST","html_attributions":[],"results":[]}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func Test_NearbySearch(t *testing.T) {
+	defer func(d time.Duration) { pageTokenDelay = d }(pageTokenDelay)
+	pageTokenDelay = time.Millisecond
+
+	tests := []struct {
+		name      string
+		pages     int
+		opts      NearbySearchOptions
+		expected  []string
+		requests  int
+		nextToken string
+	}{
+		{"single page", 1, NearbySearchOptions{}, []string{"place1"}, 1, ""},
+		{"all pages", 4, NearbySearchOptions{}, []string{"place1", "place2", "place3"}, 4, "page4"},
+		{"max pages", 3, NearbySearchOptions{MaxPages: 1}, []string{"place1"}, 1, "page2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []url.Values
+			g, err := NewGeocoder(nil, "https://localhost", "en", placesPagesClient(&requests, tt.pages), 1000,
+				time.Millisecond, nil, WithAPIKey("key"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.NearbySearch(context.TODO(), 52.52, 13.405, 500, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range res.Results {
+				got = append(got, p.PlaceID)
+			}
+			if !reflect.DeepEqual(got, tt.expected) || len(requests) != tt.requests || res.NextPageToken != tt.nextToken {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v after %d requests, next %q\nExpected:\n%v after %d requests, next %q",
+					tt.name, got, len(requests), res.NextPageToken, tt.expected, tt.requests, tt.nextToken)
+			}
+			if requests[0].Get("radius") != "500" || requests[0].Get("location") != "52.52000000,13.40500000" {
+				t.Errorf("test for %v Failed - unexpected query %v", tt.name, requests[0])
+			}
+		})
+	}
+}
+
+func Test_NearbySearchRankByDistance(t *testing.T) {
+	var requests []url.Values
+	g, err := NewGeocoder(nil, "https://localhost", "en", placesPagesClient(&requests, 1), 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NearbySearch(context.TODO(), 52.52, 13.405, 0, NearbySearchOptions{RankByDistance: true}); err == nil {
+		t.Errorf("test for missing keyword Failed - expected error")
+	}
+	if _, err := g.NearbySearch(context.TODO(), 52.52, 13.405, 0, NearbySearchOptions{RankByDistance: true, Type: "cafe"}); err != nil {
+		t.Fatal(err)
+	}
+	if q := requests[0]; q.Get("rankby") != "distance" || q.Has("radius") || q.Get("type") != "cafe" {
+		t.Errorf("test for rank by distance Failed - unexpected query %v", q)
+	}
+}
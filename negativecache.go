@@ -0,0 +1,56 @@
+package geocoder
+
+import "time"
+
+// WithNegativeCache caches unsuccessful responses, e.g. ZERO_RESULTS of ocean coordinates or
+// INVALID_REQUEST of garbage input, for the TTL of their status, up to maxEntries per status.
+// It works with or without WithCache. OK and OVER_QUERY_LIMIT statuses are never cached by it
+func WithNegativeCache(maxEntries int, ttls map[GoogleResponseStatus]time.Duration) Option {
+	return func(g *Geocoder) {
+		n := &negativeCache{caches: make(map[GoogleResponseStatus]*MemoryCache)}
+		for status, ttl := range ttls {
+			if status == GRS_OK || status == GRS_OVER_QUERY_LIMIT || ttl <= 0 {
+				continue
+			}
+			n.caches[status] = NewMemoryCache(maxEntries, ttl)
+		}
+		if len(n.caches) > 0 {
+			g.negative = n
+		}
+	}
+}
+
+// negativeCache keeps unsuccessful responses in a MemoryCache per status
+type negativeCache struct {
+	caches map[GoogleResponseStatus]*MemoryCache
+}
+
+// Get returns the cached response for key
+func (n *negativeCache) Get(key string) (*GoogleResponse, bool) {
+	for _, c := range n.caches {
+		if res, ok := c.Get(key); ok {
+			return res, true
+		}
+	}
+	return nil, false
+}
+
+// Set caches res if its status has a TTL
+func (n *negativeCache) Set(key string, res *GoogleResponse) {
+	if c, ok := n.caches[res.Status]; ok {
+		c.Set(key, res)
+	}
+}
+
+// cached returns the response for key from the cache or the negative cache
+func (g *Geocoder) cached(key string) (*GoogleResponse, bool) {
+	if g.cache != nil {
+		if res, ok := g.cache.Get(key); ok {
+			return res, true
+		}
+	}
+	if g.negative != nil {
+		return g.negative.Get(key)
+	}
+	return nil, false
+}
@@ -0,0 +1,55 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_NegativeCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   GoogleResponseStatus
+		wait     time.Duration
+		expected int
+	}{
+		{"zero results cached", GRS_ZERO_RESULTS, 0, 1},
+		{"zero results expired", GRS_ZERO_RESULTS, 30 * time.Millisecond, 2},
+		{"invalid request cached", GRS_INVALID_REQUEST, 0, 1},
+		{"unknown error not cached", GRS_UNKNOWN_ERROR, 0, 2},
+		{"over query limit never cached", GRS_OVER_QUERY_LIMIT, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := requesterFunc(func(string) (*http.Response, error) {
+				requests++
+				body := `{"status":"` + string(tt.status) + `"}`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+			})
+			g, err := NewGeocoder(nil, "https://localhost", "en", client, 1000, 0, nil, WithAPIKey("key"),
+				WithNegativeCache(10, map[GoogleResponseStatus]time.Duration{
+					GRS_ZERO_RESULTS:     20 * time.Millisecond,
+					GRS_INVALID_REQUEST:  time.Hour,
+					GRS_OVER_QUERY_LIMIT: time.Hour,
+				}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 2; i++ {
+				res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+				if err != nil || res.Status != tt.status {
+					t.Fatalf("test for %v Failed - response %v, error %v", tt.name, res, err)
+				}
+				time.Sleep(tt.wait)
+			}
+			if requests != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, requests, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package geocoder
+
+import "log/slog"
+
+// Option configures optional Geocoder behavior
+type Option func(*Geocoder)
+
+// WithUsageTracker makes the Geocoder count every request sent to Google in u
+func WithUsageTracker(u *UsageTracker) Option {
+	return func(g *Geocoder) {
+		g.usage = u
+	}
+}
+
+// WithSwapDetection makes the Geocoder reject coordinates which look swapped
+// with ErrSwappedCoordinates before a request is spent on them
+func WithSwapDetection() Option {
+	return func(g *Geocoder) {
+		g.detectSwaps = true
+	}
+}
+
+// WithLongitudeNormalization makes the Geocoder wrap longitudes into [-180, 180) instead of
+// rejecting those out of range, e.g. 190 becomes -170
+func WithLongitudeNormalization() Option {
+	return func(g *Geocoder) {
+		g.normalizeLng = true
+	}
+}
+
+// WithLogger makes the Geocoder log requests and cooldowns to logger.
+// Signatures, client IDs and API keys are redacted
+func WithLogger(logger *slog.Logger) Option {
+	return func(g *Geocoder) {
+		if logger != nil {
+			g.logger = logger
+		}
+	}
+}
+
+// WithBurst allows up to n requests to be sent at once after an idle period, the default is 1.
+// The average rate is still bounded by the requests per second: tokens are refilled at that
+// rate, so a full burst is available again after n/rps seconds without requests
+func WithBurst(n int) Option {
+	return func(g *Geocoder) {
+		if n > 0 {
+			g.burst = n
+		}
+	}
+}
+
+// WithNonBlockingCooldown makes ReverseGeocode and Geocode return a *QuotaError right away
+// when Google responds with OVER_QUERY_LIMIT, instead of waiting out the cooldown before
+// returning the response. The cooldown still holds back subsequent requests.
+// Without this option the blocking behavior is kept for compatibility
+func WithNonBlockingCooldown() Option {
+	return func(g *Geocoder) {
+		g.nonBlockingCooldown = true
+	}
+}
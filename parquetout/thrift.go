@@ -0,0 +1,109 @@
+package parquetout
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol types
+const (
+	tI32    = 5
+	tI64    = 6
+	tBinary = 8
+	tList   = 9
+	tStruct = 12
+)
+
+// thriftWriter encodes the Parquet metadata structs with the Thrift compact protocol
+type thriftWriter struct {
+	buf bytes.Buffer
+	// Last field ID of every open struct, innermost last
+	last []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{last: []int16{0}}
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.last[len(w.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(uint64(zigzag(int64(id))))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, tI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, tI64)
+	w.varint(zigzag(v))
+}
+
+func (w *thriftWriter) binary(v string) {
+	w.varint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) str(id int16, v string) {
+	w.field(id, tBinary)
+	w.binary(v)
+}
+
+// list writes the header of a list field of n elements of type typ
+func (w *thriftWriter) list(id int16, typ byte, n int) {
+	w.field(id, tList)
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | typ)
+		return
+	}
+	w.buf.WriteByte(0xf0 | typ)
+	w.varint(uint64(n))
+}
+
+func (w *thriftWriter) i32List(id int16, values ...int32) {
+	w.list(id, tI32, len(values))
+	for _, v := range values {
+		w.varint(zigzag(int64(v)))
+	}
+}
+
+func (w *thriftWriter) strList(id int16, values ...string) {
+	w.list(id, tBinary, len(values))
+	for _, v := range values {
+		w.binary(v)
+	}
+}
+
+// beginStruct opens a struct field, or a list element if id is 0
+func (w *thriftWriter) beginStruct(id int16) {
+	if id != 0 {
+		w.field(id, tStruct)
+	}
+	w.last = append(w.last, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.last = w.last[:len(w.last)-1]
+}
+
+// bytes ends the top level struct and returns the encoding
+func (w *thriftWriter) bytes() []byte {
+	w.buf.WriteByte(0)
+	return w.buf.Bytes()
+}
@@ -0,0 +1,349 @@
+// Package parquetout writes batch geocoding results as a Parquet file for data lake ingestion.
+// Files have a single flat schema of optional columns:
+//
+//	id                 string   BatchItem.ID
+//	input              string   the address, or "lat,lng" of reverse geocoded items
+//	lat, lng           double   location of the best result
+//	formatted_address  string   formatted address of the best result
+//	country            string   ISO 3166-1 alpha-2 code of the best result
+//	confidence         double   geocoder.Confidence of the best result
+//	status             string   Google status of the response
+//	error              string   why the item failed
+//
+// Pages are PLAIN encoded and uncompressed, so the package has no dependencies
+package parquetout
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/alvillain/geocoder"
+)
+
+// defaultRowGroupSize is the number of rows per row group if Options.RowGroupSize is zero
+const defaultRowGroupSize = 10000
+
+// magic starts and ends a Parquet file
+const magic = "PAR1"
+
+// createdBy is the application name in the file metadata
+const createdBy = "github.com/alvillain/geocoder/parquetout"
+
+// Parquet enum values of the Thrift metadata
+const (
+	typeDouble    = 5
+	typeByteArray = 6
+	repOptional   = 1
+	convertedUTF8 = 0
+	encodingPlain = 0
+	encodingRLE   = 3
+	codecNone     = 0
+	pageTypeData  = 0
+	fileVersion   = 1
+)
+
+// row is a result, nil values are nulls
+type row struct {
+	strings [stringColumns]*string
+	doubles [doubleColumns]*float64
+}
+
+// Column indexes in row
+const (
+	colID = iota
+	colInput
+	colFormattedAddress
+	colCountry
+	colStatus
+	colError
+	stringColumns
+)
+
+const (
+	colLat = iota
+	colLng
+	colConfidence
+	doubleColumns
+)
+
+// column is a leaf of the schema, index is into row.strings or row.doubles by type
+type column struct {
+	name  string
+	typ   int32
+	index int
+}
+
+// columns in file order
+var columns = []column{
+	{"id", typeByteArray, colID},
+	{"input", typeByteArray, colInput},
+	{"lat", typeDouble, colLat},
+	{"lng", typeDouble, colLng},
+	{"formatted_address", typeByteArray, colFormattedAddress},
+	{"country", typeByteArray, colCountry},
+	{"confidence", typeDouble, colConfidence},
+	{"status", typeByteArray, colStatus},
+	{"error", typeByteArray, colError},
+}
+
+// Options of Writer
+type Options struct {
+	// Rows buffered in memory and written together, 10000 if zero
+	RowGroupSize int
+	// Picks the best result of every response, see geocoder.GoogleResponse.BestMatch
+	BestMatch geocoder.BestMatchOptions
+}
+
+// columnChunk is the metadata of a written column chunk
+type columnChunk struct {
+	offset int64
+	size   int64
+	values int64
+}
+
+// rowGroup is the metadata of a written row group
+type rowGroup struct {
+	columns []columnChunk
+	rows    int64
+}
+
+// Writer writes batch results as a Parquet file. Its Write method fits as the emit function of
+// geocoder.BatchProcessor.Process. It is not safe for concurrent use
+type Writer struct {
+	w      io.Writer
+	opts   Options
+	offset int64
+	rows   []row
+	groups []rowGroup
+	closed bool
+}
+
+// NewWriter creates new instance of Writer writing to w. The file is complete once Close returns
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	if opts.RowGroupSize < 0 {
+		return nil, errors.New("row group size must not be negative")
+	}
+	if opts.RowGroupSize == 0 {
+		opts.RowGroupSize = defaultRowGroupSize
+	}
+	pw := &Writer{w: w, opts: opts}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write adds the row of a batch result
+func (w *Writer) Write(r geocoder.BatchResult) error {
+	if w.closed {
+		return errors.New("parquet writer is closed")
+	}
+	w.rows = append(w.rows, newRow(r, w.opts.BestMatch))
+	if len(w.rows) >= w.opts.RowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close writes the buffered rows and the file footer. It doesn't close the underlying writer
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	w.closed = true
+	footer := w.footer()
+	if err := w.write(footer); err != nil {
+		return err
+	}
+	return w.write(binary.LittleEndian.AppendUint32([]byte(nil), uint32(len(footer))), []byte(magic))
+}
+
+func newRow(r geocoder.BatchResult, opts geocoder.BestMatchOptions) row {
+	var rw row
+	str := func(col int, v string) {
+		if v != "" {
+			rw.strings[col] = &v
+		}
+	}
+	dbl := func(col int, v float64) {
+		rw.doubles[col] = &v
+	}
+
+	str(colID, r.Item.ID)
+	if r.Item.Address != "" {
+		str(colInput, r.Item.Address)
+	} else {
+		str(colInput, strconv.FormatFloat(r.Item.Lat, 'f', -1, 64)+","+strconv.FormatFloat(r.Item.Lng, 'f', -1, 64))
+	}
+	if r.Err != nil {
+		str(colError, r.Err.Error())
+	}
+	if r.Response == nil {
+		return rw
+	}
+	str(colStatus, string(r.Response.Status))
+	best := r.Response.BestMatch(opts)
+	if best == nil {
+		return rw
+	}
+	dbl(colLat, best.Geometry.Location.Lat)
+	dbl(colLng, best.Geometry.Location.Lng)
+	str(colFormattedAddress, best.FormattedAddress)
+	if country, ok := geocoder.GetComponent[geocoder.Country](best); ok {
+		str(colCountry, country.ShortName)
+	}
+	dbl(colConfidence, geocoder.Confidence(best))
+	return rw
+}
+
+// flush writes the buffered rows as a row group
+func (w *Writer) flush() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	group := rowGroup{rows: int64(len(w.rows))}
+	for _, col := range columns {
+		data := pageData(col, w.rows)
+		header := pageHeader(len(data), len(w.rows))
+		chunk := columnChunk{offset: w.offset, size: int64(len(header) + len(data)), values: int64(len(w.rows))}
+		if err := w.write(header, data); err != nil {
+			return err
+		}
+		group.columns = append(group.columns, chunk)
+	}
+	w.groups = append(w.groups, group)
+	w.rows = w.rows[:0]
+	return nil
+}
+
+func (w *Writer) write(chunks ...[]byte) error {
+	for _, c := range chunks {
+		n, err := w.w.Write(c)
+		w.offset += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageData returns a data page of col: the definition levels followed by the non-null values
+func pageData(col column, rows []row) []byte {
+	levels := make([]bool, len(rows))
+	var values bytes.Buffer
+	for i, r := range rows {
+		if col.typ == typeDouble {
+			if v := r.doubles[col.index]; v != nil {
+				levels[i] = true
+				values.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(*v)))
+			}
+			continue
+		}
+		if v := r.strings[col.index]; v != nil {
+			levels[i] = true
+			values.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(*v))))
+			values.WriteString(*v)
+		}
+	}
+	encoded := encodeLevels(levels)
+	data := binary.LittleEndian.AppendUint32(nil, uint32(len(encoded)))
+	data = append(data, encoded...)
+	return append(data, values.Bytes()...)
+}
+
+// encodeLevels encodes definition levels of bit width 1 as RLE runs of the RLE/bit-packing hybrid
+func encodeLevels(levels []bool) []byte {
+	var out []byte
+	for start := 0; start < len(levels); {
+		end := start + 1
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		out = binary.AppendUvarint(out, uint64(end-start)<<1)
+		if levels[start] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		start = end
+	}
+	return out
+}
+
+// pageHeader returns the PageHeader of an uncompressed data page of size bytes and n values
+func pageHeader(size, n int) []byte {
+	t := newThriftWriter()
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(size))
+	t.i32(3, int32(size))
+	t.beginStruct(5)
+	t.i32(1, int32(n))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE)
+	t.i32(4, encodingRLE)
+	t.endStruct()
+	return t.bytes()
+}
+
+// footer returns the FileMetaData of the written row groups
+func (w *Writer) footer() []byte {
+	t := newThriftWriter()
+	t.i32(1, fileVersion)
+
+	t.list(2, tStruct, len(columns)+1)
+	t.beginStruct(0)
+	t.str(4, "schema")
+	t.i32(5, int32(len(columns)))
+	t.endStruct()
+	for _, col := range columns {
+		t.beginStruct(0)
+		t.i32(1, col.typ)
+		t.i32(3, repOptional)
+		t.str(4, col.name)
+		if col.typ == typeByteArray {
+			t.i32(6, convertedUTF8)
+		}
+		t.endStruct()
+	}
+
+	var rows int64
+	for _, g := range w.groups {
+		rows += g.rows
+	}
+	t.i64(3, rows)
+
+	t.list(4, tStruct, len(w.groups))
+	for _, g := range w.groups {
+		t.beginStruct(0)
+		t.list(1, tStruct, len(g.columns))
+		var size int64
+		for i, c := range g.columns {
+			size += c.size
+			t.beginStruct(0)
+			t.i64(2, c.offset)
+			t.beginStruct(3)
+			t.i32(1, columns[i].typ)
+			t.i32List(2, encodingPlain, encodingRLE)
+			t.strList(3, columns[i].name)
+			t.i32(4, codecNone)
+			t.i64(5, c.values)
+			t.i64(6, c.size)
+			t.i64(7, c.size)
+			t.i64(9, c.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, size)
+		t.i64(3, g.rows)
+		t.endStruct()
+	}
+	t.str(6, createdBy)
+	return t.bytes()
+}
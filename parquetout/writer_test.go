@@ -0,0 +1,203 @@
+package parquetout
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+)
+
+// thriftReader decodes Thrift compact protocol structs into maps of field IDs
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case tI32, tI64:
+		return r.varint()
+	case tBinary:
+		n, size := binary.Uvarint(r.data[r.pos:])
+		r.pos += size
+		s := string(r.data[r.pos : r.pos+int(n)])
+		r.pos += int(n)
+		return s
+	case tList:
+		header := r.data[r.pos]
+		r.pos++
+		n := int(header >> 4)
+		if n == 15 {
+			size, m := binary.Uvarint(r.data[r.pos:])
+			r.pos += m
+			n = int(size)
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case tStruct:
+		fields := make(map[int16]any)
+		var id int16
+		for {
+			header := r.data[r.pos]
+			r.pos++
+			if header == 0 {
+				return fields
+			}
+			if delta := int16(header >> 4); delta != 0 {
+				id += delta
+			} else {
+				id = int16(r.varint())
+			}
+			fields[id] = r.value(header & 0x0f)
+		}
+	}
+	panic("unexpected thrift type")
+}
+
+// readColumn returns the values of a column chunk, nil for nulls
+func readColumn(t *testing.T, file []byte, chunk map[int16]any, typ int64) []any {
+	meta := chunk[3].(map[int16]any)
+	r := &thriftReader{data: file, pos: int(meta[9].(int64))}
+	header := r.value(tStruct).(map[int16]any)
+	n := int(header[5].(map[int16]any)[1].(int64))
+	page := file[r.pos : r.pos+int(header[2].(int64))]
+
+	levelsSize := int(binary.LittleEndian.Uint32(page))
+	levels := &thriftReader{data: page[4 : 4+levelsSize]}
+	values := page[4+levelsSize:]
+	var defined []bool
+	for levels.pos < len(levels.data) {
+		run, m := binary.Uvarint(levels.data[levels.pos:])
+		levels.pos += m
+		level := levels.data[levels.pos] == 1
+		levels.pos++
+		for i := 0; i < int(run>>1); i++ {
+			defined = append(defined, level)
+		}
+	}
+	if len(defined) != n {
+		t.Fatalf("test for definition levels Failed - %d levels for %d values", len(defined), n)
+	}
+	out := make([]any, n)
+	for i := range out {
+		if !defined[i] {
+			continue
+		}
+		if typ == typeDouble {
+			out[i] = math.Float64frombits(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+			continue
+		}
+		size := binary.LittleEndian.Uint32(values)
+		out[i] = string(values[4 : 4+size])
+		values = values[4+size:]
+	}
+	return out
+}
+
+// results are written by the tests, the best result of "a" is not the first one
+var results = []geocoder.BatchResult{
+	{Item: geocoder.BatchItem{ID: "a", Address: "Pariser Platz, Berlin"}, Response: &geocoder.GoogleResponse{
+		Status: geocoder.GRS_OK,
+		Results: []*geocoder.ResultSet{{
+			FormattedAddress: "Berlin, Germany",
+			Geometry:         geocoder.Geometry{Location: geocoder.Coordinate{Lat: 52.52, Lng: 13.405}, LocationType: "APPROXIMATE"},
+			PartialMatch:     true,
+		}, {
+			FormattedAddress:  "Pariser Platz, 10117 Berlin, Germany",
+			Geometry:          geocoder.Geometry{Location: geocoder.Coordinate{Lat: 52.516, Lng: 13.3777}, LocationType: "ROOFTOP"},
+			AddressComponents: []geocoder.AddressComponent{{LongName: "Germany", ShortName: "DE", Types: []string{"country", "political"}}},
+		}},
+	}},
+	{Item: geocoder.BatchItem{ID: "b", Lat: -10, Lng: 20.5}, Err: errors.New("ocean")},
+	{Item: geocoder.BatchItem{Lat: 1, Lng: 2}, Response: &geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}},
+}
+
+// writeFile returns the Parquet file of results
+func writeFile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Options{RowGroupSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func Test_Writer(t *testing.T) {
+	file := writeFile(t)
+	if string(file[:4]) != magic || string(file[len(file)-4:]) != magic {
+		t.Fatalf("test for magic Failed - unexpected file %q", file)
+	}
+	footerSize := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := &thriftReader{data: file[len(file)-8-footerSize : len(file)-8]}
+	meta := footer.value(tStruct).(map[int16]any)
+	if meta[3].(int64) != 3 || len(meta[4].([]any)) != 2 {
+		t.Fatalf("test for footer Failed - unexpected metadata %v", meta)
+	}
+	schema := meta[2].([]any)
+	var names []string
+	for _, element := range schema[1:] {
+		names = append(names, element.(map[int16]any)[4].(string))
+	}
+	expectedNames := []string{"id", "input", "lat", "lng", "formatted_address", "country", "confidence", "status", "error"}
+	if !reflect.DeepEqual(names, expectedNames) || schema[0].(map[int16]any)[5].(int64) != int64(len(expectedNames)) {
+		t.Errorf("test for schema Failed - results not match\nGot:\n%v\nExpected:\n%v", names, expectedNames)
+	}
+
+	expected := [][]any{
+		{"a", "Pariser Platz, Berlin", 52.516, 13.3777, "Pariser Platz, 10117 Berlin, Germany", "DE", 1.0, "OK", nil},
+		{"b", "-10,20.5", nil, nil, nil, nil, nil, nil, "ocean"},
+		{nil, "1,2", nil, nil, nil, nil, nil, "ZERO_RESULTS", nil},
+	}
+	got := make([][]any, 0, 3)
+	for _, group := range meta[4].([]any) {
+		chunks := group.(map[int16]any)[1].([]any)
+		var rows [][]any
+		for i, chunk := range chunks {
+			for j, v := range readColumn(t, file, chunk.(map[int16]any), int64(columns[i].typ)) {
+				if i == 0 {
+					rows = append(rows, make([]any, len(chunks)))
+				}
+				rows[j][i] = v
+			}
+		}
+		got = append(got, rows...)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("test for rows Failed - results not match\nGot:\n%v\nExpected:\n%v", got, expected)
+	}
+}
+
+// testdata/results.parquet was checked with github.com/xitongsys/parquet-go, an independent
+// reader, which reads the rows of Test_Writer from it
+func Test_WriterGolden(t *testing.T) {
+	golden, err := os.ReadFile("testdata/results.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file := writeFile(t); !bytes.Equal(file, golden) {
+		t.Errorf("test for golden file Failed - results not match\nGot:\n%x\nExpected:\n%x", file, golden)
+	}
+}
@@ -0,0 +1,76 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPaused is returned while the Geocoder is paused with PP_FAIL policy, and by calls
+// which never wait, like TryReverseGeocode
+var ErrPaused = errors.New("geocoder is paused")
+
+// PausePolicy tells what happens to requests while the Geocoder is paused
+type PausePolicy string
+
+const (
+	// Requests wait until Resume or until their context is done
+	PP_WAIT PausePolicy = "wait"
+	// Requests fail with ErrPaused right away
+	PP_FAIL PausePolicy = "fail"
+)
+
+// WithPausePolicy sets what happens to requests while the Geocoder is paused, PP_WAIT by default
+func WithPausePolicy(policy PausePolicy) Option {
+	return func(g *Geocoder) {
+		g.pausePolicy = policy
+	}
+}
+
+// Pause halts outbound requests, e.g. during an incident or a quota emergency, until Resume.
+// Cached responses are still served. Requests already sent are not affected
+func (g *Geocoder) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resumed == nil {
+		g.resumed = make(chan struct{})
+		g.logger.Info("geocoder paused")
+	}
+}
+
+// Resume lets requests be sent again after Pause, waiting requests proceed
+func (g *Geocoder) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
+		g.logger.Info("geocoder resumed")
+	}
+}
+
+// Paused reports whether the Geocoder is paused
+func (g *Geocoder) Paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.resumed != nil
+}
+
+// waitResumed blocks while the Geocoder is paused, according to the pause policy.
+// With noWait it fails right away instead of waiting
+func (g *Geocoder) waitResumed(ctx context.Context, noWait bool) error {
+	g.mu.Lock()
+	resumed := g.resumed
+	g.mu.Unlock()
+	if resumed == nil {
+		return nil
+	}
+	if noWait || g.pausePolicy == PP_FAIL {
+		return ErrPaused
+	}
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,92 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Pause(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		try      bool
+		expected error
+	}{
+		{"wait", nil, false, nil},
+		{"fail", []Option{WithPausePolicy(PP_FAIL)}, false, ErrPaused},
+		{"try", nil, true, ErrPaused},
+	}
+	for _, tt := range tests {
+		var requests atomic.Int32
+		client := requesterFunc(func(targetURL string) (*http.Response, error) {
+			requests.Add(1)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+		})
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Hour, nil,
+			append(tt.opts, WithAPIKey("key"))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Pause()
+		if !g.Paused() || !g.Stats().Paused {
+			t.Errorf("test for %v Failed - not paused", tt.name)
+		}
+		done := make(chan error, 1)
+		go func() {
+			if tt.try {
+				_, err := g.TryReverseGeocode(context.TODO(), 52.52, 13.405)
+				done <- err
+				return
+			}
+			_, err := g.ReverseGeocode(context.TODO(), 52.52, 13.405)
+			done <- err
+		}()
+		if tt.expected == nil {
+			select {
+			case err := <-done:
+				t.Errorf("test for %v Failed - returned while paused %v", tt.name, err)
+			case <-time.After(50 * time.Millisecond):
+			}
+			if requests.Load() != 0 {
+				t.Errorf("test for %v Failed - request sent while paused", tt.name)
+			}
+			g.Resume()
+		}
+		if err := <-done; !errors.Is(err, tt.expected) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.expected)
+		}
+		expected := int32(1)
+		if tt.expected != nil {
+			expected = 0
+		}
+		if requests.Load() != expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, requests.Load(), expected)
+		}
+	}
+}
+
+func Test_PauseCancellation(t *testing.T) {
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Hour, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Pause()
+	defer g.Resume()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := g.ReverseGeocode(ctx, 52.52, 13.405); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("test for cancellation Failed - results not match\nGot:\n%v\nExpected:\n%v", err, context.DeadlineExceeded)
+	}
+	if health, err := g.Ping(context.TODO()); health != HS_DEGRADED || !errors.Is(err, ErrPaused) {
+		t.Errorf("test for ping Failed - unexpected %v %v", health, err)
+	}
+}
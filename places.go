@@ -0,0 +1,102 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var apiPlaceDetails = &mapsAPI{path: "place/details", usage: UsageAPIPlaces}
+
+// Place is a place of the Places API. Which fields are set depends on the request,
+// e.g. the fields asked for in PlaceDetails
+type Place struct {
+	PlaceID           string             `json:"place_id"`
+	Name              string             `json:"name"`
+	FormattedAddress  string             `json:"formatted_address,omitempty"`
+	AddressComponents []AddressComponent `json:"address_components,omitempty"`
+	// Simplified address returned by NearbySearch
+	Vicinity string   `json:"vicinity,omitempty"`
+	Geometry Geometry `json:"geometry"`
+	Types    []string `json:"types,omitempty"`
+	// OPERATIONAL, CLOSED_TEMPORARILY or CLOSED_PERMANENTLY
+	BusinessStatus           string        `json:"business_status,omitempty"`
+	FormattedPhoneNumber     string        `json:"formatted_phone_number,omitempty"`
+	InternationalPhoneNumber string        `json:"international_phone_number,omitempty"`
+	Website                  string        `json:"website,omitempty"`
+	OpeningHours             *OpeningHours `json:"opening_hours,omitempty"`
+	// Offset of the place time zone from UTC in minutes
+	UTCOffset        int     `json:"utc_offset,omitempty"`
+	Rating           float64 `json:"rating,omitempty"`
+	UserRatingsTotal int     `json:"user_ratings_total,omitempty"`
+	// From 0, free, to 4, very expensive
+	PriceLevel int       `json:"price_level,omitempty"`
+	PlusCode   *PlusCode `json:"plus_code,omitempty"`
+	// Google Maps page of the place
+	URL string `json:"url,omitempty"`
+}
+
+// OpeningHours are the regular opening hours of a place
+type OpeningHours struct {
+	// Nil if unknown
+	OpenNow *bool           `json:"open_now,omitempty"`
+	Periods []OpeningPeriod `json:"periods,omitempty"`
+	// Localized opening hours per day of the week, e.g. Monday: 9:00 AM – 5:00 PM
+	WeekdayText []string `json:"weekday_text,omitempty"`
+}
+
+// OpeningPeriod is a period the place is open in. Places open around the clock
+// have a single period opening on Sunday at 0000 without Close
+type OpeningPeriod struct {
+	Open  OpeningTime  `json:"open"`
+	Close *OpeningTime `json:"close,omitempty"`
+}
+
+// OpeningTime is a time of the week
+type OpeningTime struct {
+	// Day of the week, 0 is Sunday
+	Day int `json:"day"`
+	// 24-hour hhmm time, e.g. 1730
+	Time string `json:"time"`
+}
+
+// PlaceDetailsResponse is the response of the Place Details API
+type PlaceDetailsResponse struct {
+	Result           *Place               `json:"result"`
+	HTMLAttributions []string             `json:"html_attributions"`
+	Status           GoogleResponseStatus `json:"status"`
+	ErrorMessage     string               `json:"error_message,omitempty"`
+}
+
+// PlaceDetails returns the details of placeID. Fields restrict the response and the billing to the
+// given fields, e.g. "geometry", "formatted_phone_number" or "opening_hours", all of them are
+// returned and billed if empty. Requests are limited by the EP_PLACES limiter
+func (g *Geocoder) PlaceDetails(ctx context.Context, placeID string, fields ...string) (*PlaceDetailsResponse, error) {
+	return g.PlaceDetailsSession(ctx, placeID, "", fields...)
+}
+
+// PlaceDetailsSession is PlaceDetails concluding the autocomplete session of sessionToken
+func (g *Geocoder) PlaceDetailsSession(ctx context.Context, placeID string, sessionToken SessionToken, fields ...string) (*PlaceDetailsResponse, error) {
+	if strings.TrimSpace(placeID) == "" {
+		return nil, errors.New("empty place ID")
+	}
+	params := url.Values{"place_id": {placeID}}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if sessionToken != "" {
+		params.Set("sessiontoken", string(sessionToken))
+	}
+
+	res := &PlaceDetailsResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_PLACE_DETAILS,
+		endpoint: EP_PLACES,
+		api:      apiPlaceDetails,
+		params:   params,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
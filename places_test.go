@@ -0,0 +1,58 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_PlaceDetails(t *testing.T) {
+	var requested *url.URL
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		requested = u
+		body := `{"html_attributions":[],"status":"OK","result":{"place_id":"ChIJ","name":"Fernsehturm",
+			"formatted_phone_number":"030 247575875","geometry":{"location":{"lat":52.5208,"lng":13.4094}},
+			"opening_hours":{"open_now":true,"periods":[{"open":{"day":1,"time":"0900"},"close":{"day":1,"time":"2300"}}],
+			"weekday_text":["Monday: 9:00 AM – 11:00 PM"]}}}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.PlaceDetailsSession(context.TODO(), "ChIJ", "token", "name", "geometry", "formatted_phone_number", "opening_hours")
+	if err != nil {
+		t.Fatal(err)
+	}
+	open := true
+	expected := &Place{
+		PlaceID:              "ChIJ",
+		Name:                 "Fernsehturm",
+		FormattedPhoneNumber: "030 247575875",
+		Geometry:             Geometry{Location: Coordinate{Lat: 52.5208, Lng: 13.4094}},
+		OpeningHours: &OpeningHours{
+			OpenNow:     &open,
+			Periods:     []OpeningPeriod{{Open: OpeningTime{Day: 1, Time: "0900"}, Close: &OpeningTime{Day: 1, Time: "2300"}}},
+			WeekdayText: []string{"Monday: 9:00 AM – 11:00 PM"},
+		},
+	}
+	if !reflect.DeepEqual(res.Result, expected) {
+		t.Errorf("test for result Failed - results not match\nGot:\n%+v\nExpected:\n%+v", res.Result, expected)
+	}
+	query := requested.Query()
+	if requested.Path != "/maps/api/place/details/json" || query.Get("place_id") != "ChIJ" ||
+		query.Get("fields") != "name,geometry,formatted_phone_number,opening_hours" || query.Get("sessiontoken") != "token" {
+		t.Errorf("test for request Failed - unexpected url %v", requested)
+	}
+}
@@ -0,0 +1,294 @@
+package geocoder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Open Location Code constants, see https://github.com/google/open-location-code
+const (
+	plusCodeSeparator     = '+'
+	plusCodeSeparatorPos  = 8
+	plusCodePadding       = '0'
+	plusCodeAlphabet      = "23456789CFGHJMPQRVWX"
+	plusCodeBase          = 20
+	plusCodeMaxDigits     = 15
+	plusCodePairLength    = 10
+	plusCodeGridColumns   = 4
+	plusCodeGridRows      = 5
+	plusCodeMinTrimmable  = 6
+	plusCodePairPrecision = 8000
+	// 20^4, place value of the first pair
+	plusCodePairFirstValue = 160000
+	// 5^4 and 4^4, place values of the first grid digit
+	plusCodeGridLatFirstValue = 625
+	plusCodeGridLngFirstValue = 256
+	// Units per degree of the most precise code, 8000 * 5^5 and 8000 * 4^5
+	plusCodeFinalLatPrecision = 25000000
+	plusCodeFinalLngPrecision = 8192000
+)
+
+// plusCodePairResolutions are the sizes in degrees of the areas of codes with 2, 4, 6, 8 and 10 digits
+var plusCodePairResolutions = []float64{20, 1, .05, .0025, .000125}
+
+// ErrInvalidPlusCode is returned for strings which are not valid Open Location Codes,
+// also by Geocode for addresses starting with such a string
+var ErrInvalidPlusCode = errors.New("invalid plus code")
+
+// PlusCodeArea is the area a plus code stands for
+type PlusCodeArea struct {
+	LatLo, LngLo float64
+	LatHi, LngHi float64
+	// Number of significant digits of the code
+	Length int
+}
+
+// Center returns the center of a
+func (a PlusCodeArea) Center() Coordinate {
+	return Coordinate{
+		Lat: math.Min(a.LatLo+(a.LatHi-a.LatLo)/2, 90),
+		Lng: math.Min(a.LngLo+(a.LngHi-a.LngLo)/2, 180),
+	}
+}
+
+// ValidPlusCode reports whether code is a valid full or short plus code, case insensitive
+func ValidPlusCode(code string) bool {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	if sep < 0 || sep != strings.LastIndexByte(code, plusCodeSeparator) || len(code) == 1 {
+		return false
+	}
+	if sep > plusCodeSeparatorPos || sep%2 == 1 {
+		return false
+	}
+	if pad := strings.IndexByte(code, plusCodePadding); pad >= 0 {
+		// padding only in full codes, between whole pairs and the separator, which ends the code
+		if sep < plusCodeSeparatorPos || pad == 0 || code[len(code)-1] != plusCodeSeparator {
+			return false
+		}
+		padding := code[pad:sep]
+		if strings.Trim(padding, "0") != "" || len(padding)%2 == 1 || len(padding) > plusCodeSeparatorPos-2 {
+			return false
+		}
+	}
+	if len(code)-sep-1 == 1 {
+		return false
+	}
+	for _, r := range strings.ToUpper(code) {
+		if r != plusCodeSeparator && r != plusCodePadding && !strings.ContainsRune(plusCodeAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ShortPlusCode reports whether code is a valid short plus code like CWC8+R9, which has to be
+// recovered with a reference location
+func ShortPlusCode(code string) bool {
+	return ValidPlusCode(code) && strings.IndexByte(code, plusCodeSeparator) < plusCodeSeparatorPos
+}
+
+// FullPlusCode reports whether code is a valid full plus code like 849VCWC8+R9
+func FullPlusCode(code string) bool {
+	if !ValidPlusCode(code) || ShortPlusCode(code) {
+		return false
+	}
+	code = strings.ToUpper(code)
+	if strings.IndexByte(plusCodeAlphabet, code[0])*plusCodeBase >= 180 {
+		return false
+	}
+	return len(code) < 2 || strings.IndexByte(plusCodeAlphabet, code[1])*plusCodeBase < 360
+}
+
+// EncodePlusCode returns the full plus code of a coordinate with length digits, 10 digits are
+// about 14x14 meters. Length is 2, 4, 6, 8 or 10 to 15
+func EncodePlusCode(lat, lng float64, length int) (string, error) {
+	if length < 2 || length < plusCodePairLength && length%2 == 1 {
+		return "", fmt.Errorf("%w: unsupported length %d", ErrInvalidPlusCode, length)
+	}
+	length = min(length, plusCodeMaxDigits)
+	lat = math.Max(-90, math.Min(90, lat))
+	lng = normalizeLongitude(lng)
+	if lat == 90 {
+		lat -= plusCodeLatPrecision(length)
+	}
+
+	latVal := int64(math.Round(lat*plusCodeFinalLatPrecision)) + 90*plusCodeFinalLatPrecision
+	lngVal := int64(math.Round(lng*plusCodeFinalLngPrecision)) + 180*plusCodeFinalLngPrecision
+	code := make([]byte, plusCodeMaxDigits)
+	if length > plusCodePairLength {
+		for i := plusCodeMaxDigits - 1; i >= plusCodePairLength; i-- {
+			code[i] = plusCodeAlphabet[latVal%plusCodeGridRows*plusCodeGridColumns+lngVal%plusCodeGridColumns]
+			latVal /= plusCodeGridRows
+			lngVal /= plusCodeGridColumns
+		}
+	} else {
+		latVal /= plusCodeGridLatFirstValue * plusCodeGridRows
+		lngVal /= plusCodeGridLngFirstValue * plusCodeGridColumns
+	}
+	for i := plusCodePairLength - 2; i >= 0; i -= 2 {
+		code[i] = plusCodeAlphabet[latVal%plusCodeBase]
+		code[i+1] = plusCodeAlphabet[lngVal%plusCodeBase]
+		latVal /= plusCodeBase
+		lngVal /= plusCodeBase
+	}
+
+	if length < plusCodeSeparatorPos {
+		return string(code[:length]) + strings.Repeat("0", plusCodeSeparatorPos-length) + "+", nil
+	}
+	return string(code[:plusCodeSeparatorPos]) + "+" + string(code[plusCodeSeparatorPos:length]), nil
+}
+
+// plusCodeLatPrecision returns the height in degrees of the area of a code with length digits
+func plusCodeLatPrecision(length int) float64 {
+	if length <= plusCodePairLength {
+		return math.Pow(plusCodeBase, float64(2-length/2))
+	}
+	return math.Pow(plusCodeBase, -3) / math.Pow(plusCodeGridRows, float64(length-plusCodePairLength))
+}
+
+// DecodePlusCode returns the area of a full plus code
+func DecodePlusCode(code string) (PlusCodeArea, error) {
+	if !FullPlusCode(code) {
+		return PlusCodeArea{}, fmt.Errorf("%w: %q is not a full code", ErrInvalidPlusCode, code)
+	}
+	code = strings.ToUpper(strings.NewReplacer("+", "", "0", "").Replace(code))
+	if len(code) > plusCodeMaxDigits {
+		code = code[:plusCodeMaxDigits]
+	}
+
+	normalLat, normalLng := int64(-90*plusCodePairPrecision), int64(-180*plusCodePairPrecision)
+	pairs := min(len(code), plusCodePairLength)
+	place := int64(plusCodePairFirstValue)
+	for i := 0; i < pairs; i += 2 {
+		normalLat += int64(strings.IndexByte(plusCodeAlphabet, code[i])) * place
+		normalLng += int64(strings.IndexByte(plusCodeAlphabet, code[i+1])) * place
+		if i < pairs-2 {
+			place /= plusCodeBase
+		}
+	}
+	latPrecision := float64(place) / plusCodePairPrecision
+	lngPrecision := latPrecision
+
+	var gridLat, gridLng int64
+	if len(code) > plusCodePairLength {
+		row, col := int64(plusCodeGridLatFirstValue), int64(plusCodeGridLngFirstValue)
+		for i := plusCodePairLength; i < len(code); i++ {
+			digit := int64(strings.IndexByte(plusCodeAlphabet, code[i]))
+			gridLat += digit / plusCodeGridColumns * row
+			gridLng += digit % plusCodeGridColumns * col
+			if i < len(code)-1 {
+				row /= plusCodeGridRows
+				col /= plusCodeGridColumns
+			}
+		}
+		latPrecision = float64(row) / plusCodeFinalLatPrecision
+		lngPrecision = float64(col) / plusCodeFinalLngPrecision
+	}
+
+	lat := float64(normalLat)/plusCodePairPrecision + float64(gridLat)/plusCodeFinalLatPrecision
+	lng := float64(normalLng)/plusCodePairPrecision + float64(gridLng)/plusCodeFinalLngPrecision
+	return PlusCodeArea{
+		LatLo:  roundPlusCode(lat),
+		LngLo:  roundPlusCode(lng),
+		LatHi:  roundPlusCode(lat + latPrecision),
+		LngHi:  roundPlusCode(lng + lngPrecision),
+		Length: len(code),
+	}, nil
+}
+
+// roundPlusCode drops floating point noise from decoded degrees
+func roundPlusCode(degrees float64) float64 {
+	return math.Round(degrees*1e14) / 1e14
+}
+
+// ShortenPlusCode removes as many leading digits from a full code as can be recovered with
+// a reference location nearby, e.g. the center of the locality of a compound code.
+// Padded codes can't be shortened
+func ShortenPlusCode(code string, lat, lng float64) (string, error) {
+	if !FullPlusCode(code) {
+		return "", fmt.Errorf("%w: %q is not a full code", ErrInvalidPlusCode, code)
+	}
+	if strings.IndexByte(code, plusCodePadding) >= 0 {
+		return "", fmt.Errorf("%w: padded code %q can't be shortened", ErrInvalidPlusCode, code)
+	}
+	code = strings.ToUpper(code)
+	area, err := DecodePlusCode(code)
+	if err != nil {
+		return "", err
+	}
+	if area.Length < plusCodeMinTrimmable {
+		return "", fmt.Errorf("%w: code %q is too short to be shortened", ErrInvalidPlusCode, code)
+	}
+	center := area.Center()
+	lat = math.Max(-90, math.Min(90, lat))
+	lng = normalizeLongitude(lng)
+	distance := math.Max(math.Abs(center.Lat-lat), math.Abs(center.Lng-lng))
+	for i := len(plusCodePairResolutions) - 2; i >= 1; i-- {
+		if distance < plusCodePairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+	return code, nil
+}
+
+// RecoverPlusCode returns the full code of a short code nearest to a reference location,
+// full codes are returned as they are
+func RecoverPlusCode(code string, lat, lng float64) (string, error) {
+	if FullPlusCode(code) {
+		return strings.ToUpper(code), nil
+	}
+	if !ShortPlusCode(code) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPlusCode, code)
+	}
+	code = strings.ToUpper(code)
+	lat = math.Max(-90, math.Min(90, lat))
+	lng = normalizeLongitude(lng)
+
+	missing := plusCodeSeparatorPos - strings.IndexByte(code, plusCodeSeparator)
+	resolution := math.Pow(plusCodeBase, float64(2-missing/2))
+	half := resolution / 2
+	reference, err := EncodePlusCode(lat, lng, plusCodePairLength)
+	if err != nil {
+		return "", err
+	}
+	area, err := DecodePlusCode(reference[:missing] + code)
+	if err != nil {
+		return "", err
+	}
+	center := area.Center()
+	// the nearest match may be in the neighboring cell of the reference
+	if lat+half < center.Lat && center.Lat-resolution >= -90 {
+		center.Lat -= resolution
+	} else if lat-half > center.Lat && center.Lat+resolution <= 90 {
+		center.Lat += resolution
+	}
+	if lng+half < center.Lng {
+		center.Lng -= resolution
+	} else if lng-half > center.Lng {
+		center.Lng += resolution
+	}
+	return EncodePlusCode(center.Lat, center.Lng, area.Length)
+}
+
+// plusCodeAddress checks a forward geocoding address starting with a plus code, a global code
+// like 849VCWC8+R9 or a compound code like "CWC8+R9 Mountain View, CA". The code is upper cased.
+// Addresses not starting with something looking like a plus code are returned unchanged
+func plusCodeAddress(address string) (string, error) {
+	code, locality, _ := strings.Cut(strings.TrimSpace(address), " ")
+	if !strings.ContainsRune(code, plusCodeSeparator) || strings.Trim(strings.ToUpper(code), plusCodeAlphabet+"0+") != "" {
+		return address, nil
+	}
+	if !ValidPlusCode(code) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPlusCode, code)
+	}
+	locality = strings.TrimSpace(locality)
+	if ShortPlusCode(code) && locality == "" {
+		return "", fmt.Errorf("%w: short code %q needs a locality", ErrInvalidPlusCode, code)
+	}
+	if locality == "" {
+		return strings.ToUpper(code), nil
+	}
+	return strings.ToUpper(code) + " " + locality, nil
+}
@@ -0,0 +1,199 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_EncodePlusCode(t *testing.T) {
+	tests := []struct {
+		lat, lng float64
+		length   int
+		expected string
+	}{
+		{20.375, 2.775, 6, "7FG49Q00+"},
+		{20.3700625, 2.7821875, 10, "7FG49QCJ+2V"},
+		{20.3701125, 2.782234375, 11, "7FG49QCJ+2VX"},
+		{20.3701135, 2.78223535156, 13, "7FG49QCJ+2VXGJ"},
+		{47.0000625, 8.0000625, 10, "8FVC2222+22"},
+		{-41.2730625, 174.7859375, 10, "4VCPPQGP+Q9"},
+		{0.5, -179.5, 4, "62G20000+"},
+		{90, 1, 4, "CFX30000+"},
+		{1, 180, 4, "62H20000+"},
+	}
+	for _, tt := range tests {
+		code, err := EncodePlusCode(tt.lat, tt.lng, tt.length)
+		if err != nil || code != tt.expected {
+			t.Errorf("test for %v,%v Failed - results not match\nGot:\n%v %v\nExpected:\n%v", tt.lat, tt.lng, code, err, tt.expected)
+		}
+	}
+	if _, err := EncodePlusCode(1, 1, 7); !errors.Is(err, ErrInvalidPlusCode) {
+		t.Errorf("test for odd length Failed - unexpected error %v", err)
+	}
+}
+
+func Test_DecodePlusCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected PlusCodeArea
+	}{
+		{"7FG49Q00+", PlusCodeArea{LatLo: 20.35, LngLo: 2.75, LatHi: 20.4, LngHi: 2.8, Length: 6}},
+		{"7fg49qcj+2v", PlusCodeArea{LatLo: 20.37, LngLo: 2.782125, LatHi: 20.370125, LngHi: 2.78225, Length: 10}},
+		{"8FVC2222+22", PlusCodeArea{LatLo: 47, LngLo: 8, LatHi: 47.000125, LngHi: 8.000125, Length: 10}},
+	}
+	for _, tt := range tests {
+		area, err := DecodePlusCode(tt.code)
+		if err != nil || area != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%+v %v\nExpected:\n%+v", tt.code, area, err, tt.expected)
+		}
+	}
+
+	area, _ := DecodePlusCode("7FG49QCJ+2VXGJ")
+	if center := area.Center(); math.Abs(center.Lat-20.3701135) > 1e-6 || math.Abs(center.Lng-2.78223535) > 1e-6 {
+		t.Errorf("test for center Failed - unexpected center %v", center)
+	}
+	for _, code := range []string{"CWC8+R9", "WC2345G6+", "7FG49QCJ+2"} {
+		if _, err := DecodePlusCode(code); !errors.Is(err, ErrInvalidPlusCode) {
+			t.Errorf("test for %v Failed - unexpected error %v", code, err)
+		}
+	}
+}
+
+func Test_ValidPlusCode(t *testing.T) {
+	tests := []struct {
+		code               string
+		valid, short, full bool
+	}{
+		{"8FWC2345+G6", true, false, true},
+		{"8FWC2345+G6G", true, false, true},
+		{"8fwc2345+", true, false, true},
+		{"8FWCX400+", true, false, true},
+		{"WC2345+G6g", true, true, false},
+		{"2345+G6", true, true, false},
+		{"45+G6", true, true, false},
+		{"+G6", true, true, false},
+		{"G+", false, false, false},
+		{"+", false, false, false},
+		{"8FWC2345+G", false, false, false},
+		{"8FWC2_45+G6", false, false, false},
+		{"8FWC2η45+G6", false, false, false},
+		{"8FWC2345+G6+", false, false, false},
+		{"8FWC2345G6+", false, false, false},
+		{"8FWC2300+G6", false, false, false},
+		{"WC2300+G6g", false, false, false},
+		{"WC2345+G", false, false, false},
+		{"WC2300+", false, false, false},
+		{"C2345+G6", false, false, false},
+		{"CFX30000+", true, false, true},
+		{"X2222222+", true, false, false},
+	}
+	for _, tt := range tests {
+		if valid, short, full := ValidPlusCode(tt.code), ShortPlusCode(tt.code), FullPlusCode(tt.code); valid != tt.valid || short != tt.short || full != tt.full {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v %v %v\nExpected:\n%v %v %v", tt.code, valid, short, full, tt.valid, tt.short, tt.full)
+		}
+	}
+}
+
+func Test_ShortenRecoverPlusCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		lat, lng float64
+		short    string
+	}{
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625, "+2VX"},
+		{"9C3W9QCJ+2VX", 51.3708675, -1.217765625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625 + 0.03, "9QCJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625 + 0.5, "9C3W9QCJ+2VX"},
+		{"8FJFW222+", 42.899, 9.012, "22+"},
+		{"8FJF0000+", 42.899, 9.012, ""},
+	}
+	for _, tt := range tests {
+		short, err := ShortenPlusCode(tt.code, tt.lat, tt.lng)
+		if tt.short == "" {
+			if !errors.Is(err, ErrInvalidPlusCode) {
+				t.Errorf("test for shorten %v Failed - unexpected error %v", tt.code, err)
+			}
+			continue
+		}
+		if err != nil || short != tt.short {
+			t.Errorf("test for shorten %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v", tt.code, short, err, tt.short)
+		}
+		if full, err := RecoverPlusCode(short, tt.lat, tt.lng); err != nil || full != tt.code {
+			t.Errorf("test for recover %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v", short, full, err, tt.code)
+		}
+	}
+
+	// the nearest match is across the antimeridian or next to a pole
+	for _, tt := range []struct {
+		short    string
+		lat, lng float64
+	}{
+		{"2222+22", -0.5, 179.99},
+		{"XXXX+XX", 89.6, -179.9},
+	} {
+		full, err := RecoverPlusCode(tt.short, tt.lat, tt.lng)
+		if err != nil {
+			t.Errorf("test for recover %v Failed - unexpected error %v", tt.short, err)
+			continue
+		}
+		area, _ := DecodePlusCode(full)
+		if center := area.Center(); Haversine(Coordinate{Lat: tt.lat, Lng: tt.lng}, center) > 100000 {
+			t.Errorf("test for recover %v Failed - %v at %v is too far", tt.short, full, center)
+		}
+	}
+	if _, err := RecoverPlusCode("C2345+G6", 1, 1); !errors.Is(err, ErrInvalidPlusCode) {
+		t.Errorf("test for invalid short code Failed - unexpected error %v", err)
+	}
+}
+
+func Test_GeocodePlusCode(t *testing.T) {
+	var requests []*url.URL
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, u)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"results":[],"status":"ZERO_RESULTS"}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000,
+		time.Millisecond, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		address  string
+		expected string
+		err      error
+	}{
+		{"849vcwc8+r9", "849VCWC8+R9", nil},
+		{" cwc8+r9 Mountain View, CA", "CWC8+R9 Mountain View, CA", nil},
+		{"1600 Amphitheatre Pkwy + Charleston Rd", "1600 Amphitheatre Pkwy + Charleston Rd", nil},
+		{"CWC8+R9", "", ErrInvalidPlusCode},
+		{"849VCWC8+R Mountain View", "", ErrInvalidPlusCode},
+	}
+	for _, tt := range tests {
+		requests = nil
+		_, err := g.Geocode(context.TODO(), tt.address)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test for %v Failed - unexpected error %v", tt.address, err)
+			continue
+		}
+		if tt.err != nil {
+			if len(requests) != 0 {
+				t.Errorf("test for %v Failed - invalid code sent", tt.address)
+			}
+			continue
+		}
+		if len(requests) != 1 || requests[0].Query().Get("address") != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.address, requests, tt.expected)
+		}
+	}
+}
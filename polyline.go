@@ -0,0 +1,92 @@
+package geocoder
+
+import (
+	"errors"
+	"strings"
+)
+
+// polylinePrecision is the scale of coordinates in encoded polylines, 5 decimal places
+const polylinePrecision = 1e5
+
+// ErrInvalidPolyline is returned for malformed encoded polylines
+var ErrInvalidPolyline = errors.New("invalid encoded polyline")
+
+// Polyline is a path in the Encoded Polyline Algorithm Format
+type Polyline struct {
+	Points string `json:"points"`
+}
+
+// Decode returns the coordinates of the path
+func (p Polyline) Decode() ([]Coordinate, error) {
+	return DecodePolyline(p.Points)
+}
+
+// DecodePolyline decodes a path in the Encoded Polyline Algorithm Format, e.g. overview_polyline
+// of a route
+func DecodePolyline(encoded string) ([]Coordinate, error) {
+	var path []Coordinate
+	var lat, lng int64
+	for i := 0; i < len(encoded); {
+		var deltas [2]int64
+		for j := range deltas {
+			var result int64
+			var shift uint
+			for {
+				if i >= len(encoded) {
+					return nil, ErrInvalidPolyline
+				}
+				b := int64(encoded[i]) - 63
+				i++
+				if b < 0 || b > 63 || shift > 60 {
+					return nil, ErrInvalidPolyline
+				}
+				result |= (b & 0x1f) << shift
+				shift += 5
+				if b < 0x20 {
+					break
+				}
+			}
+			if result&1 != 0 {
+				deltas[j] = ^(result >> 1)
+			} else {
+				deltas[j] = result >> 1
+			}
+		}
+		lat += deltas[0]
+		lng += deltas[1]
+		path = append(path, Coordinate{Lat: float64(lat) / polylinePrecision, Lng: float64(lng) / polylinePrecision})
+	}
+	return path, nil
+}
+
+// EncodePolyline encodes path in the Encoded Polyline Algorithm Format
+func EncodePolyline(path []Coordinate) string {
+	var sb strings.Builder
+	var prevLat, prevLng int64
+	for _, c := range path {
+		lat, lng := roundE5(c.Lat), roundE5(c.Lng)
+		encodePolylineValue(&sb, lat-prevLat)
+		encodePolylineValue(&sb, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return sb.String()
+}
+
+func encodePolylineValue(sb *strings.Builder, v int64) {
+	u := v << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+	sb.WriteByte(byte(u + 63))
+}
+
+func roundE5(v float64) int64 {
+	if v < 0 {
+		return int64(v*polylinePrecision - 0.5)
+	}
+	return int64(v*polylinePrecision + 0.5)
+}
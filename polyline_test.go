@@ -0,0 +1,32 @@
+package geocoder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_Polyline(t *testing.T) {
+	// example of the Encoded Polyline Algorithm Format documentation
+	encoded := "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	path := []Coordinate{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}, {Lat: 43.252, Lng: -126.453}}
+
+	got, err := DecodePolyline(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, path) {
+		t.Errorf("test for decoding Failed - results not match\nGot:\n%v\nExpected:\n%v", got, path)
+	}
+	if got := EncodePolyline(path); got != encoded {
+		t.Errorf("test for encoding Failed - results not match\nGot:\n%v\nExpected:\n%v", got, encoded)
+	}
+	if got, err := DecodePolyline(""); err != nil || len(got) != 0 {
+		t.Errorf("test for empty Failed - unexpected %v, %v", got, err)
+	}
+	for _, invalid := range []string{"_p~iF~ps|", "_p~iF", " "} {
+		if _, err := DecodePolyline(invalid); !errors.Is(err, ErrInvalidPolyline) {
+			t.Errorf("test for %q Failed - unexpected error %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrNoCredentials is returned when every credential of the pool was taken out of rotation
+var ErrNoCredentials = errors.New("all credentials of the pool are disabled")
+
+// PoolStrategy selects a credential of the pool for the next request
+type PoolStrategy string
+
+const (
+	// Credentials are used in turn
+	PS_ROUND_ROBIN PoolStrategy = "round_robin"
+	// The credential with the fewest requests sent so far is used
+	PS_LEAST_USED PoolStrategy = "least_used"
+	// The credential whose limiter has the most tokens available is used, so the request
+	// waits the least and the load follows the quota of every project
+	PS_MOST_TOKENS PoolStrategy = "most_tokens"
+)
+
+// Credential is a member of the credential pool, either a BusinessKey or an API key
+type Credential struct {
+	BusinessKey *BusinessKey
+	APIKey      string
+	// Geocoding requests per second of the credential, requestPerSecond of the Geocoder if zero
+	RPS int
+}
+
+// WithCredentialPool splits geocoding requests between several credentials, e.g. of different
+// projects, each with its own rate limiter in place of the Geocoder one. A credential is taken out
+// of rotation on REQUEST_DENIED or HTTP 403, and the request is repeated right away with another one.
+// The signer of WithSigner applies to every BusinessKey of the pool, an HMAC one with the signing key
+// of the credential, while signing keys of WithSigningKeys don't. The BusinessKey passed to NewGeocoder may be nil
+func WithCredentialPool(strategy PoolStrategy, creds ...Credential) Option {
+	return func(g *Geocoder) {
+		g.poolStrategy = strategy
+		g.poolCredentials = creds
+	}
+}
+
+// poolMember is a credential of the pool with its own limiter
+type poolMember struct {
+	// Copy of the Geocoder builder with the credential
+	builder RequestBuilder
+	limiter *rate.Limiter
+	// Requests per second of the credential, the Geocoder ones if zero
+	rps int
+	// Adaptive rate state of the limiter, nil unless WithAdaptiveRate is used
+	adaptive *adaptiveRate
+	// Number of times the credential was picked
+	used atomic.Int64
+	// Set once the credential is taken out of rotation
+	disabled atomic.Bool
+}
+
+// credentialPool picks credentials for requests according to the strategy
+type credentialPool struct {
+	strategy PoolStrategy
+	members  []*poolMember
+	next     atomic.Uint64
+}
+
+func newCredentialPool(builder RequestBuilder, strategy PoolStrategy, creds []Credential, rps, burst int, adaptive *adaptiveRate) (*credentialPool, error) {
+	switch strategy {
+	case PS_ROUND_ROBIN, PS_LEAST_USED, PS_MOST_TOKENS:
+	default:
+		return nil, errors.New("unknown pool strategy " + string(strategy))
+	}
+	p := &credentialPool{strategy: strategy}
+	for _, cred := range creds {
+		if cred.BusinessKey == nil && cred.APIKey == "" {
+			return nil, errors.New("empty BusinessKey and APIKey in pool credential")
+		}
+		if cred.RPS < 0 {
+			return nil, errors.New("RPS of pool credential must not be negative")
+		}
+		m := &poolMember{rps: cred.RPS}
+		limit := m.ceiling(rps)
+		m.limiter = rate.NewLimiter(rate.Limit(limit), burst)
+		if adaptive != nil {
+			m.adaptive = &adaptiveRate{min: adaptive.min, successesPerStep: adaptive.successesPerStep, current: float64(limit)}
+		}
+		m.builder = builder
+		m.builder.BusinessKey = cred.BusinessKey
+		m.builder.APIKey = cred.APIKey
+		signer, err := poolSigner(builder.Signer, cred)
+		if err != nil {
+			return nil, err
+		}
+		m.builder.Signer = signer
+		p.members = append(p.members, m)
+	}
+	return p, nil
+}
+
+// poolSigner returns the Signer of a pool credential given the one of the Geocoder. HMAC signers
+// keep their hash with the signing key of the credential, key rotation falls back to HMAC-SHA1
+// and other signers, e.g. NoopSigner, are shared
+func poolSigner(s Signer, cred Credential) (Signer, error) {
+	switch s := s.(type) {
+	case nil, *keyRotation:
+		return nil, nil
+	case *hmacSigner:
+		if cred.BusinessKey == nil {
+			return nil, nil
+		}
+		return newHMACSigner(s.hash, cred.BusinessKey.SigningKey)
+	default:
+		return s, nil
+	}
+}
+
+// pick returns the credential for the next request among the available ones at now, nil if p is nil
+func (p *credentialPool) pick(available func(*RequestBuilder) bool, now time.Time) (*poolMember, error) {
+	if p == nil {
+		return nil, nil
+	}
+	var picked *poolMember
+	switch p.strategy {
+	case PS_MOST_TOKENS:
+		var most float64
+		for _, m := range p.members {
+			if !m.usable(available) {
+				continue
+			}
+			if tokens := m.limiter.TokensAt(now); picked == nil || tokens > most {
+				picked, most = m, tokens
+			}
+		}
+	case PS_LEAST_USED:
+		for _, m := range p.members {
+			if m.usable(available) && (picked == nil || m.used.Load() < picked.used.Load()) {
+				picked = m
+			}
+		}
+	default:
+		for range p.members {
+			m := p.members[(p.next.Add(1)-1)%uint64(len(p.members))]
+			if m.usable(available) {
+				picked = m
+				break
+			}
+		}
+	}
+	if picked == nil {
+		for _, m := range p.members {
+			if !m.disabled.Load() {
+				return nil, ErrQuotaExhausted
+			}
+		}
+		return nil, ErrNoCredentials
+	}
+	picked.used.Add(1)
+	return picked, nil
+}
+
+// ceiling returns the requests per second of m given the ones of the Geocoder
+func (m *poolMember) ceiling(rps int) int {
+	if m.rps == 0 {
+		return rps
+	}
+	return m.rps
+}
+
+// setRPS applies the requests per second of the Geocoder to the limiters of the members
+// without their own rate, keeping the adaptive rate below it
+func (p *credentialPool) setRPS(now time.Time, rps int) {
+	if p == nil {
+		return
+	}
+	for _, m := range p.members {
+		if m.rps != 0 {
+			continue
+		}
+		limit := float64(rps)
+		if m.adaptive != nil {
+			limit = m.adaptive.setCeiling(limit)
+		}
+		m.limiter.SetLimitAt(now, rate.Limit(limit))
+	}
+}
+
+// usable reports whether m is in rotation and available
+func (m *poolMember) usable(available func(*RequestBuilder) bool) bool {
+	return !m.disabled.Load() && available(&m.builder)
+}
+
+// disable takes m out of rotation and reports whether other credentials are still available
+func (p *credentialPool) disable(ctx context.Context, logger *slog.Logger, m *poolMember, reason string) bool {
+	if !m.disabled.Swap(true) {
+		logger.WarnContext(ctx, "pool credential taken out of rotation",
+			slog.String("client_id", m.builder.clientID()), slog.String("reason", reason))
+	}
+	for _, other := range p.members {
+		if !other.disabled.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+// credentialRejection reports whether the credential of the request was rejected and why
+func credentialRejection(res *GoogleResponse, err error) (string, bool) {
+	var httpErr *HTTPError
+	switch {
+	case err == nil && res != nil && res.Status == GRS_REQUEST_DENIED:
+		return string(res.Status) + ": " + res.ErrorMessage, true
+	case errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden:
+		return httpErr.Error(), true
+	}
+	return "", false
+}
@@ -0,0 +1,164 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// poolClient answers OK and records the key or client of every request, keys in denied are rejected
+func poolClient(used *[]string, denied ...string) HttpRequester {
+	return requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, _ := url.Parse(targetURL)
+		key := u.Query().Get("key") + u.Query().Get("client")
+		*used = append(*used, key)
+		body := `{"status":"OK"}`
+		for _, d := range denied {
+			if key == d {
+				body = `{"status":"REQUEST_DENIED","error_message":"This API project is not authorized to use this API."}`
+			}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func Test_CredentialPool(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy PoolStrategy
+		denied   []string
+		expected []string
+	}{
+		{"round robin", PS_ROUND_ROBIN, nil, []string{"a", "client-b", "c", "a"}},
+		{"least used", PS_LEAST_USED, nil, []string{"a", "client-b", "c", "a"}},
+		{"round robin denied", PS_ROUND_ROBIN, []string{"client-b"}, []string{"a", "client-b", "c", "a", "c"}},
+		{"least used denied", PS_LEAST_USED, []string{"a"}, []string{"a", "client-b", "c", "client-b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []string
+			g, err := NewGeocoder(nil, "https://localhost/maps/api/geocode/json", "en", poolClient(&used, tt.denied...),
+				1000, time.Millisecond, nil, WithCredentialPool(tt.strategy,
+					Credential{APIKey: "a"},
+					Credential{BusinessKey: &BusinessKey{ClientID: "client-b", SigningKey: "bXlfdGVzdF9rZXk="}},
+					Credential{APIKey: "c"},
+				))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 4; i++ {
+				res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+				if err != nil || res.Status != GRS_OK {
+					t.Fatalf("test for %v Failed - unexpected response %v, error %v", tt.name, res, err)
+				}
+			}
+			if !reflect.DeepEqual(used, tt.expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, used, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_CredentialPoolExhausted(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used, "a", "b"), 1000, time.Millisecond, nil,
+		WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "a"}, Credential{APIKey: "b"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.ReverseGeocode(context.TODO(), 1, 1)
+	if err != nil || res.Status != GRS_REQUEST_DENIED || len(used) != 2 {
+		t.Errorf("test for exhausted pool Failed - response %v, error %v after %d requests", res, err, len(used))
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("test for disabled pool Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrNoCredentials)
+	}
+}
+
+func Test_CredentialPoolLimiters(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1, time.Millisecond, nil,
+		WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "a", RPS: 1000}, Credential{APIKey: "b", RPS: 1000}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the Geocoder limit of 1 request per second doesn't apply to pool credentials
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("test for per-credential limiters Failed - 6 requests took %v", elapsed)
+	}
+}
+
+func Test_CredentialPoolMostTokens(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1, time.Millisecond, nil,
+		WithCredentialPool(PS_MOST_TOKENS, Credential{APIKey: "a"}, Credential{APIKey: "b"}, Credential{APIKey: "c", RPS: 1000}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a and b refill once per second, c right away
+	expected := []string{"a", "b", "c", "c", "c"}
+	if !reflect.DeepEqual(used, expected) {
+		t.Errorf("test for most tokens Failed - results not match\nGot:\n%v\nExpected:\n%v", used, expected)
+	}
+}
+
+func Test_CredentialPoolSigner(t *testing.T) {
+	poolKey := "cG9vbF9rZXk="
+	sha256Signer, _ := NewHMACSHA256Signer("bXlfdGVzdF9rZXk=")
+	poolSHA256, _ := NewHMACSHA256Signer(poolKey)
+	poolSHA1, _ := NewHMACSHA1Signer(poolKey)
+
+	tests := []struct {
+		name     string
+		signer   Signer
+		expected Signer
+	}{
+		{"Default HMAC-SHA1", nil, poolSHA1},
+		{"HMAC-SHA256 with the pool key", sha256Signer, poolSHA256},
+		{"Unsigned", NoopSigner{}, NoopSigner{}},
+	}
+
+	for _, tt := range tests {
+		var requested string
+		client := requesterFunc(func(targetURL string) (*http.Response, error) {
+			requested = targetURL
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+		})
+		opts := []Option{WithCredentialPool(PS_ROUND_ROBIN, Credential{BusinessKey: &BusinessKey{ClientID: "pool", SigningKey: poolKey}})}
+		if tt.signer != nil {
+			opts = append(opts, WithSigner(tt.signer))
+		}
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second, nil, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.ReverseGeocode(context.TODO(), 52.52, 13.405); err != nil {
+			t.Fatal(err)
+		}
+		ur, _ := url.Parse(requested)
+		got := ur.Query().Get("signature")
+		unsigned, _, _ := strings.Cut(ur.RawQuery, "&signature=")
+		expected, _ := tt.expected.Sign(ur.Path + "?" + unsigned)
+		if got != expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, expected)
+		}
+	}
+}
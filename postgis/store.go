@@ -0,0 +1,87 @@
+// Package postgis implements geocoder.ResultStore on top of a PostGIS table.
+// The table is expected to have the following layout:
+//
+//	CREATE TABLE geocode_results (
+//		location   geography(Point, 4326) NOT NULL,
+//		response   jsonb NOT NULL,
+//		created_at timestamptz NOT NULL DEFAULT now()
+//	);
+//	CREATE INDEX ON geocode_results USING gist (location);
+//
+// Any database/sql driver for PostgreSQL can be used
+package postgis
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/alvillain/geocoder"
+)
+
+// tableNameRe restricts table names to plain, optionally schema qualified identifiers
+var tableNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Store looks up previously geocoded results within a radius around the queried coordinate
+type Store struct {
+	db         *sql.DB
+	lookupStmt string
+	saveStmt   string
+	radius     float64
+}
+
+var _ geocoder.ResultStore = (*Store)(nil)
+
+// New creates new instance of Store. Stored results closer than radiusMeters to the
+// queried coordinate are reused, the closest one wins
+func New(db *sql.DB, table string, radiusMeters float64) (*Store, error) {
+	if db == nil {
+		return nil, errors.New("empty db")
+	}
+	if !tableNameRe.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	if radiusMeters < 0 {
+		return nil, errors.New("radiusMeters must not be negative")
+	}
+	return &Store{
+		db: db,
+		lookupStmt: `SELECT response FROM ` + table + `
+			WHERE ST_DWithin(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)
+			ORDER BY location <-> ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography
+			LIMIT 1`,
+		saveStmt: `INSERT INTO ` + table + ` (location, response)
+			VALUES (ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)`,
+		radius: radiusMeters,
+	}, nil
+}
+
+// Lookup returns the stored response closest to the coordinate within the radius
+func (s *Store) Lookup(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, bool, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx, s.lookupStmt, lng, lat, s.radius).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var res *geocoder.GoogleResponse
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, false, err
+	}
+	return res, true, nil
+}
+
+// Save stores the response for the coordinate
+func (s *Store) Save(ctx context.Context, lat, lng float64, res *geocoder.GoogleResponse) error {
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, s.saveStmt, lng, lat, raw)
+	return err
+}
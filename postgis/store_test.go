@@ -0,0 +1,226 @@
+package postgis
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alvillain/geocoder"
+)
+
+// fakeDriver is a database/sql driver keeping the rows of every data source name in memory.
+// It understands the two statements of Store, distances come from geocoder.Haversine
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+type fakeDB struct {
+	mu      sync.Mutex
+	rows    []fakeRow
+	queries []string
+	// Returned by every statement if set
+	err error
+}
+
+type fakeRow struct {
+	location geocoder.Coordinate
+	response []byte
+}
+
+var testDriver = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+func init() {
+	sql.Register("postgisfake", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dbs[name] == nil {
+		d.dbs[name] = &fakeDB{}
+	}
+	return &fakeConn{db: d.dbs[name]}, nil
+}
+
+// openDB returns a Store on a new in-memory table and its rows
+func openDB(t *testing.T, table string) (*Store, *fakeDB) {
+	fake := &fakeDB{}
+	testDriver.mu.Lock()
+	testDriver.dbs[t.Name()] = fake
+	testDriver.mu.Unlock()
+
+	db, err := sql.Open("postgisfake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	s, err := New(db, table, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, fake
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.queries = append(c.db.queries, query)
+	if c.db.err != nil {
+		return nil, c.db.err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(query), "INSERT") {
+		return nil, errors.New("unexpected statement " + query)
+	}
+	location := geocoder.Coordinate{Lng: args[0].Value.(float64), Lat: args[1].Value.(float64)}
+	c.db.rows = append(c.db.rows, fakeRow{location: location, response: args[2].Value.([]byte)})
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.queries = append(c.db.queries, query)
+	if c.db.err != nil {
+		return nil, c.db.err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(query), "SELECT") {
+		return nil, errors.New("unexpected statement " + query)
+	}
+	point := geocoder.Coordinate{Lng: args[0].Value.(float64), Lat: args[1].Value.(float64)}
+	radius := args[2].Value.(float64)
+	rows := &fakeRows{}
+	closest := radius
+	for _, r := range c.db.rows {
+		if d := geocoder.Haversine(point, r.location); d <= closest {
+			closest, rows.values = d, [][]byte{r.response}
+		}
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	values [][]byte
+}
+
+func (r *fakeRows) Columns() []string { return []string{"response"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func response(address string) *geocoder.GoogleResponse {
+	return &geocoder.GoogleResponse{
+		Status:  geocoder.GRS_OK,
+		Results: []*geocoder.ResultSet{{FormattedAddress: address, Types: []string{"street_address"}}},
+	}
+}
+
+func Test_New(t *testing.T) {
+	db, err := sql.Open("postgisfake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name   string
+		db     *sql.DB
+		table  string
+		radius float64
+		err    bool
+	}{
+		{"Plain table", db, "geocode_results", 100, false},
+		{"Schema qualified table", db, "geo.geocode_results", 0, false},
+		{"Empty db", nil, "geocode_results", 100, true},
+		{"Injected table name", db, "results; DROP TABLE users", 100, true},
+		{"Negative radius", db, "geocode_results", -1, true},
+	}
+
+	for _, tt := range tests {
+		if _, err := New(tt.db, tt.table, tt.radius); (err != nil) != tt.err {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func Test_Store(t *testing.T) {
+	s, db := openDB(t, "geo.geocode_results")
+	ctx := context.TODO()
+	if err := s.Save(ctx, 52.52, 13.405, response("Berlin")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(ctx, 52.5205, 13.405, response("Mitte")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+		expected *geocoder.GoogleResponse
+	}{
+		{"Closest within radius", 52.5204, 13.405, response("Mitte")},
+		{"Exact location", 52.52, 13.405, response("Berlin")},
+		{"Miss outside radius", 48.8566, 2.3522, nil},
+	}
+
+	for _, tt := range tests {
+		res, ok, err := s.Lookup(ctx, tt.lat, tt.lng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != (tt.expected != nil) || !reflect.DeepEqual(res, tt.expected) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v %v\nExpected:\n%v", tt.name, res, ok, tt.expected)
+		}
+	}
+	for _, query := range db.queries {
+		if !strings.Contains(query, " geo.geocode_results") {
+			t.Errorf("test for table name Failed - query without table %q", query)
+		}
+	}
+}
+
+func Test_StoreErrors(t *testing.T) {
+	s, db := openDB(t, "geocode_results")
+	ctx := context.TODO()
+	db.err = errors.New("connection refused")
+
+	if _, ok, err := s.Lookup(ctx, 1, 1); ok || !errors.Is(err, db.err) {
+		t.Errorf("test for lookup error Failed - results not match\nGot:\n%v %v\nExpected:\n%v", ok, err, db.err)
+	}
+	if err := s.Save(ctx, 1, 1, response("first")); !errors.Is(err, db.err) {
+		t.Errorf("test for save error Failed - results not match\nGot:\n%v\nExpected:\n%v", err, db.err)
+	}
+
+	db.err = nil
+	db.rows = append(db.rows, fakeRow{location: geocoder.Coordinate{Lat: 1, Lng: 1}, response: []byte("not json")})
+	if _, ok, err := s.Lookup(ctx, 1, 1); ok || err == nil {
+		t.Errorf("test for invalid stored response Failed - results not match\nGot:\n%v %v\nExpected:\nerror", ok, err)
+	}
+}
@@ -0,0 +1,64 @@
+package geocoder
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// Priority is the class of a request, see WithPriority
+type Priority string
+
+const (
+	// User-facing lookups, the default
+	PR_INTERACTIVE Priority = "interactive"
+	// Bulk work like backfills, limited to the share of the rate not reserved by WithInteractiveReserve
+	PR_BATCH Priority = "batch"
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context tagging the requests sent with it with priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom returns the priority of requests sent with ctx, PR_INTERACTIVE if ctx is not tagged
+func PriorityFrom(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PR_INTERACTIVE
+}
+
+// WithInteractiveReserve reserves share, between 0 and 1, of every limiter rate and burst for
+// PR_INTERACTIVE requests, so PR_BATCH requests can't starve them. Interactive requests may use
+// the whole rate. Nothing is reserved by default
+func WithInteractiveReserve(share float64) Option {
+	return func(g *Geocoder) {
+		g.interactiveReserve = share
+	}
+}
+
+// batchLimiter returns the limiter PR_BATCH requests of ctx wait for before limiter, nil if
+// ctx is interactive or nothing is reserved. Its rate follows the changes of the limiter rate
+func (g *Geocoder) batchLimiter(ctx context.Context, limiter *rate.Limiter) *rate.Limiter {
+	if g.interactiveReserve <= 0 || PriorityFrom(ctx) != PR_BATCH {
+		return nil
+	}
+	limit := limiter.Limit() * rate.Limit(1-g.interactiveReserve)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.batchLimiters == nil {
+		g.batchLimiters = make(map[*rate.Limiter]*rate.Limiter)
+	}
+	batch, ok := g.batchLimiters[limiter]
+	if !ok {
+		burst := max(1, int(float64(limiter.Burst())*(1-g.interactiveReserve)))
+		batch = rate.NewLimiter(limit, burst)
+		g.batchLimiters[limiter] = batch
+	} else if batch.Limit() != limit {
+		batch.SetLimitAt(g.clock.Now(), limit)
+	}
+	return batch
+}
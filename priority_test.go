@@ -0,0 +1,107 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Priority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority Priority
+		expected error
+	}{
+		{"interactive", PR_INTERACTIVE, nil},
+		{"batch", PR_BATCH, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		client := requesterFunc(func(targetURL string) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+		})
+		// burst of 4, one token is reserved for interactive requests
+		g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1, time.Hour, nil,
+			WithAPIKey("key"), WithBurst(4), WithInteractiveReserve(0.25))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := WithPriority(context.TODO(), tt.priority)
+		var got error
+		for i := 0; i < 4 && got == nil; i++ {
+			_, got = g.TryReverseGeocode(ctx, 52.52, 13.405+float64(i))
+		}
+		if !errors.Is(got, tt.expected) {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+		if tt.priority == PR_BATCH {
+			if _, err := g.TryReverseGeocode(context.TODO(), 52.52, 0); err != nil {
+				t.Errorf("test for %v Failed - reserved token not left to interactive requests %v", tt.name, err)
+			}
+		}
+	}
+}
+
+func Test_PriorityFrom(t *testing.T) {
+	if got := PriorityFrom(context.TODO()); got != PR_INTERACTIVE {
+		t.Errorf("test for untagged context Failed - results not match\nGot:\n%v\nExpected:\n%v", got, PR_INTERACTIVE)
+	}
+	if got := PriorityFrom(WithPriority(context.TODO(), PR_BATCH)); got != PR_BATCH {
+		t.Errorf("test for batch context Failed - results not match\nGot:\n%v\nExpected:\n%v", got, PR_BATCH)
+	}
+	if _, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 1, time.Hour, nil,
+		WithAPIKey("key"), WithInteractiveReserve(1)); err == nil {
+		t.Errorf("test for full reserve Failed - no error")
+	}
+}
+
+func Test_PriorityRefusedBatchToken(t *testing.T) {
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1, time.Hour, nil,
+		WithAPIKey("key"), WithBurst(4), WithInteractiveReserve(0.25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// interactive requests empty the Geocoder limiter, the batch limiter keeps its 3 tokens
+	for i := 0; i < 4; i++ {
+		if _, err := g.TryReverseGeocode(context.TODO(), 52.52, 13.405+float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.TryReverseGeocode(WithPriority(context.TODO(), PR_BATCH), 52.52, 0); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("test for refused batch request Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrRateLimited)
+	}
+	if tokens := g.batchLimiters[g.limiter].TokensAt(time.Now()); tokens < 2.5 {
+		t.Errorf("test for refused batch request Failed - batch token was consumed, %v left", tokens)
+	}
+}
+
+func Test_PriorityCanceledBatchToken(t *testing.T) {
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK","results":[]}`))}, nil
+	})
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1, time.Hour, nil,
+		WithAPIKey("key"), WithBurst(4), WithInteractiveReserve(0.25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// interactive requests empty the Geocoder limiter, the batch limiter keeps its 3 tokens
+	for i := 0; i < 4; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 52.52, 13.405+float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(WithPriority(context.TODO(), PR_BATCH), 100*time.Millisecond)
+	defer cancel()
+	if _, err := g.ReverseGeocode(ctx, 52.52, 0); !errors.Is(err, ErrWouldExceedDeadline) {
+		t.Errorf("test for refused batch request Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrWouldExceedDeadline)
+	}
+	if tokens := g.batchLimiters[g.limiter].TokensAt(time.Now()); tokens < 2.5 {
+		t.Errorf("test for refused batch request Failed - batch token was consumed, %v left", tokens)
+	}
+}
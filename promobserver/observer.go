@@ -0,0 +1,162 @@
+// Package promobserver implements geocoder.RequestObserver on top of the Prometheus client
+package promobserver
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Observer exposes geocoder request metrics. It is a prometheus.Collector,
+// register it in a prometheus.Registerer and pass it to geocoder.NewGeocoder
+type Observer struct {
+	duration     *prometheus.HistogramVec
+	statuses     *prometheus.CounterVec
+	limiterWaits *prometheus.HistogramVec
+	cacheHits    *prometheus.CounterVec
+	errors       *prometheus.CounterVec
+
+	cacheLookups   *prometheus.Desc
+	cacheEvictions *prometheus.Desc
+	cacheEntries   *prometheus.Desc
+	cacheHitRatio  *prometheus.Desc
+
+	mu         sync.Mutex
+	cacheStats map[string]func() geocoder.CacheStats
+}
+
+var (
+	_ geocoder.DetailedRequestObserver = (*Observer)(nil)
+	_ geocoder.CacheObserver           = (*Observer)(nil)
+)
+
+// New creates new instance of Observer. Metric names are prefixed with namespace
+func New(namespace string) *Observer {
+	return &Observer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "geocoder",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests to the geocoding provider.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"provider", "status", "attempt"}),
+		statuses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "geocoder",
+			Name:      "responses_total",
+			Help:      "Number of geocoding responses by response status.",
+		}, []string{"provider", "status"}),
+		limiterWaits: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "geocoder",
+			Name:      "limiter_wait_seconds",
+			Help:      "Time spent waiting for the rate limiter.",
+			Buckets:   []float64{0, .001, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"provider"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "geocoder",
+			Name:      "cache_hits_total",
+			Help:      "Number of responses served from cache.",
+		}, []string{"provider"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "geocoder",
+			Name:      "request_errors_total",
+			Help:      "Number of HTTP requests failed with transport or decoding errors.",
+		}, []string{"provider", "http_status"}),
+		cacheLookups: prometheus.NewDesc(prometheus.BuildFQName(namespace, "geocoder", "cache_lookups_total"),
+			"Number of cache lookups by result.", []string{"provider", "result"}, nil),
+		cacheEvictions: prometheus.NewDesc(prometheus.BuildFQName(namespace, "geocoder", "cache_evictions_total"),
+			"Number of entries evicted from the cache.", []string{"provider"}, nil),
+		cacheEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, "geocoder", "cache_entries"),
+			"Number of entries in the cache.", []string{"provider"}, nil),
+		cacheHitRatio: prometheus.NewDesc(prometheus.BuildFQName(namespace, "geocoder", "cache_hit_ratio"),
+			"Share of cache lookups served from the cache.", []string{"provider"}, nil),
+		cacheStats: make(map[string]func() geocoder.CacheStats),
+	}
+}
+
+// ObserveHTTPRequest records a request whose response status is unknown
+func (o *Observer) ObserveHTTPRequest(label string, duration time.Duration) {
+	o.ObserveRequest(geocoder.RequestInfo{Label: label, Duration: duration})
+}
+
+// ObserveRequest records request duration, response status and whether the request was a retry.
+// Cache hits and failed requests are counted separately
+func (o *Observer) ObserveRequest(info geocoder.RequestInfo) {
+	if info.Cached {
+		o.cacheHits.WithLabelValues(info.Label).Inc()
+		return
+	}
+	if info.Err != nil {
+		o.errors.WithLabelValues(info.Label, strconv.Itoa(info.HTTPStatusCode)).Inc()
+	}
+	status := statusLabel(info.Status)
+	o.duration.WithLabelValues(info.Label, status, attemptLabel(info.Attempt)).Observe(info.Duration.Seconds())
+	o.statuses.WithLabelValues(info.Label, status).Inc()
+}
+
+// ObserveLimiterWait records time spent waiting for the rate limiter
+func (o *Observer) ObserveLimiterWait(label string, wait time.Duration) {
+	o.limiterWaits.WithLabelValues(label).Observe(wait.Seconds())
+}
+
+// ObserveCacheStats registers the cache statistics of the geocoder labelled label,
+// they are polled on every collection
+func (o *Observer) ObserveCacheStats(label string, stats func() geocoder.CacheStats) {
+	o.mu.Lock()
+	o.cacheStats[label] = stats
+	o.mu.Unlock()
+}
+
+// Describe implements prometheus.Collector
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	o.duration.Describe(ch)
+	o.statuses.Describe(ch)
+	o.limiterWaits.Describe(ch)
+	o.cacheHits.Describe(ch)
+	o.errors.Describe(ch)
+	ch <- o.cacheLookups
+	ch <- o.cacheEvictions
+	ch <- o.cacheEntries
+	ch <- o.cacheHitRatio
+}
+
+// Collect implements prometheus.Collector
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	o.duration.Collect(ch)
+	o.statuses.Collect(ch)
+	o.limiterWaits.Collect(ch)
+	o.cacheHits.Collect(ch)
+	o.errors.Collect(ch)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for label, statsFunc := range o.cacheStats {
+		stats := statsFunc()
+		ch <- prometheus.MustNewConstMetric(o.cacheLookups, prometheus.CounterValue, float64(stats.Hits), label, "hit")
+		ch <- prometheus.MustNewConstMetric(o.cacheLookups, prometheus.CounterValue, float64(stats.Misses), label, "miss")
+		ch <- prometheus.MustNewConstMetric(o.cacheEvictions, prometheus.CounterValue, float64(stats.Evictions), label)
+		ch <- prometheus.MustNewConstMetric(o.cacheEntries, prometheus.GaugeValue, float64(stats.Entries), label)
+		ch <- prometheus.MustNewConstMetric(o.cacheHitRatio, prometheus.GaugeValue, stats.HitRatio, label)
+	}
+}
+
+// attemptLabel separates first attempts from retries, so that retries don't skew latency SLOs
+func attemptLabel(attempt int) string {
+	if attempt > 1 {
+		return "retry"
+	}
+	return "first"
+}
+
+func statusLabel(status geocoder.GoogleResponseStatus) string {
+	if status == "" {
+		return "unknown"
+	}
+	return string(status)
+}
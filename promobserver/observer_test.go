@@ -0,0 +1,62 @@
+package promobserver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Observer(t *testing.T) {
+	obs := New("test")
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(obs)
+
+	obs.ObserveRequest(geocoder.RequestInfo{Label: "google", Duration: time.Millisecond, Status: geocoder.GRS_OK, Attempt: 1})
+	obs.ObserveRequest(geocoder.RequestInfo{Label: "google", Duration: time.Millisecond, Status: geocoder.GRS_OK, Attempt: 2})
+	obs.ObserveHTTPRequest("google", time.Millisecond)
+	obs.ObserveLimiterWait("google", 0)
+	obs.ObserveRequest(geocoder.RequestInfo{Label: "google", Status: geocoder.GRS_OK, Cached: true})
+
+	expected := `
+# HELP test_geocoder_responses_total Number of geocoding responses by response status.
+# TYPE test_geocoder_responses_total counter
+test_geocoder_responses_total{provider="google",status="OK"} 2
+test_geocoder_responses_total{provider="google",status="unknown"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "test_geocoder_responses_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(obs); n != 7 {
+		t.Errorf("test for series count Failed - results not match\nGot:\n%v\nExpected:\n%v", n, 7)
+	}
+}
+
+func Test_ObserverCacheStats(t *testing.T) {
+	obs := New("test")
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(obs)
+	obs.ObserveCacheStats("google", func() geocoder.CacheStats {
+		return geocoder.CacheStats{Hits: 3, Misses: 1, Evictions: 2, Entries: 10, HitRatio: 0.75}
+	})
+
+	expected := `
+# HELP test_geocoder_cache_entries Number of entries in the cache.
+# TYPE test_geocoder_cache_entries gauge
+test_geocoder_cache_entries{provider="google"} 10
+# HELP test_geocoder_cache_hit_ratio Share of cache lookups served from the cache.
+# TYPE test_geocoder_cache_hit_ratio gauge
+test_geocoder_cache_hit_ratio{provider="google"} 0.75
+# HELP test_geocoder_cache_lookups_total Number of cache lookups by result.
+# TYPE test_geocoder_cache_lookups_total counter
+test_geocoder_cache_lookups_total{provider="google",result="hit"} 3
+test_geocoder_cache_lookups_total{provider="google",result="miss"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"test_geocoder_cache_entries", "test_geocoder_cache_hit_ratio", "test_geocoder_cache_lookups_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,173 @@
+package geocoder
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned when the daily quota of the credentials has been used up
+var ErrQuotaExhausted = errors.New("daily quota exhausted")
+
+// QuotaMode tells what the Geocoder does once the daily quota is exhausted
+type QuotaMode string
+
+const (
+	// Every request fails with ErrQuotaExhausted, cached responses are not served
+	QM_FAIL_FAST QuotaMode = "fail_fast"
+	// Cached responses are served, cache misses fail with ErrQuotaExhausted
+	QM_CACHE_ONLY QuotaMode = "cache_only"
+)
+
+// QuotaStats is the quota usage of a credential on a day
+type QuotaStats struct {
+	// Client ID, or API key with all but the last 4 characters masked
+	Credential string
+	// Date in YYYY-MM-DD format
+	Date      string
+	Used      int64
+	Limit     int64
+	Remaining int64
+}
+
+// QuotaTracker counts billable requests per credential per day against a daily limit.
+// It is safe for concurrent use
+type QuotaTracker struct {
+	limit    int64
+	location *time.Location
+
+	mu    sync.Mutex
+	clock Clock
+	used  map[string]*quotaDay
+}
+
+// quotaDay is the number of requests of a credential on date
+type quotaDay struct {
+	date string
+	used int64
+}
+
+// NewQuotaTracker creates new instance of QuotaTracker allowing dailyLimit requests per credential.
+// Days are split in the given location, Google resets quotas at midnight Pacific Time,
+// so use America/Los_Angeles to match it. Nil location means UTC
+func NewQuotaTracker(dailyLimit int64, location *time.Location) (*QuotaTracker, error) {
+	if dailyLimit <= 0 {
+		return nil, errors.New("dailyLimit must be a positive number")
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return &QuotaTracker{limit: dailyLimit, location: location, clock: systemClock{}, used: make(map[string]*quotaDay)}, nil
+}
+
+// WithQuota enforces the daily quota of tracker. Requests are counted per credential when sent,
+// pool credentials with exhausted quota are skipped. mode tells what happens once no credential
+// has quota left
+func WithQuota(tracker *QuotaTracker, mode QuotaMode) Option {
+	return func(g *Geocoder) {
+		g.quota = tracker
+		g.quotaMode = mode
+	}
+}
+
+// Remaining returns the number of requests credential may still make on the day of t
+func (q *QuotaTracker) Remaining(t time.Time, credential string) int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.limit - q.day(t, credential).used
+}
+
+// Budget returns the share of the daily quota of credential left, use it as DegradationPolicy.Budget.
+// The day is taken from the clock of the Geocoder q is passed to with WithQuota
+func (q *QuotaTracker) Budget(credential string) QuotaBudget {
+	return QuotaBudgetFunc(func() float64 {
+		q.mu.Lock()
+		now := q.clock.Now()
+		q.mu.Unlock()
+		return float64(q.Remaining(now, credential)) / float64(q.limit)
+	})
+}
+
+// useClock makes Budget take the day from clock
+func (q *QuotaTracker) useClock(clock Clock) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.clock = clock
+}
+
+// Stats returns the usage of the given credentials on the day of t, sorted by credential
+func (q *QuotaTracker) Stats(t time.Time, credentials ...string) []QuotaStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stats := make([]QuotaStats, 0, len(credentials))
+	for _, credential := range credentials {
+		day := q.day(t, credential)
+		stats = append(stats, QuotaStats{
+			Credential: credential,
+			Date:       day.date,
+			Used:       day.used,
+			Limit:      q.limit,
+			Remaining:  q.limit - day.used,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Credential < stats[j].Credential })
+	return stats
+}
+
+// take counts a request of credential made at t, it returns false without counting if the quota is exhausted
+func (q *QuotaTracker) take(t time.Time, credential string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	day := q.day(t, credential)
+	if day.used >= q.limit {
+		return false
+	}
+	day.used++
+	return true
+}
+
+// day returns the counter of credential for the day of t, resetting it when the day has changed
+func (q *QuotaTracker) day(t time.Time, credential string) *quotaDay {
+	date := t.In(q.location).Format(usageDateLayout)
+	day, ok := q.used[credential]
+	if !ok {
+		day = &quotaDay{}
+		q.used[credential] = day
+	}
+	if day.date != date {
+		day.date = date
+		day.used = 0
+	}
+	return day
+}
+
+// credentialName identifies the credential of b in quota stats without disclosing the API key
+func credentialName(b *RequestBuilder) string {
+	if b.BusinessKey != nil {
+		return b.BusinessKey.ClientID
+	}
+	if len(b.APIKey) <= 4 {
+		return strings.Repeat("*", len(b.APIKey))
+	}
+	return strings.Repeat("*", len(b.APIKey)-4) + b.APIKey[len(b.APIKey)-4:]
+}
+
+// quotaAvailable reports whether the credential of b may make a request now
+func (g *Geocoder) quotaAvailable(b *RequestBuilder) bool {
+	return g.quota == nil || g.quota.Remaining(g.clock.Now(), credentialName(b)) > 0
+}
+
+// quotaExhausted reports whether no credential of the Geocoder may make a request now
+func (g *Geocoder) quotaExhausted() bool {
+	if g.pool == nil {
+		return !g.quotaAvailable(&g.builder)
+	}
+	for _, m := range g.pool.members {
+		if !m.disabled.Load() && g.quotaAvailable(&m.builder) {
+			return false
+		}
+	}
+	return true
+}
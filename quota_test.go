@@ -0,0 +1,132 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_QuotaTrackerReset(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+	q, _ := NewQuotaTracker(2, la)
+	// 07:59 UTC is still the previous day in Los Angeles
+	before := time.Date(2024, 3, 1, 7, 59, 0, 0, time.UTC)
+	after := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		take     bool
+		expected int64
+	}{
+		{"first", before, true, 1},
+		{"second", before, true, 0},
+		{"exhausted", before, false, 0},
+		{"next day", after, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if took := q.take(tt.t, "client"); took != tt.take {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, took, tt.take)
+			}
+			if remaining := q.Remaining(tt.t, "client"); remaining != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, remaining, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_QuotaTrackerBudget(t *testing.T) {
+	clock := &manualClock{now: time.Date(2000, 1, 1, 23, 0, 0, 0, time.UTC)}
+	q, _ := NewQuotaTracker(4, nil)
+	if _, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 10, time.Second,
+		nil, WithAPIKey("key"), WithQuota(q, QM_FAIL_FAST), WithClock(clock)); err != nil {
+		t.Fatal(err)
+	}
+	q.take(clock.Now(), "client")
+	budget := q.Budget("client")
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		expected float64
+	}{
+		{"Same day", 0, 0.75},
+		{"Next day", 2 * time.Hour, 1},
+	}
+	for _, tt := range tests {
+		clock.Advance(tt.advance)
+		if remaining := budget.Remaining(); remaining != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, remaining, tt.expected)
+		}
+	}
+}
+
+func Test_QuotaModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     QuotaMode
+		expected error
+	}{
+		{"fail fast", QM_FAIL_FAST, ErrQuotaExhausted},
+		{"cache only", QM_CACHE_ONLY, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []string
+			q, _ := NewQuotaTracker(1, nil)
+			g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+				WithAPIKey("api-key-1234"), WithCache(NewMemoryCache(10, time.Hour)), WithQuota(q, tt.mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, tt.expected) {
+				t.Errorf("test for %v cached Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.expected)
+			}
+			if _, err := g.ReverseGeocode(context.TODO(), 2, 2); !errors.Is(err, ErrQuotaExhausted) {
+				t.Errorf("test for %v miss Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, ErrQuotaExhausted)
+			}
+			if len(used) != 1 {
+				t.Errorf("test for %v requests Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, len(used), 1)
+			}
+		})
+	}
+}
+
+func Test_QuotaPoolStats(t *testing.T) {
+	var used []string
+	q, _ := NewQuotaTracker(1, nil)
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+		WithCredentialPool(PS_ROUND_ROBIN, Credential{APIKey: "key-aaaa"}, Credential{APIKey: "key-bbbb"}),
+		WithQuota(q, QM_FAIL_FAST))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("test for exhausted pool Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrQuotaExhausted)
+	}
+
+	date := time.Now().UTC().Format(usageDateLayout)
+	expected := []QuotaStats{
+		{Credential: "****aaaa", Date: date, Used: 1, Limit: 1},
+		{Credential: "****bbbb", Date: date, Used: 1, Limit: 1},
+	}
+	if stats := g.Stats().Quota; !reflect.DeepEqual(stats, expected) {
+		t.Errorf("test for stats Failed - results not match\nGot:\n%v\nExpected:\n%v", stats, expected)
+	}
+}
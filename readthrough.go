@@ -0,0 +1,62 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+)
+
+// ResultStore is a shared persistent store of previously geocoded results, e.g. a PostGIS table.
+// Implementations decide how close a stored result must be to the queried coordinate
+type ResultStore interface {
+	// Lookup returns a stored response for the coordinate, found is false if there is none
+	Lookup(ctx context.Context, lat, lng float64) (res *GoogleResponse, found bool, err error)
+	// Save stores the response for the coordinate
+	Save(ctx context.Context, lat, lng float64, res *GoogleResponse) error
+}
+
+// ReadThrough serves reverse geocoding from a ResultStore, falling back to the live provider
+// and writing its OK responses back to the store
+type ReadThrough struct {
+	store        ResultStore
+	provider     ReverseGeocoder
+	onStoreError func(err error)
+}
+
+// NewReadThrough creates new instance of ReadThrough. Store failures never fail requests,
+// the provider is used instead and the error is passed to onStoreError if it is not nil
+func NewReadThrough(store ResultStore, provider ReverseGeocoder, onStoreError func(err error)) (*ReadThrough, error) {
+	if store == nil {
+		return nil, errors.New("empty ResultStore")
+	}
+	if provider == nil {
+		return nil, errors.New("empty provider")
+	}
+	return &ReadThrough{store: store, provider: provider, onStoreError: onStoreError}, nil
+}
+
+// ReverseGeocode returns the stored response for the coordinate or geocodes it using the provider
+func (r *ReadThrough) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	res, found, err := r.store.Lookup(ctx, lat, lng)
+	if err != nil {
+		r.storeError(err)
+	} else if found {
+		return res, nil
+	}
+
+	res, err = r.provider.ReverseGeocode(ctx, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	if res.Status == GRS_OK {
+		if err := r.store.Save(ctx, lat, lng, res); err != nil {
+			r.storeError(err)
+		}
+	}
+	return res, nil
+}
+
+func (r *ReadThrough) storeError(err error) {
+	if r.onStoreError != nil {
+		r.onStoreError(err)
+	}
+}
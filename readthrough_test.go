@@ -0,0 +1,58 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeResultStore struct {
+	saved     map[Coordinate]*GoogleResponse
+	lookupErr error
+}
+
+func (s *fakeResultStore) Lookup(ctx context.Context, lat, lng float64) (*GoogleResponse, bool, error) {
+	if s.lookupErr != nil {
+		return nil, false, s.lookupErr
+	}
+	res, ok := s.saved[Coordinate{Lat: lat, Lng: lng}]
+	return res, ok, nil
+}
+
+func (s *fakeResultStore) Save(ctx context.Context, lat, lng float64, res *GoogleResponse) error {
+	s.saved[Coordinate{Lat: lat, Lng: lng}] = res
+	return nil
+}
+
+type countingReverseGeocoder struct {
+	calls int
+}
+
+func (c *countingReverseGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	c.calls++
+	return &GoogleResponse{Status: GRS_OK}, nil
+}
+
+func Test_ReadThrough(t *testing.T) {
+	store := &fakeResultStore{saved: map[Coordinate]*GoogleResponse{}}
+	provider := &countingReverseGeocoder{}
+	var storeErrors []error
+	rt, _ := NewReadThrough(store, provider, func(err error) { storeErrors = append(storeErrors, err) })
+
+	for i := 0; i < 3; i++ {
+		if _, err := rt.ReverseGeocode(context.TODO(), 52.52, 13.40); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("test for read-through Failed - results not match\nGot:\n%v\nExpected:\n%v", provider.calls, 1)
+	}
+
+	store.lookupErr = errors.New("connection refused")
+	if _, err := rt.ReverseGeocode(context.TODO(), 52.52, 13.40); err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 2 || len(storeErrors) != 1 {
+		t.Errorf("test for store failure Failed - provider calls %v, store errors %v", provider.calls, storeErrors)
+	}
+}
@@ -0,0 +1,105 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// RequestBuilder builds signed requests to Google Maps web service endpoints without
+// executing them, for consumers with their own HTTP execution and retry stack
+type RequestBuilder struct {
+	// Endpoint URL, e.g. https://maps.googleapis.com/maps/api/geocode/json
+	Endpoint string
+	// Output language, leave empty to keep default behavior. A language in params takes precedence
+	Language string
+	// Google BusinessKey, requests are signed with its signing key
+	BusinessKey *BusinessKey
+	// Maps Platform API key, used if BusinessKey is nil
+	APIKey string
+	// Send APIKey in X-Goog-Api-Key header instead of the query string
+	APIKeyInHeader bool
+	// Signs URLs of BusinessKey requests, HMAC-SHA1 of the BusinessKey signing key if nil
+	Signer Signer
+}
+
+// URL returns the endpoint URL with params, language and credentials, signed if BusinessKey is set
+func (b *RequestBuilder) URL(params url.Values) (*url.URL, error) {
+	if b.BusinessKey == nil && b.APIKey == "" {
+		return nil, errors.New("empty BusinessKey and APIKey")
+	}
+	ur, err := url.Parse(b.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = append([]string(nil), v...)
+	}
+	if b.Language != "" && query.Get("language") == "" {
+		query.Set("language", b.Language)
+	}
+	if b.BusinessKey == nil {
+		if !b.APIKeyInHeader {
+			query.Set("key", b.APIKey)
+		}
+		ur.RawQuery = query.Encode()
+		return ur, nil
+	}
+
+	query.Set("client", b.BusinessKey.ClientID)
+	if b.BusinessKey.Channel != "" {
+		query.Set("channel", b.BusinessKey.Channel)
+	}
+
+	ur.RawQuery = query.Encode()
+
+	signature, err := b.signature(ur.Path + "?" + ur.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	if signature == "" {
+		return ur, nil
+	}
+
+	// the signature covers the query as encoded, so it is appended last without sorting the keys again
+	ur.RawQuery += "&signature=" + url.QueryEscape(signature)
+
+	return ur, nil
+}
+
+// Build returns a signed GET request with params
+func (b *RequestBuilder) Build(ctx context.Context, params url.Values) (*http.Request, error) {
+	ur, err := b.URL(params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ur.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	b.setHeaders(req)
+	return req, nil
+}
+
+// setHeaders adds credential headers to req
+func (b *RequestBuilder) setHeaders(req *http.Request) {
+	if b.BusinessKey == nil && b.APIKeyInHeader {
+		req.Header.Set(apiKeyHeader, b.APIKey)
+	}
+}
+
+// signature returns a signature of the targetURL using Signer or Google client's signing key
+func (b *RequestBuilder) signature(targetURL string) (string, error) {
+	signer := b.Signer
+	if signer == nil {
+		var err error
+		signer, err = NewHMACSHA1Signer(b.BusinessKey.SigningKey)
+		if err != nil {
+			return "", err
+		}
+	}
+	return signer.Sign(targetURL)
+}
@@ -0,0 +1,75 @@
+package geocoder
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_RequestBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		builder        RequestBuilder
+		expectedURL    string
+		expectedHeader string
+	}{
+		{
+			"Should sign business key request",
+			RequestBuilder{
+				Endpoint:    "https://maps.googleapis.com/maps/api/geocode/json",
+				Language:    "en",
+				BusinessKey: &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"},
+			},
+			"https://maps.googleapis.com/maps/api/geocode/json?channel=grg-local&client=my_test_client&language=en&latlng=45.32000000%2C12.67000000&sensor=false&signature=bdwh-bmlibC2w2N_A2tgt7pSuAE%3D",
+			"",
+		},
+		{
+			"Should put API key in header",
+			RequestBuilder{
+				Endpoint:       "https://maps.googleapis.com/maps/api/geocode/json",
+				APIKey:         "my_api_key",
+				APIKeyInHeader: true,
+			},
+			"https://maps.googleapis.com/maps/api/geocode/json?latlng=45.32000000%2C12.67000000&sensor=false",
+			"my_api_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := url.Values{"latlng": {"45.32000000,12.67000000"}, "sensor": {"false"}}
+			req, err := tt.builder.Build(context.TODO(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.URL.String() != tt.expectedURL {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, req.URL, tt.expectedURL)
+			}
+			if req.Header.Get("X-Goog-Api-Key") != tt.expectedHeader {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, req.Header.Get("X-Goog-Api-Key"), tt.expectedHeader)
+			}
+		})
+	}
+}
+
+func Test_RequestBuilderSignatureLast(t *testing.T) {
+	builder := RequestBuilder{
+		Endpoint:    "https://maps.googleapis.com/maps/api/staticmap",
+		BusinessKey: &BusinessKey{ClientID: "gme-x", SigningKey: "bXlfdGVzdF9rZXk="},
+	}
+	ur, err := builder.URL(url.Values{"center": {"52.52,13.405"}, "size": {"100x100"}, "zoom": {"10"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, signature, found := strings.Cut(ur.String(), "&signature=")
+	if !found || strings.Contains(signature, "&") {
+		t.Fatalf("test for signature position Failed - signature is not the last parameter of %v", ur)
+	}
+	if !strings.HasSuffix(unsigned, "&size=100x100&zoom=10") {
+		t.Errorf("test for signature position Failed - parameters after the signature in %v", ur)
+	}
+	if valid, err := VerifySignature("bXlfdGVzdF9rZXk=", ur.String()); err != nil || !valid {
+		t.Errorf("test for signature Failed - results not match\nGot:\n%v %v\nExpected:\ntrue", valid, err)
+	}
+}
@@ -0,0 +1,43 @@
+package geocoder
+
+import (
+	"fmt"
+	"io"
+)
+
+// defaultMaxResponseSize bounds response bodies unless WithMaxResponseSize says otherwise.
+// Geocoding responses are a few kilobytes
+const defaultMaxResponseSize = 4 << 20
+
+// ResponseTooLargeError is returned when the response body exceeds the limit set by WithMaxResponseSize
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds %d bytes", e.Limit)
+}
+
+// WithMaxResponseSize limits the size of response bodies read from Google, 4 MB by default.
+// Larger bodies, e.g. HTML pages of a misbehaving proxy, fail with ResponseTooLargeError
+// instead of being decoded into memory
+func WithMaxResponseSize(bytes int64) Option {
+	return func(g *Geocoder) {
+		if bytes > 0 {
+			g.maxResponseSize = bytes
+		}
+	}
+}
+
+// readBody reads body up to limit bytes, failing with ResponseTooLargeError if it is larger
+func readBody(body io.Reader, limit int64) ([]byte, error) {
+	// one byte past the limit tells a body of exactly limit bytes from a larger one
+	raw, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+	return raw, nil
+}
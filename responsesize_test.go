@@ -0,0 +1,44 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_MaxResponseSize(t *testing.T) {
+	okBody := `{"status":"OK"}`
+	tests := []struct {
+		name     string
+		body     string
+		limit    int64
+		expected error
+	}{
+		{"below limit", okBody, 100, nil},
+		{"exactly limit", okBody, int64(len(okBody)), nil},
+		{"html page", "<html>" + strings.Repeat("<p>proxy error</p>", 1000) + "</html>", 1024, &ResponseTooLargeError{Limit: 1024}},
+		{"padded json", okBody + strings.Repeat(" ", 100), 20, &ResponseTooLargeError{Limit: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := requesterFunc(func(string) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tt.body))}, nil
+			})
+			g, err := NewGeocoder(nil, "https://localhost", "en", client, 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithMaxResponseSize(tt.limit))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = g.ReverseGeocode(context.TODO(), 1, 1)
+			var tooLarge *ResponseTooLargeError
+			if tt.expected == nil && err != nil || tt.expected != nil && (!errors.As(err, &tooLarge) || tooLarge.Limit != tt.limit) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, err, tt.expected)
+			}
+		})
+	}
+}
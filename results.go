@@ -0,0 +1,80 @@
+package geocoder
+
+// HasType reports whether the result is of type typ, e.g. "street_address"
+func (r *ResultSet) HasType(typ string) bool {
+	return hasType(r.Types, typ)
+}
+
+// FilterTypes returns the results having any of types, in Google's order, e.g.
+//
+//	addresses := res.FilterTypes("street_address", "premise")
+func (r *GoogleResponse) FilterTypes(types ...string) []*ResultSet {
+	var filtered []*ResultSet
+	for _, res := range r.Results {
+		for _, typ := range types {
+			if res.HasType(typ) {
+				filtered = append(filtered, res)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// BestMatchOptions tunes GoogleResponse.BestMatch
+type BestMatchOptions struct {
+	// Result types preferred among results of equal confidence, street_address and premise if empty
+	PreferredTypes []string
+	// Query point used to break the remaining ties by distance, optional
+	Origin *Coordinate
+}
+
+// defaultPreferredTypes are the BestMatchOptions.PreferredTypes if none are given
+var defaultPreferredTypes = []string{"street_address", "premise"}
+
+// BestMatch returns the single most reliable result, nil if there are none. Results are compared by
+//  1. Confidence, i.e. ROOFTOP is preferred over RANGE_INTERPOLATED, GEOMETRIC_CENTER and APPROXIMATE,
+//     and partial matches are penalized
+//  2. having any of the preferred types
+//  3. distance from the origin, if given
+//
+// Results equal in all of them keep Google's order
+func (r *GoogleResponse) BestMatch(opts BestMatchOptions) *ResultSet {
+	preferred := opts.PreferredTypes
+	if len(preferred) == 0 {
+		preferred = defaultPreferredTypes
+	}
+	isPreferred := func(res *ResultSet) bool {
+		for _, typ := range preferred {
+			if res.HasType(typ) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var best *ResultSet
+	var bestConfidence, bestDistance float64
+	var bestPreferred bool
+	for _, res := range r.Results {
+		confidence, pref, distance := Confidence(res), isPreferred(res), 0.0
+		if opts.Origin != nil {
+			distance = Haversine(*opts.Origin, res.Geometry.Location)
+		}
+		switch {
+		case best == nil:
+		case confidence != bestConfidence:
+			if confidence < bestConfidence {
+				continue
+			}
+		case pref != bestPreferred:
+			if !pref {
+				continue
+			}
+		case distance >= bestDistance:
+			continue
+		}
+		best, bestConfidence, bestPreferred, bestDistance = res, confidence, pref, distance
+	}
+	return best
+}
@@ -0,0 +1,89 @@
+package geocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FilterTypes(t *testing.T) {
+	res := &GoogleResponse{Results: []*ResultSet{
+		{PlaceID: "address", Types: []string{"street_address"}},
+		{PlaceID: "route", Types: []string{"route"}},
+		{PlaceID: "premise", Types: []string{"establishment", "premise"}},
+		{PlaceID: "city", Types: []string{"locality", "political"}},
+	}}
+	tests := []struct {
+		name     string
+		types    []string
+		expected []string
+	}{
+		{"addresses", []string{"street_address", "premise"}, []string{"address", "premise"}},
+		{"political", []string{"political"}, []string{"city"}},
+		{"none", []string{"airport"}, nil},
+		{"no types", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range res.FilterTypes(tt.types...) {
+				got = append(got, r.PlaceID)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_BestMatch(t *testing.T) {
+	result := func(id, locationType string, partial bool, lat float64, types ...string) *ResultSet {
+		return &ResultSet{PlaceID: id, Types: types, PartialMatch: partial,
+			Geometry: Geometry{LocationType: locationType, Location: Coordinate{Lat: lat, Lng: 13.405}}}
+	}
+	origin := &Coordinate{Lat: 52.52, Lng: 13.405}
+	tests := []struct {
+		name     string
+		results  []*ResultSet
+		opts     BestMatchOptions
+		expected string
+	}{
+		{"rooftop first", []*ResultSet{
+			result("approximate", LT_APPROXIMATE, false, 52.52, "street_address"),
+			result("rooftop", LT_ROOFTOP, false, 52.6, "establishment"),
+		}, BestMatchOptions{}, "rooftop"},
+		{"partial match penalized", []*ResultSet{
+			result("partial", LT_ROOFTOP, true, 52.52, "street_address"),
+			result("interpolated", LT_RANGE_INTERPOLATED, false, 52.52, "street_address"),
+		}, BestMatchOptions{}, "interpolated"},
+		{"preferred type", []*ResultSet{
+			result("establishment", LT_ROOFTOP, false, 52.52, "establishment"),
+			result("premise", LT_ROOFTOP, false, 52.6, "premise"),
+		}, BestMatchOptions{}, "premise"},
+		{"custom preferred type", []*ResultSet{
+			result("premise", LT_ROOFTOP, false, 52.52, "premise"),
+			result("establishment", LT_ROOFTOP, false, 52.6, "establishment"),
+		}, BestMatchOptions{PreferredTypes: []string{"establishment"}}, "establishment"},
+		{"tie by distance", []*ResultSet{
+			result("far", LT_ROOFTOP, false, 52.6, "street_address"),
+			result("near", LT_ROOFTOP, false, 52.53, "street_address"),
+		}, BestMatchOptions{Origin: origin}, "near"},
+		{"tie keeps order", []*ResultSet{
+			result("first", LT_ROOFTOP, false, 52.6, "street_address"),
+			result("second", LT_ROOFTOP, false, 52.53, "street_address"),
+		}, BestMatchOptions{}, "first"},
+		{"no results", nil, BestMatchOptions{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if best := (&GoogleResponse{Results: tt.results}).BestMatch(tt.opts); best != nil {
+				got = best.PlaceID
+			}
+			if got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
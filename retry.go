@@ -0,0 +1,40 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WithRetries makes the Geocoder retry failed requests up to maxAttempts attempts in total.
+// The first retry is made after backoff, every next one doubles the delay.
+// Only transient failures are retried: network errors, HTTP 429 and 5xx statuses
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(g *Geocoder) {
+		if maxAttempts > 0 {
+			g.maxAttempts = maxAttempts
+		}
+		g.retryBackoff = backoff
+	}
+}
+
+// transportError wraps errors returned by HttpRequester, those are worth retrying
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string { return e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// isRetryable reports whether the failed attempt may succeed if repeated
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var te *transportError
+	if errors.As(err, &te) {
+		return true
+	}
+	var he *HTTPError
+	return errors.As(err, &he) && he.Retryable()
+}
@@ -0,0 +1,122 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyHttpRequester fails the first failures calls and then responds with responseBodyJSON.
+// Failures are HTTP responses with failureStatus, or network errors if it is zero
+type flakyHttpRequester struct {
+	mu               sync.Mutex
+	failures         int
+	failureStatus    int
+	responseBodyJSON string
+}
+
+func (c *flakyHttpRequester) Get(targetURL string) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		if c.failureStatus != 0 {
+			body := io.NopCloser(bytes.NewReader([]byte("<html>Bad Gateway</html>")))
+			return &http.Response{StatusCode: c.failureStatus, Body: body}, nil
+		}
+		return nil, errors.New("connection reset")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(c.responseBodyJSON)))}, nil
+}
+
+type recordingObserver struct {
+	infos []RequestInfo
+}
+
+func (o *recordingObserver) ObserveHTTPRequest(label string, duration time.Duration) {}
+func (o *recordingObserver) ObserveRequest(info RequestInfo) {
+	// durations and error values vary, those are checked elsewhere
+	info.Duration, info.Err = 0, nil
+	o.infos = append(o.infos, info)
+}
+func (o *recordingObserver) ObserveLimiterWait(label string, wait time.Duration) {}
+
+func Test_WithRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		failureStatus int
+		maxAttempts   int
+		expectedInfos []RequestInfo
+		expectError   bool
+	}{
+		{
+			"Should succeed after retries",
+			2,
+			0,
+			3,
+			[]RequestInfo{
+				{Label: "google", Attempt: 1},
+				{Label: "google", Attempt: 2},
+				{Label: "google", Attempt: 3, Final: true, Status: GRS_OK, HTTPStatusCode: http.StatusOK},
+			},
+			false,
+		},
+		{
+			"Should give up after max attempts",
+			5,
+			0,
+			2,
+			[]RequestInfo{
+				{Label: "google", Attempt: 1},
+				{Label: "google", Attempt: 2, Final: true},
+			},
+			true,
+		},
+		{
+			"Should retry 5xx",
+			1,
+			http.StatusBadGateway,
+			2,
+			[]RequestInfo{
+				{Label: "google", Attempt: 1, HTTPStatusCode: http.StatusBadGateway},
+				{Label: "google", Attempt: 2, Final: true, Status: GRS_OK, HTTPStatusCode: http.StatusOK},
+			},
+			false,
+		},
+		{
+			"Should not retry 403",
+			1,
+			http.StatusForbidden,
+			2,
+			[]RequestInfo{
+				{Label: "google", Attempt: 1, Final: true, HTTPStatusCode: http.StatusForbidden},
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := &recordingObserver{}
+			client := &flakyHttpRequester{failures: tt.failures, failureStatus: tt.failureStatus, responseBodyJSON: `{"status":"OK"}`}
+			bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+			geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second,
+				observer, WithRetries(tt.maxAttempts, time.Millisecond))
+
+			_, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070)
+			if (err != nil) != tt.expectError {
+				t.Errorf("test for %v Failed - unexpected error %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(observer.infos, tt.expectedInfos) {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%+v\nExpected:\n%+v", tt.name, observer.infos, tt.expectedInfos)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	// defaultRoadsURL is the base URL of the Roads API
+	defaultRoadsURL = "https://roads.googleapis.com/v1"
+	// maxRoadsPoints is the number of points the Roads API accepts per request
+	maxRoadsPoints = 100
+)
+
+// WithRoadsURL sends SnapToRoads and NearestRoads requests to url instead of the Google
+// base URL https://roads.googleapis.com/v1, e.g. to a proxy or a test server
+func WithRoadsURL(url string) Option {
+	return func(g *Geocoder) {
+		if url != "" {
+			g.roadsURL = strings.TrimSuffix(url, "/")
+		}
+	}
+}
+
+// SnappedPoint is a point on a road
+type SnappedPoint struct {
+	Location LatLng `json:"location"`
+	// Index of the input point the point is snapped from, nil for interpolated points
+	OriginalIndex *int   `json:"originalIndex,omitempty"`
+	PlaceID       string `json:"placeId"`
+}
+
+// roadsResponse is the response of the Roads API
+type roadsResponse struct {
+	SnappedPoints  []SnappedPoint `json:"snappedPoints"`
+	WarningMessage string         `json:"warningMessage,omitempty"`
+}
+
+// SnapToRoads returns the roads a GPS trace was most likely traveled along. With interpolate
+// the points needed to follow the road geometry smoothly are added. Paths longer than 100
+// points are sent in batches, each batch is a request limited by the EP_ROADS limiter.
+// It requires API key authentication, ErrAPIKeyRequired is returned without one, errors are
+// reported as HTTPError
+func (g *Geocoder) SnapToRoads(ctx context.Context, path []Coordinate, interpolate bool) ([]SnappedPoint, error) {
+	params := url.Values{}
+	if interpolate {
+		params.Set("interpolate", "true")
+	}
+	return g.roads(ctx, "snapToRoads", "path", OP_SNAP_TO_ROADS, path, params)
+}
+
+// NearestRoads returns the nearest road segment of every point, the points don't need to form
+// a path. Points off any road are missing in the result. Batching and limiting are the same as
+// in SnapToRoads
+func (g *Geocoder) NearestRoads(ctx context.Context, points []Coordinate) ([]SnappedPoint, error) {
+	return g.roads(ctx, "nearestRoads", "points", OP_NEAREST_ROADS, points, url.Values{})
+}
+
+// roads sends points to the Roads API method in batches and merges the snapped points,
+// original indexes are relative to points
+func (g *Geocoder) roads(ctx context.Context, method, param string, op Operation, points []Coordinate, params url.Values) ([]SnappedPoint, error) {
+	if len(points) == 0 {
+		return nil, errors.New("no points")
+	}
+	for _, p := range points {
+		if err := validateCoordinates(p.Lat, p.Lng); err != nil {
+			return nil, err
+		}
+	}
+	api := &mapsAPI{usage: UsageAPIRoads, rest: true, url: g.roadsURL + "/" + method}
+
+	var snapped []SnappedPoint
+	for start := 0; start < len(points); start += maxRoadsPoints {
+		end := min(start+maxRoadsPoints, len(points))
+		encoded := make([]string, 0, end-start)
+		for _, p := range points[start:end] {
+			encoded = append(encoded, formatLatLng(p.Lat, p.Lng, g.precision))
+		}
+		query := url.Values{param: {strings.Join(encoded, "|")}}
+		for k, v := range params {
+			query[k] = v
+		}
+
+		res := &roadsResponse{}
+		if _, err := g.callAPI(ctx, &request{op: op, endpoint: EP_ROADS, api: api, params: query}, res); err != nil {
+			return snapped, err
+		}
+		for _, p := range res.SnappedPoints {
+			if p.OriginalIndex != nil {
+				index := *p.OriginalIndex + start
+				p.OriginalIndex = &index
+			}
+			snapped = append(snapped, p)
+		}
+	}
+	return snapped, nil
+}
@@ -0,0 +1,92 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roadsClient snaps every requested point to itself, adding an interpolated point after
+// each if requested
+func roadsClient(requests *[]*url.URL) HttpRequester {
+	return requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		*requests = append(*requests, u)
+		param := "path"
+		if strings.HasSuffix(u.Path, "/nearestRoads") {
+			param = "points"
+		}
+		var points []string
+		for i, p := range strings.Split(u.Query().Get(param), "|") {
+			lat, lng, err := ParseLatLng(p)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, fmt.Sprintf(`{"location":{"latitude":%v,"longitude":%v},"originalIndex":%d,"placeId":"p%d"}`, lat, lng, i, i))
+			if u.Query().Get("interpolate") == "true" {
+				points = append(points, fmt.Sprintf(`{"location":{"latitude":%v,"longitude":%v},"placeId":"i%d"}`, lat, lng, i))
+			}
+		}
+		body := `{"snappedPoints":[` + strings.Join(points, ",") + `]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func Test_Roads(t *testing.T) {
+	trace := make([]Coordinate, 150)
+	for i := range trace {
+		trace[i] = Coordinate{Lat: 52.5 + float64(i)/1000, Lng: 13.4}
+	}
+	tests := []struct {
+		name        string
+		call        func(g *Geocoder) ([]SnappedPoint, error)
+		path        string
+		snapped     int
+		lastIndex   int
+		interpolate string
+	}{
+		{"snap to roads", func(g *Geocoder) ([]SnappedPoint, error) { return g.SnapToRoads(context.TODO(), trace, false) },
+			"/v1/snapToRoads", 150, 149, ""},
+		{"interpolated", func(g *Geocoder) ([]SnappedPoint, error) { return g.SnapToRoads(context.TODO(), trace, true) },
+			"/v1/snapToRoads", 300, 149, "true"},
+		{"nearest roads", func(g *Geocoder) ([]SnappedPoint, error) { return g.NearestRoads(context.TODO(), trace) },
+			"/v1/nearestRoads", 150, 149, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*url.URL
+			g, err := NewGeocoder(nil, "https://localhost", "en", roadsClient(&requests), 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithRoadsURL("https://roads.localhost/v1/"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			snapped, err := tt.call(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(requests) != 2 || requests[0].Host != "roads.localhost" || requests[0].Path != tt.path ||
+				requests[0].Query().Get("interpolate") != tt.interpolate || requests[0].Query().Has("language") {
+				t.Errorf("test for %v Failed - unexpected requests %v", tt.name, requests)
+			}
+			var last int
+			for _, p := range snapped {
+				if p.OriginalIndex != nil {
+					last = *p.OriginalIndex
+				}
+			}
+			if len(snapped) != tt.snapped || last != tt.lastIndex {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v points, last index %v\nExpected:\n%v points, last index %v",
+					tt.name, len(snapped), last, tt.snapped, tt.lastIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+)
+
+// ReverseGeocoder is implemented by anything able to reverse geocode a coordinate,
+// e.g. Geocoder or Router
+type ReverseGeocoder interface {
+	ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error)
+}
+
+// ForwardGeocoder is implemented by anything able to geocode an address, e.g. Geocoder
+type ForwardGeocoder interface {
+	Geocode(ctx context.Context, address string) (*GoogleResponse, error)
+}
+
+// RouteRule sends requests for coordinates inside any of its Regions to Target
+type RouteRule struct {
+	// Name of the rule, e.g. "yandex-ru"
+	Name string
+	// Regions covered by the rule. A region may cross the antimeridian
+	Regions []Bounds
+	// Provider or channel serving the covered regions
+	Target ReverseGeocoder
+}
+
+// Router dispatches reverse geocoding requests to different providers or channels
+// depending on where the queried coordinate is located
+type Router struct {
+	rules    []RouteRule
+	fallback ReverseGeocoder
+}
+
+// NewRouter creates new instance of Router. Rules are evaluated in the given order,
+// the first matching rule wins. Coordinates not matched by any rule go to fallback
+func NewRouter(fallback ReverseGeocoder, rules ...RouteRule) (*Router, error) {
+	if fallback == nil {
+		return nil, errors.New("empty fallback ReverseGeocoder")
+	}
+	for _, rule := range rules {
+		if rule.Target == nil {
+			return nil, errors.New("empty Target in route rule " + rule.Name)
+		}
+		if len(rule.Regions) == 0 {
+			return nil, errors.New("no regions in route rule " + rule.Name)
+		}
+	}
+	return &Router{rules: rules, fallback: fallback}, nil
+}
+
+// Route returns the ReverseGeocoder responsible for the coordinate
+func (r *Router) Route(lat, lng float64) ReverseGeocoder {
+	for _, rule := range r.rules {
+		for _, region := range rule.Regions {
+			if region.Contains(lat, lng) {
+				return rule.Target
+			}
+		}
+	}
+	return r.fallback
+}
+
+// ReverseGeocode makes reverse geocoding using the provider chosen by Route
+func (r *Router) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	return r.Route(lat, lng).ReverseGeocode(ctx, lat, lng)
+}
+
+// Contains reports whether the coordinate lies within the bounds.
+// Bounds with SouthWest.Lng greater than NorthEast.Lng are treated as crossing the antimeridian
+func (b Bounds) Contains(lat, lng float64) bool {
+	if lat < b.SouthWest.Lat || lat > b.NorthEast.Lat {
+		return false
+	}
+	if b.SouthWest.Lng <= b.NorthEast.Lng {
+		return lng >= b.SouthWest.Lng && lng <= b.NorthEast.Lng
+	}
+	return lng >= b.SouthWest.Lng || lng <= b.NorthEast.Lng
+}
@@ -0,0 +1,56 @@
+package geocoder
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeReverseGeocoder struct {
+	name string
+}
+
+func (f *fakeReverseGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*GoogleResponse, error) {
+	return &GoogleResponse{Status: GoogleResponseStatus(f.name)}, nil
+}
+
+func Test_Router(t *testing.T) {
+	yandex := &fakeReverseGeocoder{name: "yandex"}
+	google := &fakeReverseGeocoder{name: "google"}
+	router, err := NewRouter(google, RouteRule{
+		Name: "yandex-ru-ua",
+		Regions: []Bounds{
+			// Russia crosses the antimeridian
+			{SouthWest: Coordinate{Lat: 41.18, Lng: 19.64}, NorthEast: Coordinate{Lat: 81.86, Lng: -169.05}},
+			{SouthWest: Coordinate{Lat: 44.38, Lng: 22.14}, NorthEast: Coordinate{Lat: 52.38, Lng: 40.23}},
+		},
+		Target: yandex,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		lat      float64
+		lng      float64
+		expected GoogleResponseStatus
+	}{
+		{"Moscow", 55.75, 37.62, "yandex"},
+		{"Kyiv", 50.45, 30.52, "yandex"},
+		{"Chukotka east of antimeridian", 65.0, -172.0, "yandex"},
+		{"Berlin", 52.52, 13.40, "google"},
+		{"New York", 40.71, -74.00, "google"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := router.ReverseGeocode(context.TODO(), tt.lat, tt.lng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Status != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res.Status, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Package server exposes a Geocoder over HTTP, so that services share one set of credentials,
+// one cache and one rate limiter instead of embedding keys
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+// Geocoder is implemented by geocoder.Geocoder
+type Geocoder interface {
+	geocoder.ReverseGeocoder
+	geocoder.ForwardGeocoder
+}
+
+// Response is the normalized JSON body of all endpoints
+type Response struct {
+	Status  geocoder.GoogleResponseStatus `json:"status"`
+	Results []Result                      `json:"results"`
+	// LOCALITY or FALLBACK if served under quota pressure
+	Degradation geocoder.DegradationLevel `json:"degradation,omitempty"`
+	// Reason of a failed request
+	Error string `json:"error,omitempty"`
+}
+
+// Result is a normalized geocoding result
+type Result struct {
+	FormattedAddress string              `json:"formatted_address"`
+	PlaceID          string              `json:"place_id"`
+	Location         geocoder.Coordinate `json:"location"`
+	LocationType     string              `json:"location_type"`
+	Types            []string            `json:"types"`
+	PartialMatch     bool                `json:"partial_match,omitempty"`
+	// Components by their first type, e.g. "locality"
+	Components map[string]Component `json:"components"`
+}
+
+// Component is a normalized address component
+type Component struct {
+	Name      string `json:"name"`
+	ShortName string `json:"short_name"`
+}
+
+// Server serves /v1/reverse?lat=&lng= and /v1/geocode?address=
+type Server struct {
+	geocoder Geocoder
+	logger   *slog.Logger
+	mux      *http.ServeMux
+}
+
+// New creates new instance of Server. logger may be nil
+func New(g Geocoder, logger *slog.Logger) (*Server, error) {
+	if g == nil {
+		return nil, errors.New("empty Geocoder")
+	}
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	s := &Server{geocoder: g, logger: logger, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/v1/reverse", s.reverse)
+	s.mux.HandleFunc("/v1/geocode", s.geocode)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) reverse(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.fail(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	lat, errLat := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	lng, errLng := strconv.ParseFloat(r.URL.Query().Get("lng"), 64)
+	if errLat != nil || errLng != nil || math.Abs(lat) > 90 || math.Abs(lng) > 180 {
+		s.fail(w, r, http.StatusBadRequest, errors.New("lat and lng must be valid coordinates"))
+		return
+	}
+	res, err := s.geocoder.ReverseGeocode(r.Context(), lat, lng)
+	s.respond(w, r, res, err)
+}
+
+func (s *Server) geocode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.fail(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	address := r.URL.Query().Get("address")
+	if address == "" {
+		s.fail(w, r, http.StatusBadRequest, errors.New("address is required"))
+		return
+	}
+	res, err := s.geocoder.Geocode(r.Context(), address)
+	s.respond(w, r, res, err)
+}
+
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, res *geocoder.GoogleResponse, err error) {
+	if err != nil {
+		s.fail(w, r, errorStatus(w, err), err)
+		return
+	}
+	body := Response{Status: res.Status, Results: make([]Result, 0, len(res.Results)), Degradation: res.Degradation}
+	for _, rs := range res.Results {
+		body.Results = append(body.Results, normalize(rs))
+	}
+	s.write(w, http.StatusOK, body)
+}
+
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, code int, err error) {
+	s.logger.WarnContext(r.Context(), "geocoding request failed",
+		slog.String("path", r.URL.Path), slog.Int("code", code), slog.String("error", err.Error()))
+	s.write(w, code, Response{Results: []Result{}, Error: err.Error()})
+}
+
+func (s *Server) write(w http.ResponseWriter, code int, body Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
+
+// errorStatus maps a Geocoder error to an HTTP status, setting Retry-After where known
+func errorStatus(w http.ResponseWriter, err error) int {
+	var quotaErr *geocoder.QuotaError
+	var httpErr *geocoder.HTTPError
+	switch {
+	case errors.As(err, &quotaErr):
+		setRetryAfter(w, time.Until(quotaErr.Until))
+		return http.StatusServiceUnavailable
+	case errors.Is(err, geocoder.ErrCoolingDown), errors.Is(err, geocoder.ErrRateLimited), errors.Is(err, geocoder.ErrQuotaExhausted),
+		errors.Is(err, geocoder.ErrCircuitOpen):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, geocoder.ErrInvalidCoordinates):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &httpErr):
+		setRetryAfter(w, httpErr.RetryAfter)
+	}
+	return http.StatusBadGateway
+}
+
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	if d > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+}
+
+func normalize(rs *geocoder.ResultSet) Result {
+	res := Result{
+		FormattedAddress: rs.FormattedAddress,
+		PlaceID:          rs.PlaceID,
+		Location:         rs.Geometry.Location,
+		LocationType:     rs.Geometry.LocationType,
+		Types:            rs.Types,
+		PartialMatch:     rs.PartialMatch,
+		Components:       make(map[string]Component, len(rs.AddressComponents)),
+	}
+	for _, c := range rs.AddressComponents {
+		if len(c.Types) == 0 {
+			continue
+		}
+		if _, ok := res.Components[c.Types[0]]; !ok {
+			res.Components[c.Types[0]] = Component{Name: c.LongName, ShortName: c.ShortName}
+		}
+	}
+	return res
+}
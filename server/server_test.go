@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+type fakeGeocoder struct{}
+
+func (fakeGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*geocoder.GoogleResponse, error) {
+	if lat == 0 {
+		return nil, &geocoder.QuotaError{Response: &geocoder.GoogleResponse{Status: geocoder.GRS_OVER_QUERY_LIMIT}, Until: time.Now().Add(2 * time.Second)}
+	}
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{
+		FormattedAddress: "Unter den Linden 1, Berlin",
+		PlaceID:          "ChIJ",
+		Geometry:         geocoder.Geometry{Location: geocoder.Coordinate{Lat: lat, Lng: lng}, LocationType: geocoder.LT_ROOFTOP},
+		Types:            []string{"street_address"},
+		AddressComponents: []geocoder.AddressComponent{
+			{LongName: "Berlin", ShortName: "Berlin", Types: []string{"locality", "political"}},
+			{LongName: "Germany", ShortName: "DE", Types: []string{"country", "political"}},
+		},
+	}}}, nil
+}
+
+func (fakeGeocoder) Geocode(ctx context.Context, address string) (*geocoder.GoogleResponse, error) {
+	return &geocoder.GoogleResponse{Status: geocoder.GRS_ZERO_RESULTS}, nil
+}
+
+func Test_Server(t *testing.T) {
+	s, err := New(fakeGeocoder{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			"reverse",
+			"/v1/reverse?lat=52.5&lng=13.4",
+			http.StatusOK,
+			`{"status":"OK","results":[{"formatted_address":"Unter den Linden 1, Berlin","place_id":"ChIJ","location":{"lat":52.5,"lng":13.4},"location_type":"ROOFTOP","types":["street_address"],"components":{"country":{"name":"Germany","short_name":"DE"},"locality":{"name":"Berlin","short_name":"Berlin"}}}]}`,
+		},
+		{
+			"geocode",
+			"/v1/geocode?address=nowhere",
+			http.StatusOK,
+			`{"status":"ZERO_RESULTS","results":[]}`,
+		},
+		{
+			"invalid coordinate",
+			"/v1/reverse?lat=91&lng=13.4",
+			http.StatusBadRequest,
+			`{"status":"","results":[],"error":"lat and lng must be valid coordinates"}`,
+		},
+		{
+			"missing address",
+			"/v1/geocode",
+			http.StatusBadRequest,
+			`{"status":"","results":[],"error":"address is required"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			body, _ := io.ReadAll(w.Body)
+			if w.Code != tt.expectedCode || strings.TrimSpace(string(body)) != tt.expectedBody {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v %s\nExpected:\n%v %s", tt.name, w.Code, body, tt.expectedCode, tt.expectedBody)
+			}
+		})
+	}
+}
+
+func Test_ServerQuota(t *testing.T) {
+	s, err := New(fakeGeocoder{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/reverse?lat=0&lng=0", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "2" {
+		t.Errorf("test for quota Failed - results not match\nGot:\n%v %v\nExpected:\n%v %v", w.Code, w.Header().Get("Retry-After"), http.StatusServiceUnavailable, "2")
+	}
+}
@@ -0,0 +1,130 @@
+package geocoder
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"net/url"
+	"strings"
+)
+
+// Signer signs request URLs of a BusinessKey
+type Signer interface {
+	// Sign returns the signature of the URL path with query, an empty signature leaves the URL unsigned
+	Sign(pathAndQuery string) (string, error)
+}
+
+// hmacSigner signs with HMAC of a base64url encoded key, encoding signatures in base64url like Google
+type hmacSigner struct {
+	hash func() hash.Hash
+	key  []byte
+}
+
+// NewHMACSHA1Signer creates a Signer using HMAC-SHA1 as required by Google Maps Platform.
+// signingKey is base64url encoded
+func NewHMACSHA1Signer(signingKey string) (Signer, error) {
+	return newHMACSigner(sha1.New, signingKey)
+}
+
+// NewHMACSHA256Signer creates a Signer using HMAC-SHA256, for backends with stricter crypto policies.
+// signingKey is base64url encoded
+func NewHMACSHA256Signer(signingKey string) (Signer, error) {
+	return newHMACSigner(sha256.New, signingKey)
+}
+
+func newHMACSigner(h func() hash.Hash, signingKey string) (*hmacSigner, error) {
+	sKey := strings.ReplaceAll(signingKey, "-", "+")
+	sKey = strings.ReplaceAll(sKey, "_", "/")
+
+	key, err := base64.StdEncoding.DecodeString(sKey)
+	if err != nil {
+		return nil, err
+	}
+	return &hmacSigner{hash: h, key: key}, nil
+}
+
+// Sign implements Signer
+func (s *hmacSigner) Sign(pathAndQuery string) (string, error) {
+	h := hmac.New(s.hash, s.key)
+	if _, err := h.Write([]byte(pathAndQuery)); err != nil {
+		return "", err
+	}
+
+	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	signature = strings.ReplaceAll(signature, "+", "-")
+	signature = strings.ReplaceAll(signature, "/", "_")
+
+	return signature, nil
+}
+
+// SignURL signs a Google Maps Platform URL, e.g. of Static Maps or Street View, with the base64url
+// encoded signingKey of a client ID. rawURL is either an absolute URL or a path with query,
+// it is returned with the signature parameter appended
+func SignURL(signingKey, rawURL string) (string, error) {
+	ur, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	signer, err := NewHMACSHA1Signer(signingKey)
+	if err != nil {
+		return "", err
+	}
+	signature, err := signer.Sign(ur.EscapedPath() + "?" + ur.RawQuery)
+	if err != nil {
+		return "", err
+	}
+	separator := "&"
+	if ur.RawQuery == "" {
+		separator = "?"
+	}
+	return rawURL + separator + "signature=" + signature, nil
+}
+
+// VerifySignature reports whether the signature parameter of signedURL is valid for signingKey.
+// The signature covers the path and the query without the signature parameter
+func VerifySignature(signingKey, signedURL string) (bool, error) {
+	ur, err := url.Parse(signedURL)
+	if err != nil {
+		return false, err
+	}
+	var params []string
+	signature := ""
+	for _, p := range strings.Split(ur.RawQuery, "&") {
+		if strings.HasPrefix(p, "signature=") {
+			if signature, err = url.QueryUnescape(strings.TrimPrefix(p, "signature=")); err != nil {
+				return false, err
+			}
+			continue
+		}
+		params = append(params, p)
+	}
+	if signature == "" {
+		return false, nil
+	}
+	signer, err := NewHMACSHA1Signer(signingKey)
+	if err != nil {
+		return false, err
+	}
+	expected, err := signer.Sign(ur.EscapedPath() + "?" + strings.Join(params, "&"))
+	if err != nil {
+		return false, err
+	}
+	return hmac.Equal([]byte(signature), []byte(expected)), nil
+}
+
+// NoopSigner leaves URLs unsigned, e.g. for backends authenticating by client ID or API key only
+type NoopSigner struct{}
+
+// Sign implements Signer
+func (NoopSigner) Sign(string) (string, error) {
+	return "", nil
+}
+
+// WithSigner makes the Geocoder sign URLs with s instead of HMAC-SHA1 of the BusinessKey signing key
+func WithSigner(s Signer) Option {
+	return func(g *Geocoder) {
+		g.builder.Signer = s
+	}
+}
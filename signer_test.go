@@ -0,0 +1,108 @@
+package geocoder
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func Test_Signer(t *testing.T) {
+	sha1Signer, err := NewHMACSHA1Signer("bXlfdGVzdF9rZXk=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha256Signer, err := NewHMACSHA256Signer("bXlfdGVzdF9rZXk=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		signer   Signer
+		expected string
+	}{
+		{"SHA-1", sha1Signer, "f6fJxrkXIqBTPvCfq1rVeXCD19Y="},
+		{"SHA-256", sha256Signer, "wNwXsUjhinpaeAk-IqO0asftkhLVUWMWzO6q3h6F6zQ="},
+		{"no-op", NoopSigner{}, ""},
+	}
+	for _, tt := range tests {
+		b := RequestBuilder{
+			Endpoint:    "https://maps.googleapis.com/maps/api/geocode/json",
+			BusinessKey: &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"},
+			Signer:      tt.signer,
+		}
+		query := url.Values{}
+		query.Add("latlng", "-6.48358100,106.85460100")
+		query.Add("sensor", "false")
+		req, err := b.Build(context.TODO(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := req.URL.Query().Get("signature"); got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+
+	if _, err := NewHMACSHA256Signer("not base64!"); err == nil {
+		t.Errorf("test for invalid key Failed - expected an error")
+	}
+}
+
+func Test_SignURL(t *testing.T) {
+	// example of the Google Maps Platform digital signature documentation
+	const key = "vNIXE0xscrmjlyV-12Nj_BvUPaw="
+	tests := []struct {
+		name     string
+		rawURL   string
+		expected string
+	}{
+		{
+			"absolute URL",
+			"https://maps.googleapis.com/maps/api/geocode/json?address=New+York&client=clientID",
+			"https://maps.googleapis.com/maps/api/geocode/json?address=New+York&client=clientID&signature=chaRF2hTJKOScPr-RQCEhZbSzIE=",
+		},
+		{
+			"path",
+			"/maps/api/geocode/json?address=New+York&client=clientID",
+			"/maps/api/geocode/json?address=New+York&client=clientID&signature=chaRF2hTJKOScPr-RQCEhZbSzIE=",
+		},
+	}
+	for _, tt := range tests {
+		got, err := SignURL(key, tt.rawURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+		valid, err := VerifySignature(key, got)
+		if err != nil || !valid {
+			t.Errorf("test for %v verification Failed - valid %v, error %v", tt.name, valid, err)
+		}
+	}
+
+	for _, signedURL := range []string{
+		"/maps/api/geocode/json?address=Boston&client=clientID&signature=chaRF2hTJKOScPr-RQCEhZbSzIE=",
+		"/maps/api/geocode/json?address=New+York&client=clientID",
+	} {
+		if valid, err := VerifySignature(key, signedURL); err != nil || valid {
+			t.Errorf("test for %v Failed - valid %v, error %v", signedURL, valid, err)
+		}
+	}
+}
+
+func Test_VerifySignatureOfBuilder(t *testing.T) {
+	b := RequestBuilder{
+		Endpoint:    "https://maps.googleapis.com/maps/api/geocode/json",
+		BusinessKey: &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk="},
+	}
+	query := url.Values{}
+	query.Add("address", "Unter den Linden 1, Berlin")
+	ur, err := b.URL(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, err := VerifySignature("bXlfdGVzdF9rZXk=", ur.String()); err != nil || !valid {
+		t.Errorf("test for %v Failed - valid %v, error %v", ur, valid, err)
+	}
+}
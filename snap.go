@@ -0,0 +1,57 @@
+package geocoder
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// defaultCoordinatePrecision is the number of decimals coordinates are formatted with in requests
+	defaultCoordinatePrecision = 8
+	// maxSnapDecimals is the largest precision of WithCoordinateSnapping and WithCoordinatePrecision
+	maxSnapDecimals = 8
+)
+
+// WithCoordinatePrecision sets the number of decimal places of coordinates in request URLs
+// and cache keys, 8 by default. 6 decimals are about 11 cm, already beyond what geocoding
+// resolves, and let nearby coordinates share the cache
+func WithCoordinatePrecision(decimals int) Option {
+	return func(g *Geocoder) {
+		if decimals < 0 || decimals > maxSnapDecimals {
+			return
+		}
+		g.precision = decimals
+	}
+}
+
+// WithCoordinateSnapping rounds coordinates of reverse geocoding to the given number of decimal
+// places before the request URL and the cache key are built, so that GPS jitter doesn't defeat
+// caching: 5 decimals are about 1 m, 4 about 11 m. GoogleResponse.QueryKey reports the snapped key
+func WithCoordinateSnapping(decimals int) Option {
+	return func(g *Geocoder) {
+		if decimals < 0 || decimals > maxSnapDecimals {
+			return
+		}
+		g.snapDecimals = decimals
+		g.snap = true
+	}
+}
+
+// snapCoordinate rounds lat, lng to decimals decimal places
+func snapCoordinate(lat, lng float64, decimals int) (float64, float64) {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(lat*scale) / scale, math.Round(lng*scale) / scale
+}
+
+// formatLatLng formats lat, lng with decimals decimal places as "lat,lng"
+func formatLatLng(lat, lng float64, decimals int) string {
+	return strconv.FormatFloat(lat, 'f', decimals, 64) + "," + strconv.FormatFloat(lng, 'f', decimals, 64)
+}
+
+// latLngParams returns the query of reverse geocoding lat, lng
+func latLngParams(lat, lng float64, decimals int) url.Values {
+	query := url.Values{}
+	query.Add("latlng", formatLatLng(lat, lng, decimals))
+	return query
+}
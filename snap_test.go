@@ -0,0 +1,100 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_CoordinateSnapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		decimals int
+		lat      float64
+		lng      float64
+		expected string
+	}{
+		{"5 decimals", 5, 52.5200049, 13.4049951, "latlng:52.52000000,13.40500000"},
+		{"3 decimals", 3, -33.86785, 151.20732, "latlng:-33.86800000,151.20700000"},
+		{"8 decimals", 8, 52.5200049, 13.4049951, "latlng:52.52000490,13.40499510"},
+		{"disabled", -1, 52.5200049, 13.4049951, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []string
+			g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+				WithAPIKey("key"), WithCoordinateSnapping(tt.decimals))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := g.ReverseGeocode(context.TODO(), tt.lat, tt.lng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.QueryKey != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, res.QueryKey, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_CoordinateSnappingCache(t *testing.T) {
+	var used []string
+	g, err := NewGeocoder(nil, "https://localhost", "en", poolClient(&used), 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithCoordinateSnapping(4), WithCache(NewMemoryCache(10, time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GPS jitter of a parked car
+	for _, c := range []Coordinate{{Lat: 48.858370, Lng: 2.294481}, {Lat: 48.858391, Lng: 2.294458}, {Lat: 48.858412, Lng: 2.294502}} {
+		if _, err := g.ReverseGeocode(context.TODO(), c.Lat, c.Lng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(used) != 1 {
+		t.Errorf("test for snapped cache Failed - results not match\nGot:\n%v\nExpected:\n%v", len(used), 1)
+	}
+}
+
+func Test_CoordinatePrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{"default", nil, "52.52000490,13.40499510"},
+		{"6 decimals", []Option{WithCoordinatePrecision(6)}, "52.520005,13.404995"},
+		{"0 decimals", []Option{WithCoordinatePrecision(0)}, "53,13"},
+		{"out of range", []Option{WithCoordinatePrecision(9)}, "52.52000490,13.40499510"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var latlng string
+			client := requesterFunc(func(targetURL string) (*http.Response, error) {
+				u, err := url.Parse(targetURL)
+				if err != nil {
+					return nil, err
+				}
+				latlng = u.Query().Get("latlng")
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"OK"}`))}, nil
+			})
+			g, err := NewGeocoder(nil, "https://localhost", "en", client, 1000, time.Millisecond, nil,
+				append(tt.options, WithAPIKey("key"))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.ReverseGeocode(context.TODO(), 52.5200049, 13.4049951); err != nil {
+				t.Fatal(err)
+			}
+			if latlng != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, latlng, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package geocoder
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxStaticMapSize is the largest width and height of a static map in pixels
+const maxStaticMapSize = 640
+
+// StaticMapOptions describes a static map image. Either Center and Zoom, or Markers or Paths
+// to fit the viewport to, are required
+type StaticMapOptions struct {
+	// Address or "lat,lng" of the map center
+	Center string
+	// From 0, the whole world, to 21+, single buildings
+	Zoom int
+	// Size in pixels, up to 640 each
+	Width, Height int
+	// Pixel density, 1 or 2
+	Scale int
+	// Image format, e.g. png, jpg or gif, png if empty
+	Format string
+	// roadmap, satellite, terrain or hybrid, roadmap if empty
+	MapType string
+	Markers []StaticMarker
+	Paths   []StaticPath
+	// ccTLD region borders and labels are shown for, e.g. de
+	Region string
+	// Label language overriding the Geocoder one
+	Language string
+}
+
+// StaticMarker is a group of markers sharing a style
+type StaticMarker struct {
+	// Color name, e.g. red, or 0xRRGGBB
+	Color string
+	// Single uppercase letter or digit
+	Label string
+	// tiny, mid or small, normal size if empty
+	Size string
+	// URL of a custom icon image
+	Icon string
+	// Addresses or "lat,lng" coordinates of the markers
+	Locations []string
+}
+
+// StaticPath is a line through points
+type StaticPath struct {
+	// Color name or 0xRRGGBB with optional alpha, e.g. 0x0000ff80
+	Color string
+	// Fill the area enclosed by the path
+	FillColor string
+	// Thickness in pixels
+	Weight int
+	// Follow the curvature of the Earth
+	Geodesic bool
+	Points   []Coordinate
+}
+
+// StaticMapURL returns a URL of a static map image authenticated with the Geocoder credentials
+// and signed for business keys, ready for an img tag. No request is sent. The API key is always
+// part of the URL, even with WithAPIKeyHeader
+func (g *Geocoder) StaticMapURL(opts StaticMapOptions) (string, error) {
+	if opts.Width <= 0 || opts.Height <= 0 || opts.Width > maxStaticMapSize || opts.Height > maxStaticMapSize {
+		return "", errors.New("width and height must be between 1 and 640")
+	}
+	if opts.Center == "" && len(opts.Markers) == 0 && len(opts.Paths) == 0 {
+		return "", errors.New("center, markers or paths are required")
+	}
+	params := url.Values{"size": {strconv.Itoa(opts.Width) + "x" + strconv.Itoa(opts.Height)}}
+	if opts.Center != "" {
+		params.Set("center", opts.Center)
+		params.Set("zoom", strconv.Itoa(opts.Zoom))
+	}
+	setNonEmpty(params, "format", opts.Format)
+	setNonEmpty(params, "maptype", opts.MapType)
+	setNonEmpty(params, "region", opts.Region)
+	setNonEmpty(params, "language", opts.Language)
+	if opts.Scale > 1 {
+		params.Set("scale", strconv.Itoa(opts.Scale))
+	}
+	for _, m := range opts.Markers {
+		params.Add("markers", m.encode())
+	}
+	for _, p := range opts.Paths {
+		params.Add("path", p.encode())
+	}
+
+	builder := g.builder
+	builder.APIKeyInHeader = false
+	builder.Endpoint = staticMapEndpoint(builder.Endpoint)
+	ur, err := builder.URL(params)
+	if err != nil {
+		return "", err
+	}
+	return ur.String(), nil
+}
+
+// staticMapEndpoint returns the Static Maps API URL on the host of endpoint
+func staticMapEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	u.Path, u.RawQuery = "/maps/api/staticmap", ""
+	return u.String()
+}
+
+// encode returns the markers parameter of m
+func (m StaticMarker) encode() string {
+	var parts []string
+	parts = appendStyle(parts, "color", m.Color)
+	parts = appendStyle(parts, "label", m.Label)
+	parts = appendStyle(parts, "size", m.Size)
+	parts = appendStyle(parts, "icon", m.Icon)
+	return strings.Join(append(parts, m.Locations...), "|")
+}
+
+// encode returns the path parameter of p, points are sent as an encoded polyline
+func (p StaticPath) encode() string {
+	var parts []string
+	parts = appendStyle(parts, "color", p.Color)
+	parts = appendStyle(parts, "fillcolor", p.FillColor)
+	if p.Weight > 0 {
+		parts = append(parts, "weight:"+strconv.Itoa(p.Weight))
+	}
+	if p.Geodesic {
+		parts = append(parts, "geodesic:true")
+	}
+	return strings.Join(append(parts, "enc:"+EncodePolyline(p.Points)), "|")
+}
+
+func appendStyle(parts []string, name, value string) []string {
+	if value == "" {
+		return parts
+	}
+	return append(parts, name+":"+value)
+}
+
+func setNonEmpty(params url.Values, name, value string) {
+	if value != "" {
+		params.Set(name, value)
+	}
+}
@@ -0,0 +1,65 @@
+package geocoder
+
+import (
+	"net/url"
+	"testing"
+)
+
+func Test_StaticMapURL(t *testing.T) {
+	key := "bXlfdGVzdF9rZXk="
+	g, err := NewGeocoder(&BusinessKey{ClientID: "my_test_client", SigningKey: key}, "https://maps.googleapis.com/maps/api/geocode/json",
+		"de", &fakeHttpRequester{}, 10, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := StaticMapOptions{
+		Center:  "52.52,13.405",
+		Zoom:    12,
+		Width:   400,
+		Height:  300,
+		Scale:   2,
+		MapType: "terrain",
+		Markers: []StaticMarker{{Color: "red", Label: "A", Locations: []string{"52.52,13.405", "Alexanderplatz, Berlin"}}},
+		Paths:   []StaticPath{{Color: "0x0000ff80", Weight: 5, Points: []Coordinate{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}, {Lat: 43.252, Lng: -126.453}}}},
+	}
+	rawURL, err := g.StaticMapURL(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "maps.googleapis.com" || u.Path != "/maps/api/staticmap" {
+		t.Errorf("test for endpoint Failed - results not match\nGot:\n%v\nExpected:\n%v", u.Host+u.Path, "maps.googleapis.com/maps/api/staticmap")
+	}
+	expected := map[string]string{
+		"center":   "52.52,13.405",
+		"zoom":     "12",
+		"size":     "400x300",
+		"scale":    "2",
+		"maptype":  "terrain",
+		"language": "de",
+		"markers":  "color:red|label:A|52.52,13.405|Alexanderplatz, Berlin",
+		"path":     "color:0x0000ff80|weight:5|enc:_p~iF~ps|U_ulLnnqC_mqNvxq`@",
+		"client":   "my_test_client",
+	}
+	for name, value := range expected {
+		if got := u.Query().Get(name); got != value {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", name, got, value)
+		}
+	}
+	if valid, err := VerifySignature(key, rawURL); err != nil || !valid {
+		t.Errorf("test for signature Failed - valid %v, error %v", valid, err)
+	}
+
+	for _, invalid := range []StaticMapOptions{
+		{Center: "Berlin", Zoom: 10},
+		{Center: "Berlin", Zoom: 10, Width: 641, Height: 300},
+		{Width: 400, Height: 300},
+	} {
+		if _, err := g.StaticMapURL(invalid); err == nil {
+			t.Errorf("test for %+v Failed - expected an error", invalid)
+		}
+	}
+}
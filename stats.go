@@ -0,0 +1,110 @@
+package geocoder
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the Geocoder state
+type Stats struct {
+	// Quota usage of every credential today, empty without WithQuota
+	Quota []QuotaStats
+	// State of the circuit breaker, CS_CLOSED without WithCircuitBreaker
+	Circuit CircuitState
+	// HTTP requests sent, retries and hedged requests included
+	Requests int64
+	// Responses per Google status
+	Statuses map[GoogleResponseStatus]int64
+	// Requests without a Google status: transport errors, HTTP errors and undecodable responses
+	Failures int64
+	// Attempts repeated after a failure, see WithRetries
+	Retries int64
+	// Requests which had to wait for the rate limiter and the total time they waited
+	LimiterWaits    int64
+	LimiterWaitTime time.Duration
+	// Cache lookups, see CacheStats
+	Cache CacheStats
+	// End of the running OVER_QUERY_LIMIT or HTTP 429 cooldown, zero if there is none
+	CooldownUntil time.Time
+	// Outbound requests are halted, see Pause
+	Paused bool
+}
+
+// requestCounter counts requests of a Geocoder for Stats. It is safe for concurrent use
+type requestCounter struct {
+	requests      atomic.Int64
+	failures      atomic.Int64
+	retries       atomic.Int64
+	limiterWaits  atomic.Int64
+	limiterWaited atomic.Int64
+
+	mu       sync.Mutex
+	statuses map[GoogleResponseStatus]int64
+}
+
+// attempt records the outcome of a send, info is nil if no request was sent
+func (c *requestCounter) attempt(info *RequestInfo) {
+	if info == nil {
+		return
+	}
+	c.requests.Add(1)
+	if info.Status == "" {
+		c.failures.Add(1)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.statuses == nil {
+		c.statuses = make(map[GoogleResponseStatus]int64)
+	}
+	c.statuses[info.Status]++
+}
+
+// limiterWait records a wait for the rate limiter
+func (c *requestCounter) limiterWait(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.limiterWaits.Add(1)
+	c.limiterWaited.Add(int64(d))
+}
+
+// Stats returns a snapshot of the Geocoder state, counters are totals since the Geocoder was created
+func (g *Geocoder) Stats() Stats {
+	stats := Stats{
+		Circuit:         g.breaker.current(),
+		Requests:        g.counters.requests.Load(),
+		Statuses:        make(map[GoogleResponseStatus]int64),
+		Failures:        g.counters.failures.Load(),
+		Retries:         g.counters.retries.Load(),
+		LimiterWaits:    g.counters.limiterWaits.Load(),
+		LimiterWaitTime: time.Duration(g.counters.limiterWaited.Load()),
+		Cache:           g.CacheStats(),
+	}
+	g.counters.mu.Lock()
+	for status, n := range g.counters.statuses {
+		stats.Statuses[status] = n
+	}
+	g.counters.mu.Unlock()
+
+	g.mu.Lock()
+	if g.clock.Now().Before(g.cooldownUntil) {
+		stats.CooldownUntil = g.cooldownUntil
+	}
+	stats.Paused = g.resumed != nil
+	g.mu.Unlock()
+
+	if g.quota != nil {
+		var names []string
+		if g.pool == nil {
+			names = append(names, credentialName(&g.builder))
+		} else {
+			for _, m := range g.pool.members {
+				names = append(names, credentialName(&m.builder))
+			}
+		}
+		stats.Quota = g.quota.Stats(g.clock.Now(), names...)
+	}
+	return stats
+}
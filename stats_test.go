@@ -0,0 +1,63 @@
+package geocoder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Stats(t *testing.T) {
+	var requests int
+	// a failure retried, then two OK responses of which one is cached
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", flakyClient(&requests, 500, 200), 1000,
+		time.Hour, nil, WithAPIKey("key"), WithRetries(2, time.Millisecond), WithCache(NewMemoryCache(10, time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, address := range []string{"Berlin", "Hamburg", "Berlin"} {
+		if _, err := g.Geocode(context.TODO(), address); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.startCooldown(time.Minute)
+
+	stats := g.Stats()
+	expected := Stats{
+		Circuit:  CS_CLOSED,
+		Requests: 3,
+		Statuses: map[GoogleResponseStatus]int64{GRS_OK: 2},
+		Failures: 1,
+		Retries:  1,
+		Cache:    CacheStats{Hits: 1, Misses: 2, Entries: 2, HitRatio: 1.0 / 3},
+	}
+	// limiter waits depend on timing, see Test_StatsLimiterWaits
+	cooldownUntil := stats.CooldownUntil
+	stats.CooldownUntil, stats.LimiterWaits, stats.LimiterWaitTime = time.Time{}, 0, 0
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("test for counters Failed - results not match\nGot:\n%+v\nExpected:\n%+v", stats, expected)
+	}
+	if until := time.Until(cooldownUntil); until <= 0 || until > time.Minute {
+		t.Errorf("test for cooldown Failed - unexpected cooldown end %v", cooldownUntil)
+	}
+}
+
+func Test_StatsLimiterWaits(t *testing.T) {
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{responseBodyJSON: `{"status":"ZERO_RESULTS"}`},
+		20, time.Hour, nil, WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := g.ReverseGeocode(context.TODO(), 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := g.Stats()
+	if stats.LimiterWaits != 2 || stats.LimiterWaitTime < 50*time.Millisecond || stats.LimiterWaitTime > 150*time.Millisecond {
+		t.Errorf("test for limiter waits Failed - unexpected %v waits of %v", stats.LimiterWaits, stats.LimiterWaitTime)
+	}
+	if stats.Statuses[GRS_ZERO_RESULTS] != 3 || !stats.CooldownUntil.IsZero() {
+		t.Errorf("test for statuses Failed - unexpected stats %+v", stats)
+	}
+}
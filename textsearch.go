@@ -0,0 +1,70 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var apiTextSearch = &mapsAPI{path: "place/textsearch", usage: UsageAPIPlaces}
+
+// TextSearchOptions narrows down text search results, all fields are optional
+type TextSearchOptions struct {
+	// Results near Location within Radius meters are preferred
+	Location *Coordinate
+	Radius   int
+	// Place type, e.g. restaurant
+	Type string
+	// ISO 3166-1 alpha-2 country the results are biased to
+	Region string
+	// Only places open at the time of the request
+	OpenNow bool
+	// Price levels from 0 to 4, ignored if negative or both zero
+	MinPrice, MaxPrice int
+	// Number of pages of up to 20 results to fetch, 1 to 3, all 3 if zero
+	MaxPages int
+	// Output language overriding the Geocoder one
+	Language string
+}
+
+// TextSearch returns places matching a free-text query, e.g. "pizza near Alexanderplatz".
+// Pages are fetched like in NearbySearch, every page is a request limited by the EP_PLACES limiter
+func (g *Geocoder) TextSearch(ctx context.Context, query string, opts TextSearchOptions) (*PlacesSearchResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("empty query")
+	}
+	params := url.Values{"query": {query}}
+	if opts.Location != nil {
+		if err := validateCoordinates(opts.Location.Lat, opts.Location.Lng); err != nil {
+			return nil, err
+		}
+		params.Set("location", formatLatLng(opts.Location.Lat, opts.Location.Lng, g.precision))
+	}
+	if opts.Radius > 0 {
+		params.Set("radius", strconv.Itoa(opts.Radius))
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.Region != "" {
+		params.Set("region", strings.ToLower(opts.Region))
+	}
+	if opts.OpenNow {
+		params.Set("opennow", "true")
+	}
+	if opts.MinPrice > 0 || opts.MaxPrice > 0 {
+		params.Set("minprice", strconv.Itoa(max(opts.MinPrice, 0)))
+		params.Set("maxprice", strconv.Itoa(max(opts.MaxPrice, 0)))
+	}
+	if opts.Language != "" {
+		params.Set("language", opts.Language)
+	}
+	return g.searchPlaces(ctx, &request{
+		op:       OP_TEXT_SEARCH,
+		endpoint: EP_PLACES,
+		api:      apiTextSearch,
+		params:   params,
+	}, opts.MaxPages)
+}
@@ -0,0 +1,48 @@
+package geocoder
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_TextSearch(t *testing.T) {
+	defer func(d time.Duration) { pageTokenDelay = d }(pageTokenDelay)
+	pageTokenDelay = time.Millisecond
+
+	var requests []url.Values
+	g, err := NewGeocoder(nil, "https://localhost", "en", placesPagesClient(&requests, 2), 1000, time.Millisecond, nil,
+		WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.TextSearch(context.TODO(), "pizza near Alexanderplatz", TextSearchOptions{
+		Location: &Coordinate{Lat: 52.52, Lng: 13.405},
+		Radius:   1000,
+		Region:   "DE",
+		OpenNow:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 2 || res.Status != GRS_OK {
+		t.Errorf("test for results Failed - results not match\nGot:\n%v\nExpected:\n%v", len(res.Results), 2)
+	}
+
+	expected := map[string]string{
+		"query":    "pizza near Alexanderplatz",
+		"location": "52.52000000,13.40500000",
+		"radius":   "1000",
+		"region":   "de",
+		"opennow":  "true",
+	}
+	for param, value := range expected {
+		if got := requests[0].Get(param); got != value {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", param, got, value)
+		}
+	}
+	if _, err := g.TextSearch(context.TODO(), " ", TextSearchOptions{}); err == nil {
+		t.Errorf("test for empty query Failed - expected error")
+	}
+}
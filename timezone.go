@@ -0,0 +1,57 @@
+package geocoder
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var apiTimezone = &mapsAPI{path: "timezone", usage: UsageAPITimezone}
+
+// TimezoneResponse is the response of the Time Zone API
+type TimezoneResponse struct {
+	Status       GoogleResponseStatus `json:"status"`
+	ErrorMessage string               `json:"errorMessage,omitempty"`
+	// Daylight saving offset in seconds, zero outside of daylight saving time
+	DstOffset int `json:"dstOffset"`
+	// Offset from UTC in seconds without daylight saving
+	RawOffset int `json:"rawOffset"`
+	// IANA time zone ID, e.g. Europe/Berlin
+	TimeZoneID string `json:"timeZoneId"`
+	// Localized long name, e.g. Central European Summer Time
+	TimeZoneName string `json:"timeZoneName"`
+}
+
+// Offset returns the total offset from UTC at the requested timestamp
+func (r *TimezoneResponse) Offset() time.Duration {
+	return time.Duration(r.RawOffset+r.DstOffset) * time.Second
+}
+
+// Location loads the time zone of TimeZoneID from the time zone database
+func (r *TimezoneResponse) Location() (*time.Location, error) {
+	return time.LoadLocation(r.TimeZoneID)
+}
+
+// Timezone returns the time zone of lat, lng at timestamp, which decides whether daylight
+// saving applies. Requests are limited by the EP_TIMEZONE limiter and are not cached
+func (g *Geocoder) Timezone(ctx context.Context, lat, lng float64, timestamp time.Time) (*TimezoneResponse, error) {
+	if err := validateCoordinates(lat, lng); err != nil {
+		return nil, err
+	}
+	params := url.Values{
+		"location":  {formatLatLng(lat, lng, g.precision)},
+		"timestamp": {strconv.FormatInt(timestamp.Unix(), 10)},
+	}
+
+	res := &TimezoneResponse{}
+	if _, err := g.callAPI(ctx, &request{
+		op:       OP_TIMEZONE,
+		endpoint: EP_TIMEZONE,
+		api:      apiTimezone,
+		params:   params,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
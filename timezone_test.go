@@ -0,0 +1,66 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Timezone(t *testing.T) {
+	var requested *url.URL
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		requested = u
+		body := `{"dstOffset":3600,"rawOffset":3600,"status":"OK","timeZoneId":"Europe/Berlin","timeZoneName":"Central European Summer Time"}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	usage := NewUsageTracker(nil)
+	g, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Millisecond, nil,
+		WithAPIKey("key"), WithUsageTracker(usage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := g.Timezone(context.TODO(), 52.52, 13.405, time.Unix(1720000000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &TimezoneResponse{Status: GRS_OK, DstOffset: 3600, RawOffset: 3600, TimeZoneID: "Europe/Berlin",
+		TimeZoneName: "Central European Summer Time"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("test for response Failed - results not match\nGot:\n%v\nExpected:\n%v", res, expected)
+	}
+	if res.Offset() != 2*time.Hour {
+		t.Errorf("test for offset Failed - results not match\nGot:\n%v\nExpected:\n%v", res.Offset(), 2*time.Hour)
+	}
+	if requested.Path != "/maps/api/timezone/json" || requested.Query().Get("location") != "52.52000000,13.40500000" ||
+		requested.Query().Get("timestamp") != "1720000000" {
+		t.Errorf("test for request Failed - unexpected url %v", requested)
+	}
+	if records := usage.Records(); len(records) != 1 || records[0].API != UsageAPITimezone {
+		t.Errorf("test for usage Failed - results not match\nGot:\n%v\nExpected:\n%v", records, UsageAPITimezone)
+	}
+}
+
+func Test_TimezoneStatusError(t *testing.T) {
+	g, err := NewGeocoder(nil, "https://localhost", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"INVALID_REQUEST","errorMessage":"Invalid request. Missing the 'timestamp' parameter."}`},
+		1000, time.Millisecond, nil, WithAPIKey("key"), WithStatusErrors())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = g.Timezone(context.TODO(), 52.52, 13.405, time.Now())
+	var se *StatusError
+	if !errors.As(err, &se) || se.ErrorMessage != "Invalid request. Missing the 'timestamp' parameter." {
+		t.Errorf("test for status error Failed - unexpected error %v", err)
+	}
+}
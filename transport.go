@@ -0,0 +1,131 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// apiKeyHeader carries the API key when it is kept out of the URL
+const apiKeyHeader = "X-Goog-Api-Key"
+
+// HttpDoer is implemented by HTTP clients able to send arbitrary requests, e.g. *http.Client.
+// If the HttpRequester passed to NewGeocoder implements it, requests are sent with Do
+// carrying the caller's context and headers
+type HttpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithAPIKey makes the Geocoder authenticate with a Maps Platform API key instead of
+// a BusinessKey. The key is sent in the query string and requests are not signed
+func WithAPIKey(key string) Option {
+	return func(g *Geocoder) {
+		g.builder.APIKey = key
+	}
+}
+
+// WithAPIKeyHeader is WithAPIKey sending the key in X-Goog-Api-Key header, which keeps it
+// out of URL logs. The HttpRequester must implement HttpDoer
+func WithAPIKeyHeader(key string) Option {
+	return func(g *Geocoder) {
+		g.builder.APIKey = key
+		g.builder.APIKeyInHeader = true
+	}
+}
+
+// get sends GET request to targetURL with ctx, the custom headers and headers of b
+func (g *Geocoder) get(ctx context.Context, b *RequestBuilder, targetURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	g.setHeaders(ctx, req)
+	b.setHeaders(req)
+	return g.client.Do(req)
+}
+
+// post sends POST request with JSON body to targetURL with ctx, the custom headers and headers of b
+func (g *Geocoder) post(ctx context.Context, b *RequestBuilder, targetURL string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	g.setHeaders(ctx, req)
+	req.Header.Set("Content-Type", "application/json")
+	b.setHeaders(req)
+	return g.client.Do(req)
+}
+
+// DoerFunc adapts a Do-style function, e.g. Do of an instrumented client, to HttpRequester
+// and HttpDoer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements HttpDoer
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Get implements HttpRequester
+func (f DoerFunc) Get(targetURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return f(req)
+}
+
+// NewRoundTripperRequester returns an HttpRequester sending requests with rt, e.g. a transport
+// wrapped by tracing middleware. Timeouts are left to rt and the request contexts
+func NewRoundTripperRequester(rt http.RoundTripper) HttpRequester {
+	return &http.Client{Transport: rt}
+}
+
+// asDoer returns client as HttpDoer, adapting Get-only clients
+func asDoer(client HttpRequester) HttpDoer {
+	if doer, ok := client.(HttpDoer); ok {
+		return doer
+	}
+	return legacyDoer{client}
+}
+
+// httpPoster is implemented by HTTP clients able to send POST requests
+type httpPoster interface {
+	Post(url, contentType string, body io.Reader) (*http.Response, error)
+}
+
+// legacyDoer sends requests with a Get-only HttpRequester. The request context and headers
+// are lost, POST requests need a Post method
+type legacyDoer struct {
+	HttpRequester
+}
+
+// Do implements HttpDoer
+func (d legacyDoer) Do(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return d.Get(req.URL.String())
+	case http.MethodPost:
+		if poster, ok := d.HttpRequester.(httpPoster); ok {
+			return poster.Post(req.URL.String(), req.Header.Get("Content-Type"), req.Body)
+		}
+	}
+	return nil, errors.New("HTTPClient must implement HttpDoer to send " + req.Method + " requests")
+}
+
+// clientID returns the client ID of the business key, empty for API key authentication
+func (b *RequestBuilder) clientID() string {
+	if b.BusinessKey == nil {
+		return ""
+	}
+	return b.BusinessKey.ClientID
+}
+
+// channel returns the channel of the business key, empty for API key authentication
+func (b *RequestBuilder) channel() string {
+	if b.BusinessKey == nil {
+		return ""
+	}
+	return b.BusinessKey.Channel
+}
@@ -0,0 +1,118 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeHttpDoer records requests sent with Do
+type fakeHttpDoer struct {
+	fakeHttpRequester
+	requests []*http.Request
+}
+
+func (c *fakeHttpDoer) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(c.responseBodyJSON)))}, c.err
+}
+
+func Test_APIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		option         Option
+		expectedQuery  string
+		expectedHeader string
+	}{
+		{"Key in query", WithAPIKey("my_api_key"), "my_api_key", ""},
+		{"Key in header", WithAPIKeyHeader("my_api_key"), "", "my_api_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeHttpDoer{fakeHttpRequester: fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}}
+			geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", client, 1000, time.Second, nil, tt.option)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+				t.Fatal(err)
+			}
+
+			req := client.requests[0]
+			if req.URL.Query().Get("key") != tt.expectedQuery || req.Header.Get("X-Goog-Api-Key") != tt.expectedHeader {
+				t.Errorf("test for %v Failed - unexpected request %v %v", tt.name, req.URL, req.Header)
+			}
+			if req.URL.Query().Get("signature") != "" {
+				t.Errorf("test for %v Failed - API key request was signed", tt.name)
+			}
+		})
+	}
+
+	if _, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", &fakeHttpRequester{}, 1000, time.Second, nil,
+		WithAPIKeyHeader("my_api_key")); err == nil {
+		t.Errorf("test for header without HttpDoer Failed - expected error")
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper calling a function
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_DoStyleClients(t *testing.T) {
+	var requests []*http.Request
+	respond := func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(`{"status":"OK"}`)))}, nil
+	}
+	tests := []struct {
+		name   string
+		client HttpRequester
+	}{
+		{"DoerFunc", DoerFunc(respond)},
+		{"RoundTripper", NewRoundTripperRequester(roundTripperFunc(respond))},
+	}
+
+	type ctxKey struct{}
+	for _, tt := range tests {
+		requests = nil
+		geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", tt.client, 1000, time.Second, nil,
+			WithAPIKeyHeader("my_api_key"), WithUserAgent("my-service/1.0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.WithValue(context.TODO(), ctxKey{}, tt.name)
+		if _, err := geocoder.ReverseGeocode(ctx, 49.17584440, 7.30196070); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := geocoder.ValidateAddress(ctx, PostalAddress{AddressLines: []string{"Unter den Linden 1"}}); err != nil {
+			t.Fatal(err)
+		}
+		if len(requests) != 2 || requests[0].Method != http.MethodGet || requests[1].Method != http.MethodPost {
+			t.Fatalf("test for %v Failed - unexpected requests %v", tt.name, requests)
+		}
+		for _, req := range requests {
+			if req.Context().Value(ctxKey{}) != tt.name || req.Header.Get("X-Goog-Api-Key") != "my_api_key" ||
+				req.Header.Get("User-Agent") != "my-service/1.0" {
+				t.Errorf("test for %v Failed - request lost context or headers %v", tt.name, req.Header)
+			}
+		}
+	}
+
+	// Get-only clients keep working for GET requests
+	requester := &fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}
+	geocoder, err := NewGeocoder(nil, "https://maps.googleapis.com/maps/api/geocode/json", "en", requester, 1000, time.Second, nil,
+		WithAPIKey("my_api_key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+		t.Errorf("test for Get-only client Failed - unexpected error %v", err)
+	}
+}
@@ -1,37 +1,77 @@
 package geocoder
 
 type GoogleResponse struct {
-	Results []*ResultSet         `json:"results"`
-	Status  GoogleResponseStatus `json:"status"`
+	Results      []*ResultSet         `json:"results" xml:"result"`
+	Status       GoogleResponseStatus `json:"status" xml:"status"`
+	ErrorMessage string               `json:"error_message,omitempty" xml:"error_message,omitempty"`
+	// Plus code of the queried coordinate, reverse geocoding only
+	PlusCode *PlusCode `json:"plus_code,omitempty" xml:"plus_code,omitempty"`
+	// Degradation is set by WithDegradation on responses not coming from Google at full precision
+	Degradation DegradationLevel `json:"-" xml:"-"`
+	// QueryKey is set by WithCoordinateSnapping to the request the response belongs to,
+	// e.g. latlng:52.52000000,13.40500000. It is the cache key without language
+	QueryKey string `json:"-" xml:"-"`
+	// Raw is the untouched response body, set by WithRawBody. Caches which serialize
+	// responses, like boltcache, don't keep it
+	Raw []byte `json:"-" xml:"-"`
 }
 
 type ResultSet struct {
-	AddressComponents []AddressComponent `json:"address_components"`
-	FormattedAddress  string             `json:"formatted_address"`
-	Geometry          Geometry           `json:"geometry"`
-	PlaceID           string             `json:"place_id"`
-	Types             []string           `json:"types"`
+	AddressComponents []AddressComponent `json:"address_components" xml:"address_component"`
+	FormattedAddress  string             `json:"formatted_address" xml:"formatted_address"`
+	Geometry          Geometry           `json:"geometry" xml:"geometry"`
+	PlaceID           string             `json:"place_id" xml:"place_id"`
+	Types             []string           `json:"types" xml:"type"`
+	PartialMatch      bool               `json:"partial_match" xml:"partial_match"`
+	PlusCode          *PlusCode          `json:"plus_code,omitempty" xml:"plus_code,omitempty"`
+	// Localities of a postal code spanning several ones
+	PostcodeLocalities []string `json:"postcode_localities,omitempty" xml:"postcode_locality,omitempty"`
+	// CountryMismatch is set by the country check if the result country can't contain the queried coordinate
+	CountryMismatch bool `json:"-" xml:"-"`
+	// Distance in meters from the query point, set by SortByDistance
+	Distance float64 `json:"-" xml:"-"`
+}
+
+// PlusCode is an Open Location Code of a location
+type PlusCode struct {
+	// E.g. 8FVC9G8F+5W
+	GlobalCode string `json:"global_code" xml:"global_code"`
+	// Local code with a locality, e.g. 9G8F+5W Zurich, Switzerland
+	CompoundCode string `json:"compound_code,omitempty" xml:"compound_code,omitempty"`
 }
 
 type AddressComponent struct {
-	LongName  string   `json:"long_name"`
-	ShortName string   `json:"short_name"`
-	Types     []string `json:"types"`
+	LongName  string   `json:"long_name" xml:"long_name"`
+	ShortName string   `json:"short_name" xml:"short_name"`
+	Types     []string `json:"types" xml:"type"`
 }
 
 type Geometry struct {
-	Location     Coordinate `json:"location"`
-	LocationType string     `json:"location_type"`
+	Location     Coordinate `json:"location" xml:"location"`
+	LocationType string     `json:"location_type" xml:"location_type"`
+	Viewport     Bounds     `json:"viewport" xml:"viewport"`
+	Bounds       *Bounds    `json:"bounds,omitempty" xml:"bounds,omitempty"`
 }
 
 type Coordinate struct {
-	Lat float64 `json:"lat"`
-	Lng float64 `json:"lng"`
+	Lat float64 `json:"lat" xml:"lat"`
+	Lng float64 `json:"lng" xml:"lng"`
+}
+
+// LatLng is a coordinate of the googleapis.com REST APIs, e.g. Roads and Address Validation
+type LatLng struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Coordinate returns the coordinate of l
+func (l LatLng) Coordinate() Coordinate {
+	return Coordinate{Lat: l.Latitude, Lng: l.Longitude}
 }
 
 type Bounds struct {
-	SouthWest Coordinate `json:"southwest"`
-	NorthEast Coordinate `json:"northeast"`
+	SouthWest Coordinate `json:"southwest" xml:"southwest"`
+	NorthEast Coordinate `json:"northeast" xml:"northeast"`
 }
 
 type BusinessKey struct {
@@ -50,3 +90,11 @@ const (
 	GRS_OVER_QUERY_LIMIT GoogleResponseStatus = "OVER_QUERY_LIMIT"
 	GRS_OK               GoogleResponseStatus = "OK"
 )
+
+// Geometry location types
+const (
+	LT_ROOFTOP            = "ROOFTOP"
+	LT_RANGE_INTERPOLATED = "RANGE_INTERPOLATED"
+	LT_GEOMETRIC_CENTER   = "GEOMETRIC_CENTER"
+	LT_APPROXIMATE        = "APPROXIMATE"
+)
@@ -0,0 +1,119 @@
+package geocoder
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// API names of requests in usage reports
+const (
+	UsageAPIGeocoding         = "Geocoding API"
+	UsageAPITimezone          = "Time Zone API"
+	UsageAPIElevation         = "Elevation API"
+	UsageAPIPlaces            = "Places API"
+	UsageAPIAddressValidation = "Address Validation API"
+	UsageAPIDirections        = "Directions API"
+	UsageAPIRoads             = "Roads API"
+	UsageAPIGeolocation       = "Geolocation API"
+)
+
+// usageDateLayout is the date format of usage reports
+const usageDateLayout = "2006-01-02"
+
+// usageCSVHeader follows the column layout of Google Premium Plan usage reports
+var usageCSVHeader = []string{"Date", "Client ID", "Channel", "API", "Requests"}
+
+// UsageRecord is a single row of a usage report
+type UsageRecord struct {
+	// Date in YYYY-MM-DD format
+	Date     string
+	ClientID string
+	Channel  string
+	API      string
+	Requests int64
+}
+
+// UsageTracker counts billable requests per day, client ID, channel and API.
+// It is safe for concurrent use
+type UsageTracker struct {
+	mu       sync.Mutex
+	location *time.Location
+	counts   map[UsageRecord]int64
+}
+
+// NewUsageTracker creates new instance of UsageTracker. Days are split in the given location,
+// Google reports usage in Pacific Time, so use America/Los_Angeles to get comparable numbers.
+// Nil location means UTC
+func NewUsageTracker(location *time.Location) *UsageTracker {
+	if location == nil {
+		location = time.UTC
+	}
+	return &UsageTracker{location: location, counts: make(map[UsageRecord]int64)}
+}
+
+// Record counts a request made at t
+func (u *UsageTracker) Record(t time.Time, clientID, channel, api string) {
+	key := UsageRecord{Date: t.In(u.location).Format(usageDateLayout), ClientID: clientID, Channel: channel, API: api}
+	u.mu.Lock()
+	u.counts[key]++
+	u.mu.Unlock()
+}
+
+// Records returns the collected usage sorted by date, client ID, channel and API
+func (u *UsageTracker) Records() []UsageRecord {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.records()
+}
+
+// Flush returns the collected usage like Records and resets the tracker,
+// use it to produce periodic reports
+func (u *UsageTracker) Flush() []UsageRecord {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	records := u.records()
+	u.counts = make(map[UsageRecord]int64)
+	return records
+}
+
+func (u *UsageTracker) records() []UsageRecord {
+	records := make([]UsageRecord, 0, len(u.counts))
+	for key, n := range u.counts {
+		key.Requests = n
+		records = append(records, key)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.ClientID != b.ClientID {
+			return a.ClientID < b.ClientID
+		}
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		return a.API < b.API
+	})
+	return records
+}
+
+// WriteUsageCSV writes records as CSV in the layout of Google Premium Plan usage reports
+func WriteUsageCSV(w io.Writer, records []UsageRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(usageCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{r.Date, r.ClientID, r.Channel, r.API, strconv.FormatInt(r.Requests, 10)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,38 @@
+package geocoder
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_UsageTracker(t *testing.T) {
+	usage := NewUsageTracker(time.UTC)
+	// requests are recorded at the time of the Geocoder clock
+	clock := &manualClock{now: time.Date(2021, 3, 2, 23, 59, 59, 0, time.UTC)}
+	bkey := &BusinessKey{ClientID: "my_test_client", SigningKey: "bXlfdGVzdF9rZXk=", Channel: "grg-local"}
+	geocoder, _ := NewGeocoder(bkey, "https://maps.googleapis.com/maps/api/geocode/json", "en",
+		&fakeHttpRequester{responseBodyJSON: `{"status":"OK"}`}, 1000, time.Second, nil, WithUsageTracker(usage), WithClock(clock))
+
+	for i := 0; i < 3; i++ {
+		if _, err := geocoder.ReverseGeocode(context.TODO(), 49.17584440, 7.30196070); err != nil {
+			t.Fatal(err)
+		}
+	}
+	usage.Record(time.Date(2021, 3, 1, 23, 0, 0, 0, time.UTC), "my_test_client", "other", UsageAPIGeocoding)
+
+	var buf bytes.Buffer
+	if err := WriteUsageCSV(&buf, usage.Flush()); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Date,Client ID,Channel,API,Requests\n" +
+		"2021-03-01,my_test_client,other,Geocoding API,1\n" +
+		"2021-03-02,my_test_client,grg-local,Geocoding API,3\n"
+	if buf.String() != expected {
+		t.Errorf("test for usage CSV Failed - results not match\nGot:\n%v\nExpected:\n%v", buf.String(), expected)
+	}
+	if len(usage.Records()) != 0 {
+		t.Errorf("test for usage Flush Failed - tracker was not reset")
+	}
+}
@@ -0,0 +1,216 @@
+// Package vcr records geocoding HTTP interactions to a cassette file and replays them,
+// so that tests against real coordinates run hermetically
+package vcr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/alvillain/geocoder"
+)
+
+// Mode tells whether Recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves interactions from the cassette and fails on unknown requests
+	ModeReplay Mode = iota
+	// ModeRecord sends requests with the wrapped client and records them
+	ModeRecord
+)
+
+// ErrInteractionNotFound is returned in replay mode for requests missing in the cassette
+var ErrInteractionNotFound = errors.New("vcr: interaction not found in cassette")
+
+// strippedParams are credentials never written to a cassette and ignored when matching requests,
+// both in the query and in JSON request bodies
+var strippedParams = []string{"signature", "client", "channel", "key"}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	// Method and URL path with query, without host and credentials, followed by a hash of the
+	// request body without credentials if there is one
+	Request    string `json:"request"`
+	StatusCode int    `json:"status_code"`
+	// Retry-After header of the response, if any
+	RetryAfter string `json:"retry_after,omitempty"`
+	Body       string `json:"body"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is a geocoder.HttpRequester and geocoder.HttpDoer recording or replaying interactions.
+// In replay mode identical requests are served in recorded order, the last one is repeated
+type Recorder struct {
+	path   string
+	mode   Mode
+	client geocoder.HttpRequester
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// Next interaction to replay by request
+	served map[string]int
+}
+
+// New creates new instance of Recorder for the cassette at path. In replay mode the cassette is
+// loaded and client may be nil. In record mode requests are sent with client, call Save to write them
+func New(path string, mode Mode, client geocoder.HttpRequester) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, client: client, served: make(map[string]int)}
+	if mode == ModeRecord {
+		if client == nil {
+			return nil, errors.New("empty HTTPClient")
+		}
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("vcr: reading cassette %s: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	return r, nil
+}
+
+// Get implements geocoder.HttpRequester
+func (r *Recorder) Get(targetURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.Do(req)
+}
+
+// Do implements geocoder.HttpDoer. Request headers, e.g. X-Goog-Api-Key, are neither recorded
+// nor matched
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	key := requestKey(req.Method, req.URL, reqBody)
+	if r.mode == ModeReplay {
+		return r.replay(key)
+	}
+
+	var resp *http.Response
+	var err error
+	if doer, ok := r.client.(geocoder.HttpDoer); ok {
+		resp, err = doer.Do(req)
+	} else {
+		resp, err = r.client.Get(req.URL.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request:    key,
+		StatusCode: resp.StatusCode,
+		RetryAfter: resp.Header.Get("Retry-After"),
+		Body:       string(body),
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes recorded interactions to the cassette
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return errors.New("vcr: Save in replay mode")
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) replay(key string) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matches []int
+	for i, in := range r.interactions {
+		if in.Request == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInteractionNotFound, key)
+	}
+	n := r.served[key]
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	r.served[key] = n + 1
+
+	in := r.interactions[matches[n]]
+	header := http.Header{}
+	if in.RetryAfter != "" {
+		header.Set("Retry-After", in.RetryAfter)
+	}
+	return &http.Response{
+		StatusCode: in.StatusCode,
+		Status:     fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(in.Body))),
+	}, nil
+}
+
+// requestKey identifies a request by method, path, query and body without credentials.
+// The query is encoded sorted, so parameter order doesn't matter
+func requestKey(method string, u *url.URL, body []byte) string {
+	query := u.Query()
+	for _, param := range strippedParams {
+		query.Del(param)
+	}
+	key := method + " " + u.Path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	if len(body) > 0 {
+		key += " " + bodyHash(body)
+	}
+	return key
+}
+
+// bodyHash returns a hash of a request body. Credentials are removed from JSON objects,
+// which are hashed with sorted fields
+func bodyHash(body []byte) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) == nil && fields != nil {
+		for _, param := range strippedParams {
+			delete(fields, param)
+		}
+		body, _ = json.Marshal(fields)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
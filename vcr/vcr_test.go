@@ -0,0 +1,149 @@
+package vcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	"github.com/alvillain/geocoder/geocodertest"
+)
+
+func Test_RecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	s := geocodertest.NewServer()
+	s.SetReverse(52.52, 13.40, &geocoder.GoogleResponse{Status: geocoder.GRS_OK, Results: []*geocoder.ResultSet{{FormattedAddress: "Berlin, Germany"}}})
+
+	rec, err := New(path, ModeRecord, s.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := geocoder.NewGeocoder(s.BusinessKey(), s.Endpoint(), "en", rec, 100, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := g.ReverseGeocode(context.TODO(), 52.52, 13.40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"signature", geocodertest.TestClientID} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("test for stripped secrets Failed - cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// the server is gone, the cassette is served on another endpoint host
+	replay, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err = geocoder.NewGeocoder(s.BusinessKey(), "https://maps.example"+geocodertest.GeocodePath, "en", replay, 100, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := g.ReverseGeocode(context.TODO(), 52.52, 13.40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Results[0].FormattedAddress != recorded.Results[0].FormattedAddress {
+		t.Errorf("test for replay Failed - results not match\nGot:\n%v\nExpected:\n%v", replayed.Results[0].FormattedAddress, recorded.Results[0].FormattedAddress)
+	}
+	if _, err := g.ReverseGeocode(context.TODO(), 1, 1); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("test for unknown request Failed - results not match\nGot:\n%v\nExpected:\n%v", err, ErrInteractionNotFound)
+	}
+}
+
+func Test_RecordReplayBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	// echoes the first address line as the formatted address
+	echo := geocoder.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		var in struct {
+			Address geocoder.PostalAddress `json:"address"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+			return nil, err
+		}
+		body := fmt.Sprintf(`{"result":{"address":{"formattedAddress":%q}}}`, in.Address.AddressLines[0])
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	addresses := []string{"Unter den Linden 1", "Champs-Elysees 1"}
+
+	rec, err := New(path, ModeRecord, echo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := geocoder.NewGeocoder(nil, "https://maps.example", "en", rec, 100, time.Millisecond, nil, geocoder.WithAPIKeyHeader("secret-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, address := range addresses {
+		if _, err := g.ValidateAddress(context.TODO(), geocoder.PostalAddress{AddressLines: []string{address}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Errorf("test for stripped API key Failed - cassette contains the key:\n%s", data)
+	}
+
+	replay, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err = geocoder.NewGeocoder(nil, "https://maps.example", "en", replay, 100, time.Millisecond, nil, geocoder.WithAPIKeyHeader("other-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// replayed in reverse order, every body gets its own response
+	for i := len(addresses) - 1; i >= 0; i-- {
+		res, err := g.ValidateAddress(context.TODO(), geocoder.PostalAddress{AddressLines: []string{addresses[i]}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Result.Address.FormattedAddress != addresses[i] {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", addresses[i], res.Result.Address.FormattedAddress, addresses[i])
+		}
+	}
+}
+
+func Test_BodyHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{"different bodies", `{"address":"a"}`, `{"address":"b"}`, false},
+		{"field order and spacing", `{"a":1,"b":2}`, `{ "b": 2, "a": 1 }`, true},
+		{"credentials", `{"address":"a","key":"secret"}`, `{"address":"a"}`, true},
+		{"not JSON", `address=a`, `address=b`, false},
+	}
+
+	for _, tt := range tests {
+		if got := bodyHash([]byte(tt.a)) == bodyHash([]byte(tt.b)); got != tt.equal {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.equal)
+		}
+	}
+}
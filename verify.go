@@ -0,0 +1,163 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// machineLanguage is the language machine fields of DualAddress are fetched in
+const machineLanguage = "en"
+
+// DualAddress is a verified address in two representations: localized for display to the user
+// and Latin-script for machine processing, e.g. matching against identity documents
+type DualAddress struct {
+	PlaceID      string
+	Location     Coordinate
+	LocationType string
+	PartialMatch bool
+	// Language of the display representation
+	Language string
+	// Formatted address in Language
+	Display string
+	// Formatted address in Latin script if Google has one, Display otherwise
+	Machine    string
+	Components []DualComponent
+}
+
+// DualComponent is an address component in both representations
+type DualComponent struct {
+	Types []string
+	// Long name in the language of the address
+	Display string
+	// Long name in Latin script if Google has one, Display otherwise
+	Machine string
+	// Short name, in Latin script if Google has one, e.g. ISO country code or state abbreviation
+	ShortName string
+}
+
+// Component returns the first component of type typ, e.g. "locality"
+func (a *DualAddress) Component(typ string) (DualComponent, bool) {
+	for _, c := range a.Components {
+		if hasType(c.Types, typ) {
+			return c, true
+		}
+	}
+	return DualComponent{}, false
+}
+
+// VerifyAddress geocodes address in language, then fetches the best result in English by its place ID
+// and merges both into a DualAddress. The second request is skipped if language is English.
+// An error is returned if the address is not found
+func (g *Geocoder) VerifyAddress(ctx context.Context, address, language string) (*DualAddress, error) {
+	if strings.TrimSpace(address) == "" {
+		return nil, errors.New("empty address")
+	}
+	if language == "" {
+		language = g.builder.Language
+	}
+	local, err := g.do(ctx, &request{
+		key:      "address:" + address,
+		op:       OP_GEOCODING,
+		language: language,
+		params:   url.Values{"address": {address}, "language": {language}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := local.Err(); err != nil {
+		return nil, fmt.Errorf("verifying address: %w", err)
+	}
+	if len(local.Results) == 0 {
+		return nil, fmt.Errorf("verifying address: %s without results", local.Status)
+	}
+	best := local.Results[0]
+
+	latin := best
+	if !isMachineLanguage(language) {
+		res, err := g.geocodePlace(ctx, best.PlaceID, machineLanguage)
+		if err != nil {
+			return nil, err
+		}
+		if err := res.Err(); err != nil {
+			return nil, fmt.Errorf("fetching %s representation: %w", machineLanguage, err)
+		}
+		if len(res.Results) == 0 {
+			return nil, fmt.Errorf("fetching %s representation: %s without results", machineLanguage, res.Status)
+		}
+		latin = res.Results[0]
+	}
+	return mergeDual(best, latin, language), nil
+}
+
+// geocodePlace geocodes a place ID in language
+func (g *Geocoder) geocodePlace(ctx context.Context, placeID, language string) (*GoogleResponse, error) {
+	return g.do(ctx, &request{
+		key:      "place:" + placeID,
+		op:       OP_PLACE_GEOCODING,
+		language: language,
+		params:   url.Values{"place_id": {placeID}, "language": {language}},
+	})
+}
+
+// mergeDual combines a localized and an English result of the same place.
+// Components are matched by their types, then by position
+func mergeDual(local, latin *ResultSet, language string) *DualAddress {
+	addr := &DualAddress{
+		PlaceID:      local.PlaceID,
+		Location:     local.Geometry.Location,
+		LocationType: local.Geometry.LocationType,
+		PartialMatch: local.PartialMatch,
+		Language:     language,
+		Display:      local.FormattedAddress,
+		Machine:      preferLatin(latin.FormattedAddress, local.FormattedAddress),
+	}
+
+	latinByTypes := make(map[string]AddressComponent, len(latin.AddressComponents))
+	for _, c := range latin.AddressComponents {
+		latinByTypes[strings.Join(c.Types, ",")] = c
+	}
+	for i, c := range local.AddressComponents {
+		other, ok := latinByTypes[strings.Join(c.Types, ",")]
+		if !ok && i < len(latin.AddressComponents) {
+			other = latin.AddressComponents[i]
+		}
+		addr.Components = append(addr.Components, DualComponent{
+			Types:     c.Types,
+			Display:   c.LongName,
+			Machine:   preferLatin(other.LongName, c.LongName),
+			ShortName: preferLatin(other.ShortName, c.ShortName),
+		})
+	}
+	return addr
+}
+
+// preferLatin returns the first non empty Latin-script value, a, b or the first non empty one otherwise
+func preferLatin(a, b string) string {
+	switch {
+	case a != "" && isLatin(a):
+		return a
+	case b != "" && isLatin(b):
+		return b
+	case a != "":
+		return a
+	}
+	return b
+}
+
+// isLatin reports whether all letters of s are in Latin script
+func isLatin(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isMachineLanguage(language string) bool {
+	return language == machineLanguage || strings.HasPrefix(language, machineLanguage+"-")
+}
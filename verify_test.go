@@ -0,0 +1,104 @@
+package geocoder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	verifyBodyRU = `{"status":"OK","results":[{"place_id":"ChIJ","formatted_address":"Тверская ул., 1, Москва, Россия",
+"geometry":{"location":{"lat":55.757,"lng":37.613},"location_type":"ROOFTOP"},"address_components":[
+{"long_name":"1","short_name":"1","types":["street_number"]},
+{"long_name":"Тверская улица","short_name":"Тверская ул.","types":["route"]},
+{"long_name":"Москва","short_name":"Москва","types":["locality","political"]},
+{"long_name":"Россия","short_name":"RU","types":["country","political"]}]}]}`
+	verifyBodyEN = `{"status":"OK","results":[{"place_id":"ChIJ","formatted_address":"Tverskaya St, 1, Moscow, Russia",
+"geometry":{"location":{"lat":55.757,"lng":37.613},"location_type":"ROOFTOP"},"address_components":[
+{"long_name":"1","short_name":"1","types":["street_number"]},
+{"long_name":"Tverskaya Street","short_name":"Tverskaya St","types":["route"]},
+{"long_name":"Moscow","short_name":"Moscow","types":["locality","political"]},
+{"long_name":"Russia","short_name":"RU","types":["country","political"]}]}]}`
+)
+
+func Test_VerifyAddress(t *testing.T) {
+	var queries []string
+	client := requesterFunc(func(targetURL string) (*http.Response, error) {
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, u.Query().Get("language")+":"+u.Query().Get("address")+u.Query().Get("place_id"))
+		body := verifyBodyRU
+		if strings.HasPrefix(u.Query().Get("language"), "en") {
+			body = verifyBodyEN
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	g, err := NewGeocoder(&BusinessKey{ClientID: "client", SigningKey: "bXlfdGVzdF9rZXk="}, "https://localhost", "de",
+		client, 100, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := g.VerifyAddress(context.TODO(), "Тверская 1, Москва", "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &DualAddress{
+		PlaceID:      "ChIJ",
+		Location:     Coordinate{Lat: 55.757, Lng: 37.613},
+		LocationType: LT_ROOFTOP,
+		Language:     "ru",
+		Display:      "Тверская ул., 1, Москва, Россия",
+		Machine:      "Tverskaya St, 1, Moscow, Russia",
+		Components: []DualComponent{
+			{Types: []string{"street_number"}, Display: "1", Machine: "1", ShortName: "1"},
+			{Types: []string{"route"}, Display: "Тверская улица", Machine: "Tverskaya Street", ShortName: "Tverskaya St"},
+			{Types: []string{"locality", "political"}, Display: "Москва", Machine: "Moscow", ShortName: "Moscow"},
+			{Types: []string{"country", "political"}, Display: "Россия", Machine: "Russia", ShortName: "RU"},
+		},
+	}
+	if !reflect.DeepEqual(addr, expected) {
+		t.Errorf("test for ru Failed - results not match\nGot:\n%+v\nExpected:\n%+v", addr, expected)
+	}
+	expectedQueries := []string{"ru:Тверская 1, Москва", "en:ChIJ"}
+	if !reflect.DeepEqual(queries, expectedQueries) {
+		t.Errorf("test for ru queries Failed - results not match\nGot:\n%v\nExpected:\n%v", queries, expectedQueries)
+	}
+
+	queries = nil
+	addr, err = g.VerifyAddress(context.TODO(), "Tverskaya 1, Moscow", "en-GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.Display != addr.Machine || len(queries) != 1 {
+		t.Errorf("test for en Failed - unexpected address %+v after %v", addr, queries)
+	}
+	if c, ok := addr.Component("locality"); !ok || c.Machine != "Moscow" {
+		t.Errorf("test for Component Failed - results not match\nGot:\n%v\nExpected:\n%v", c.Machine, "Moscow")
+	}
+}
+
+func Test_PreferLatin(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{"Moscow", "Москва", "Moscow"},
+		{"Москва", "Moskva", "Moskva"},
+		{"Москва", "Москва", "Москва"},
+		{"", "Москва", "Москва"},
+		{"Zürich", "", "Zürich"},
+	}
+	for _, tt := range tests {
+		if got := preferLatin(tt.a, tt.b); got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.a, got, tt.expected)
+		}
+	}
+}
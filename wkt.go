@@ -0,0 +1,118 @@
+package geocoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// WKB geometry type codes
+const (
+	wkbPoint        uint32 = 1
+	wkbPolygon      uint32 = 3
+	wkbMultiPolygon uint32 = 6
+)
+
+// WKT renders the coordinate as a WKT point, e.g. POINT(13.4 52.52).
+// WKT uses longitude/latitude (x y) axis order
+func (c Coordinate) WKT() string {
+	return "POINT(" + wktPoint(c) + ")"
+}
+
+// WKB renders the coordinate as a little-endian WKB point
+func (c Coordinate) WKB() []byte {
+	var buf bytes.Buffer
+	wkbHeader(&buf, wkbPoint)
+	wkbPoint2D(&buf, c)
+	return buf.Bytes()
+}
+
+// WKBHex renders the coordinate as a hex encoded WKB point, as accepted by PostGIS
+func (c Coordinate) WKBHex() string {
+	return hex.EncodeToString(c.WKB())
+}
+
+// WKT renders the bounds as a WKT polygon, e.g. a result viewport. Bounds crossing the
+// antimeridian, whose south-west longitude is east of the north-east one, are split at ±180
+// into a MULTIPOLYGON
+func (b Bounds) WKT() string {
+	rings := b.rings()
+	polygons := make([]string, len(rings))
+	for i, ring := range rings {
+		points := make([]string, len(ring))
+		for j, c := range ring {
+			points[j] = wktPoint(c)
+		}
+		polygons[i] = "((" + strings.Join(points, ", ") + "))"
+	}
+	if len(polygons) == 1 {
+		return "POLYGON" + polygons[0]
+	}
+	return "MULTIPOLYGON(" + strings.Join(polygons, ", ") + ")"
+}
+
+// WKB renders the bounds as a little-endian WKB polygon, or multipolygon if they cross the antimeridian
+func (b Bounds) WKB() []byte {
+	rings := b.rings()
+	var buf bytes.Buffer
+	if len(rings) > 1 {
+		wkbHeader(&buf, wkbMultiPolygon)
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(rings)))
+	}
+	for _, ring := range rings {
+		wkbHeader(&buf, wkbPolygon)
+		// a single exterior ring
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(1))
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(ring)))
+		for _, c := range ring {
+			wkbPoint2D(&buf, c)
+		}
+	}
+	return buf.Bytes()
+}
+
+// WKBHex renders the bounds as a hex encoded WKB polygon, as accepted by PostGIS
+func (b Bounds) WKBHex() string {
+	return hex.EncodeToString(b.WKB())
+}
+
+// rings returns the closed counter-clockwise exterior rings of the bounds, two of them split at
+// the antimeridian if the bounds cross it
+func (b Bounds) rings() [][]Coordinate {
+	if b.SouthWest.Lng <= b.NorthEast.Lng {
+		return [][]Coordinate{ring(b.SouthWest, b.NorthEast)}
+	}
+	return [][]Coordinate{
+		ring(b.SouthWest, Coordinate{Lat: b.NorthEast.Lat, Lng: 180}),
+		ring(Coordinate{Lat: b.SouthWest.Lat, Lng: -180}, b.NorthEast),
+	}
+}
+
+// ring returns the closed counter-clockwise ring of the rectangle from sw to ne
+func ring(sw, ne Coordinate) []Coordinate {
+	return []Coordinate{
+		sw,
+		{Lat: sw.Lat, Lng: ne.Lng},
+		ne,
+		{Lat: ne.Lat, Lng: sw.Lng},
+		sw,
+	}
+}
+
+func wktPoint(c Coordinate) string {
+	return strconv.FormatFloat(c.Lng, 'f', -1, 64) + " " + strconv.FormatFloat(c.Lat, 'f', -1, 64)
+}
+
+// Writes to bytes.Buffer never fail, so binary.Write errors are ignored below
+
+func wkbHeader(buf *bytes.Buffer, geometryType uint32) {
+	// 1 stands for little-endian (NDR) byte order
+	buf.WriteByte(1)
+	_ = binary.Write(buf, binary.LittleEndian, geometryType)
+}
+
+func wkbPoint2D(buf *bytes.Buffer, c Coordinate) {
+	_ = binary.Write(buf, binary.LittleEndian, [2]float64{c.Lng, c.Lat})
+}
@@ -0,0 +1,40 @@
+package geocoder
+
+import (
+	"strconv"
+	"testing"
+)
+
+func Test_WKT(t *testing.T) {
+	viewport := Bounds{
+		SouthWest: Coordinate{Lat: 52.5, Lng: 13.25},
+		NorthEast: Coordinate{Lat: 52.75, Lng: 13.5},
+	}
+	// Fiji spans the antimeridian
+	fiji := Bounds{
+		SouthWest: Coordinate{Lat: -21, Lng: 177},
+		NorthEast: Coordinate{Lat: -12.5, Lng: -178},
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"Point WKT", Coordinate{Lat: 52.52, Lng: 13.4}.WKT(), "POINT(13.4 52.52)"},
+		{"Point WKB", Coordinate{Lat: 2, Lng: 1}.WKBHex(), "0101000000000000000000f03f0000000000000040"},
+		{"Polygon WKT", viewport.WKT(), "POLYGON((13.25 52.5, 13.5 52.5, 13.5 52.75, 13.25 52.75, 13.25 52.5))"},
+		{"Polygon WKB", viewport.WKBHex()[:26], "01030000000100000005000000"},
+		{"Antimeridian WKT", fiji.WKT(), "MULTIPOLYGON(((177 -21, 180 -21, 180 -12.5, 177 -12.5, 177 -21)), ((-180 -21, -178 -21, -178 -12.5, -180 -12.5, -180 -21)))"},
+		{"Antimeridian WKB", fiji.WKBHex()[:44], "01060000000200000001030000000100000005000000"},
+		{"Antimeridian WKB size", strconv.Itoa(len(fiji.WKB())), strconv.Itoa(9 + 2*(9+4+5*16))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, tt.got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package workqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// NATSMsg is a JetStream message, implemented by jetstream.Msg of github.com/nats-io/nats.go
+type NATSMsg interface {
+	Data() []byte
+	Ack() error
+	NakWithDelay(delay time.Duration) error
+	Term() error
+}
+
+// NATSFetch returns up to batch messages of a JetStream pull consumer, e.g. by draining
+// Messages of jetstream.Consumer.Fetch(batch)
+type NATSFetch func(ctx context.Context, batch int) ([]NATSMsg, error)
+
+// NATSQueue is a Queue on a JetStream pull consumer. Nack asks for redelivery after the delay, the MaxDeliver
+// setting of the consumer bounds the number of deliveries of failing messages. Reject
+// terminates the message, which is never redelivered
+type NATSQueue struct {
+	fetch NATSFetch
+	batch int
+}
+
+var _ Queue = (*NATSQueue)(nil)
+
+// NewNATSQueue creates new instance of NATSQueue fetching batch messages at once
+func NewNATSQueue(fetch NATSFetch, batch int) (*NATSQueue, error) {
+	if fetch == nil {
+		return nil, errors.New("empty NATSFetch")
+	}
+	if batch <= 0 {
+		return nil, errors.New("batch must be a positive number")
+	}
+	return &NATSQueue{fetch: fetch, batch: batch}, nil
+}
+
+func (q *NATSQueue) Receive(ctx context.Context) ([]Delivery, error) {
+	msgs, err := q.fetch(ctx, q.batch)
+	if err != nil {
+		return nil, err
+	}
+	batch := make([]Delivery, 0, len(msgs))
+	for _, m := range msgs {
+		batch = append(batch, Delivery{Body: m.Data(), Opaque: m})
+	}
+	return batch, nil
+}
+
+func (q *NATSQueue) Ack(ctx context.Context, d Delivery) error {
+	return d.Opaque.(NATSMsg).Ack()
+}
+
+func (q *NATSQueue) Nack(ctx context.Context, d Delivery, delay time.Duration) error {
+	return d.Opaque.(NATSMsg).NakWithDelay(delay)
+}
+
+func (q *NATSQueue) Reject(ctx context.Context, d Delivery) error {
+	return d.Opaque.(NATSMsg).Term()
+}
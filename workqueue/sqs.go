@@ -0,0 +1,90 @@
+package workqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// maxSQSMessages is the largest number of messages SQS returns per receive
+	maxSQSMessages = 10
+	// maxSQSWaitSeconds is the longest SQS long polling wait
+	maxSQSWaitSeconds = 20
+	// maxSQSVisibilitySeconds is the longest SQS visibility timeout
+	maxSQSVisibilitySeconds = 12 * 60 * 60
+)
+
+// SQSMessage is a received SQS message
+type SQSMessage struct {
+	Body          string
+	ReceiptHandle string
+}
+
+// SQSAPI is the part of an SQS client SQSQueue needs. With github.com/aws/aws-sdk-go-v2 each
+// method is a single call of sqs.Client, e.g. ReceiveMessage with MaxNumberOfMessages and
+// WaitTimeSeconds set, mapping types.Message.Body and ReceiptHandle
+type SQSAPI interface {
+	ReceiveMessages(ctx context.Context, queueURL string, maxMessages, waitSeconds int) ([]SQSMessage, error)
+	DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error
+	ChangeMessageVisibility(ctx context.Context, queueURL, receiptHandle string, timeoutSeconds int) error
+	SendMessage(ctx context.Context, queueURL, body string) error
+}
+
+// SQSQueue is a Queue on an SQS queue. Nack makes the message visible again after the delay,
+// rounded up to seconds and at most 12 hours, a redrive policy of the queue bounds the number of deliveries of failing messages
+type SQSQueue struct {
+	api      SQSAPI
+	queueURL string
+	// Messages received at once, up to 10
+	MaxMessages int
+	// Long polling wait of Receive in seconds, up to 20
+	WaitSeconds int
+	// Rejected messages are sent to this queue before they are deleted, they are just deleted if empty
+	DeadLetterQueueURL string
+}
+
+var _ Queue = (*SQSQueue)(nil)
+
+// NewSQSQueue creates new instance of SQSQueue receiving 10 messages at once with 20 seconds long polling
+func NewSQSQueue(api SQSAPI, queueURL string) (*SQSQueue, error) {
+	if api == nil {
+		return nil, errors.New("empty SQSAPI")
+	}
+	if queueURL == "" {
+		return nil, errors.New("empty queue URL")
+	}
+	return &SQSQueue{api: api, queueURL: queueURL, MaxMessages: maxSQSMessages, WaitSeconds: maxSQSWaitSeconds}, nil
+}
+
+func (q *SQSQueue) Receive(ctx context.Context) ([]Delivery, error) {
+	maxMessages := min(max(q.MaxMessages, 1), maxSQSMessages)
+	waitSeconds := min(max(q.WaitSeconds, 0), maxSQSWaitSeconds)
+	msgs, err := q.api.ReceiveMessages(ctx, q.queueURL, maxMessages, waitSeconds)
+	if err != nil {
+		return nil, err
+	}
+	batch := make([]Delivery, 0, len(msgs))
+	for _, m := range msgs {
+		batch = append(batch, Delivery{Body: []byte(m.Body), Opaque: m.ReceiptHandle})
+	}
+	return batch, nil
+}
+
+func (q *SQSQueue) Ack(ctx context.Context, d Delivery) error {
+	return q.api.DeleteMessage(ctx, q.queueURL, d.Opaque.(string))
+}
+
+func (q *SQSQueue) Nack(ctx context.Context, d Delivery, delay time.Duration) error {
+	seconds := min(int((max(delay, 0)+time.Second-1)/time.Second), maxSQSVisibilitySeconds)
+	return q.api.ChangeMessageVisibility(ctx, q.queueURL, d.Opaque.(string), seconds)
+}
+
+func (q *SQSQueue) Reject(ctx context.Context, d Delivery) error {
+	if q.DeadLetterQueueURL != "" {
+		if err := q.api.SendMessage(ctx, q.DeadLetterQueueURL, string(d.Body)); err != nil {
+			return err
+		}
+	}
+	return q.Ack(ctx, d)
+}
@@ -0,0 +1,59 @@
+package workqueue
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// sqsAPI records the calls of SQSQueue
+type sqsAPI struct {
+	messages []SQSMessage
+	calls    []string
+}
+
+func (a *sqsAPI) ReceiveMessages(ctx context.Context, queueURL string, maxMessages, waitSeconds int) ([]SQSMessage, error) {
+	a.calls = append(a.calls, "receive "+queueURL)
+	n := min(maxMessages, len(a.messages))
+	msgs := a.messages[:n]
+	a.messages = a.messages[n:]
+	return msgs, nil
+}
+
+func (a *sqsAPI) DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error {
+	a.calls = append(a.calls, "delete "+receiptHandle)
+	return nil
+}
+
+func (a *sqsAPI) ChangeMessageVisibility(ctx context.Context, queueURL, receiptHandle string, timeoutSeconds int) error {
+	a.calls = append(a.calls, "release "+receiptHandle+" "+strconv.Itoa(timeoutSeconds))
+	return nil
+}
+
+func (a *sqsAPI) SendMessage(ctx context.Context, queueURL, body string) error {
+	a.calls = append(a.calls, "send "+queueURL+" "+body)
+	return nil
+}
+
+func Test_SQSQueue(t *testing.T) {
+	api := &sqsAPI{messages: []SQSMessage{{Body: "a", ReceiptHandle: "r1"}, {Body: "b", ReceiptHandle: "r2"}, {Body: "c", ReceiptHandle: "r3"}}}
+	q, err := NewSQSQueue(api, "jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.DeadLetterQueueURL = "dlq"
+	batch, err := q.Receive(context.TODO())
+	if err != nil || len(batch) != 3 {
+		t.Fatalf("test for receive Failed - unexpected %v %v", batch, err)
+	}
+	q.Ack(context.TODO(), batch[0])
+	q.Nack(context.TODO(), batch[1], 1500*time.Millisecond)
+	q.Reject(context.TODO(), batch[2])
+
+	expected := []string{"receive jobs", "delete r1", "release r2 2", "send dlq c", "delete r3"}
+	if !reflect.DeepEqual(api.calls, expected) {
+		t.Errorf("test for SQS calls Failed - results not match\nGot:\n%v\nExpected:\n%v", api.calls, expected)
+	}
+}
@@ -0,0 +1,235 @@
+// Package workqueue geocodes jobs pulled from a work queue, like Amazon SQS or NATS JetStream,
+// through geocoder.BatchProcessor. Every job is acknowledged once handled, handed back to the
+// queue for redelivery after transient failures, or rejected as poison if it can never succeed,
+// e.g. invalid JSON or coordinates out of range. It doesn't depend on a queue client, SQSQueue
+// and NATSQueue adapt the clients through small interfaces
+package workqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alvillain/geocoder"
+)
+
+// Delivery is a job message received from a queue
+type Delivery struct {
+	Body []byte
+	// Queue specific message passed back to Ack, Nack and Reject
+	Opaque any
+}
+
+// Queue is a work queue with per-message acknowledgement
+type Queue interface {
+	// Receive waits for the next messages, it returns an empty batch if there are none for now
+	Receive(ctx context.Context) ([]Delivery, error)
+	// Ack removes a handled message from the queue
+	Ack(ctx context.Context, d Delivery) error
+	// Nack makes the message available for redelivery once delay has passed
+	Nack(ctx context.Context, d Delivery, delay time.Duration) error
+	// Reject removes a poison message which will never succeed, or moves it to a dead letter queue
+	Reject(ctx context.Context, d Delivery) error
+}
+
+// Job is the JSON body of a message. Address is geocoded if set, the coordinate is reverse
+// geocoded otherwise
+type Job struct {
+	ID      string   `json:"id,omitempty"`
+	Address string   `json:"address,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lng     *float64 `json:"lng,omitempty"`
+}
+
+// ErrPoison is wrapped by errors of jobs which can never succeed
+var ErrPoison = errors.New("poison message")
+
+// defaultRetryDelay is the delay before a job handed back to the queue is redelivered
+const defaultRetryDelay = 30 * time.Second
+
+// Options of Worker
+type Options struct {
+	// Jobs geocoded at once, 1 if zero
+	Concurrency int
+	// Called with every geocoded job. The message is acknowledged if it returns nil and handed
+	// back to the queue otherwise, required
+	Handle func(ctx context.Context, job Job, res *geocoder.GoogleResponse) error
+	// Delay before a job handed back to the queue is redelivered, 30 seconds if zero. A longer
+	// Retry-After of a geocoder.HTTPError wins. Redelivering right away would spin on errors like
+	// geocoder.ErrQuotaExhausted or geocoder.ErrCircuitOpen and use up the deliveries of the job
+	RetryDelay time.Duration
+	// Called with the body of every rejected message and the ErrPoison error, optional
+	OnPoison func(body []byte, err error)
+	// Called with errors of Ack, Nack and Reject, which don't stop the worker, optional
+	OnError func(error)
+}
+
+// Worker pulls jobs from a Queue and geocodes them
+type Worker struct {
+	geocoder geocoder.ReverseGeocoder
+	queue    Queue
+	opts     Options
+}
+
+// NewWorker creates new instance of Worker. Address jobs require rg to implement
+// geocoder.ForwardGeocoder, as geocoder.Geocoder does
+func NewWorker(rg geocoder.ReverseGeocoder, queue Queue, opts Options) (*Worker, error) {
+	if rg == nil {
+		return nil, errors.New("empty ReverseGeocoder")
+	}
+	if queue == nil {
+		return nil, errors.New("empty Queue")
+	}
+	if opts.Handle == nil {
+		return nil, errors.New("empty Handle")
+	}
+	if opts.Concurrency < 0 {
+		return nil, errors.New("concurrency must not be negative")
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = 1
+	}
+	if opts.RetryDelay < 0 {
+		return nil, errors.New("retry delay must not be negative")
+	}
+	if opts.RetryDelay == 0 {
+		opts.RetryDelay = defaultRetryDelay
+	}
+	return &Worker{geocoder: rg, queue: queue, opts: opts}, nil
+}
+
+// Run processes jobs until ctx is done or Receive fails and returns the error. Messages
+// in flight when it returns are redelivered by the queue
+func (w *Worker) Run(ctx context.Context) error {
+	processor, err := geocoder.NewBatchProcessor(w.geocoder, w.opts.Concurrency)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	inflight := make(map[string]inflightJob)
+	items := make(chan geocoder.BatchItem)
+	receiveErr := make(chan error, 1)
+
+	go func() {
+		defer close(items)
+		for seq := 0; ; {
+			batch, err := w.queue.Receive(ctx)
+			if err != nil {
+				receiveErr <- err
+				return
+			}
+			for _, d := range batch {
+				job, err := decodeJob(d.Body)
+				if err != nil {
+					w.reject(ctx, d, err)
+					continue
+				}
+				id := strconv.Itoa(seq)
+				seq++
+				mu.Lock()
+				inflight[id] = inflightJob{job: job, delivery: d}
+				mu.Unlock()
+
+				item := geocoder.BatchItem{ID: id, Address: job.Address}
+				if job.Address == "" {
+					item.Lat, item.Lng = *job.Lat, *job.Lng
+				}
+				select {
+				case items <- item:
+				case <-ctx.Done():
+					receiveErr <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	err = processor.Process(ctx, items, func(r geocoder.BatchResult) error {
+		mu.Lock()
+		in := inflight[r.Item.ID]
+		delete(inflight, r.Item.ID)
+		mu.Unlock()
+		w.settle(ctx, in, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return <-receiveErr
+}
+
+// inflightJob is a decoded job with its message
+type inflightJob struct {
+	job      Job
+	delivery Delivery
+}
+
+// settle acknowledges, hands back or rejects the message of a geocoded job
+func (w *Worker) settle(ctx context.Context, in inflightJob, r geocoder.BatchResult) {
+	switch {
+	case ctx.Err() != nil:
+		// the queue redelivers the message after its visibility timeout
+	case r.Err != nil && permanent(r.Err):
+		w.reject(ctx, in.delivery, r.Err)
+	case r.Err != nil:
+		w.report(w.queue.Nack(ctx, in.delivery, w.retryDelay(r.Err)))
+	case w.opts.Handle(ctx, in.job, r.Response) != nil:
+		w.report(w.queue.Nack(ctx, in.delivery, w.opts.RetryDelay))
+	default:
+		w.report(w.queue.Ack(ctx, in.delivery))
+	}
+}
+
+// retryDelay returns the delay before a job failed with err is redelivered
+func (w *Worker) retryDelay(err error) time.Duration {
+	var httpErr *geocoder.HTTPError
+	if errors.As(err, &httpErr) {
+		return max(w.opts.RetryDelay, httpErr.RetryAfter)
+	}
+	return w.opts.RetryDelay
+}
+
+// reject removes a poison message from the queue
+func (w *Worker) reject(ctx context.Context, d Delivery, err error) {
+	if !errors.Is(err, ErrPoison) {
+		err = errors.Join(ErrPoison, err)
+	}
+	if w.opts.OnPoison != nil {
+		w.opts.OnPoison(d.Body, err)
+	}
+	w.report(w.queue.Reject(ctx, d))
+}
+
+func (w *Worker) report(err error) {
+	if err != nil && w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
+
+// decodeJob decodes and checks the body of a message, errors wrap ErrPoison
+func decodeJob(body []byte) (Job, error) {
+	var job Job
+	if err := json.Unmarshal(body, &job); err != nil {
+		return job, errors.Join(ErrPoison, err)
+	}
+	job.Address = strings.TrimSpace(job.Address)
+	if job.Address == "" && (job.Lat == nil || job.Lng == nil) {
+		return job, errors.Join(ErrPoison, errors.New("address or lat and lng are required"))
+	}
+	return job, nil
+}
+
+// permanent reports whether a geocoding error is final for the job, whatever the number of attempts
+func permanent(err error) bool {
+	return errors.Is(err, geocoder.ErrInvalidCoordinates) ||
+		errors.Is(err, geocoder.ErrInvalidRequest) ||
+		errors.Is(err, geocoder.ErrZeroResults) ||
+		errors.Is(err, geocoder.ErrInvalidPlusCode)
+}
@@ -0,0 +1,115 @@
+package workqueue
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alvillain/geocoder"
+	"github.com/alvillain/geocoder/geocodertest"
+)
+
+// natsMsg records how it was settled
+type natsMsg struct {
+	data    string
+	mu      *sync.Mutex
+	settled map[string]string
+}
+
+func (m natsMsg) Data() []byte { return []byte(m.data) }
+func (m natsMsg) Ack() error   { return m.settle("ack") }
+func (m natsMsg) Term() error  { return m.settle("term") }
+
+func (m natsMsg) NakWithDelay(delay time.Duration) error {
+	return m.settle("nak " + delay.String())
+}
+
+func (m natsMsg) settle(how string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settled[m.data] = how
+	return nil
+}
+
+func Test_WorkerNATS(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"reverse", `{"id":"a","lat":52.52,"lng":13.405}`, "ack"},
+		{"forward", `{"id":"b","address":"Paris"}`, "ack"},
+		{"invalid json", `{"id":`, "term"},
+		{"missing coordinates", `{"id":"c","lat":1}`, "term"},
+		{"invalid coordinates", `{"id":"d","lat":91,"lng":0}`, "term"},
+		{"transient error", `{"id":"e","lat":3,"lng":3}`, "nak 30s"},
+		{"quota exhausted", `{"id":"q","lat":5,"lng":5}`, "nak 30s"},
+		{"retry after", `{"id":"r","lat":6,"lng":6}`, "nak 2m0s"},
+		{"handler error", `{"id":"h","lat":4,"lng":4}`, "nak 30s"},
+	}
+	mock := geocodertest.NewMock().
+		OnReverseGeocode(91, 0, nil, geocoder.ErrInvalidCoordinates).
+		OnReverseGeocode(3, 3, nil, &geocoder.HTTPError{StatusCode: http.StatusServiceUnavailable}).
+		OnReverseGeocode(5, 5, nil, geocoder.ErrQuotaExhausted).
+		OnReverseGeocode(6, 6, nil, &geocoder.HTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Minute})
+
+	var mu sync.Mutex
+	settled := make(map[string]string)
+	var msgs []NATSMsg
+	for _, tt := range tests {
+		msgs = append(msgs, natsMsg{data: tt.body, mu: &mu, settled: settled})
+	}
+	fetched := false
+	queue, err := NewNATSQueue(func(ctx context.Context, batch int) ([]NATSMsg, error) {
+		if fetched {
+			return nil, io.EOF
+		}
+		fetched = true
+		return msgs, nil
+	}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handled, poison []string
+	w, err := NewWorker(mock, queue, Options{
+		Concurrency: 3,
+		Handle: func(ctx context.Context, job Job, res *geocoder.GoogleResponse) error {
+			if job.ID == "h" {
+				return errors.New("store unavailable")
+			}
+			handled = append(handled, job.ID)
+			return nil
+		},
+		OnPoison: func(body []byte, err error) {
+			if !errors.Is(err, ErrPoison) {
+				t.Errorf("test for poison error Failed - %v doesn't wrap ErrPoison", err)
+			}
+			poison = append(poison, string(body))
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Run(context.TODO()); !errors.Is(err, io.EOF) {
+		t.Errorf("test for run Failed - results not match\nGot:\n%v\nExpected:\n%v", err, io.EOF)
+	}
+
+	for _, tt := range tests {
+		if got := settled[tt.body]; got != tt.expected {
+			t.Errorf("test for %v Failed - results not match\nGot:\n%v\nExpected:\n%v", tt.name, got, tt.expected)
+		}
+	}
+	sort.Strings(handled)
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(handled, expected) {
+		t.Errorf("test for handled jobs Failed - results not match\nGot:\n%v\nExpected:\n%v", handled, expected)
+	}
+	if len(poison) != 3 {
+		t.Errorf("test for poison messages Failed - results not match\nGot:\n%v\nExpected:\n%v", len(poison), 3)
+	}
+}